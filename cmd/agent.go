@@ -15,8 +15,10 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strconv"
@@ -159,17 +161,34 @@ var agentCompileCmd = &cobra.Command{
 // ---- agent execution (search history) ----
 
 var (
-	execName   string
-	execStatus string
-	execSince  string
-	execWindow string
+	execName     string
+	execStatus   string
+	execSince    string
+	execWindow   string
+	execQuery    string
+	execFreeText string
+	execSort     string
+	execPage     int
+	execSize     int
+	execAll      bool
+	execNDJSON   bool
 )
 
+// executionSortFields are the fields --sort accepts, as the search API names them.
+var executionSortFields = []string{"startTime", "endTime", "updateTime", "executionTime", "status", "workflowType"}
+
 var agentExecutionCmd = &cobra.Command{
 	Use:   "execution",
 	Short: "Search agent execution history",
 	Long: `Search agent execution history with optional filters.
 
+Results come a page at a time (--page, --size); --all walks every page, writing
+rows as they arrive. --query takes a Conductor search expression, e.g.
+"status IN (FAILED,TIMED_OUT)", ANDed with the other filters; --free-text
+searches execution contents. --sort takes field[:asc|desc] (ascending by
+default) over ` + strings.Join(executionSortFields, ", ") + `; results are newest first
+otherwise. --ndjson prints one JSON execution per line for other tools.
+
 Time formats for --since and --window: 30s, 5m, 1h, 6h, 1d, 7d, 1mo, 1y`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -177,26 +196,110 @@ Time formats for --since and --window: 30s, 5m, 1h, 6h, 1d, 7d, 1mo, 1y`,
 		if err != nil {
 			return err
 		}
+		if execNDJSON && format != OutputFormatTable {
+			return fmt.Errorf("--ndjson cannot be combined with --json or --csv")
+		}
+		if execPage < 1 || execSize < 1 {
+			return fmt.Errorf("--page and --size must be at least 1")
+		}
 		from, to, err := buildExecutionWindow(execSince, execWindow)
 		if err != nil {
 			return err
 		}
-		page, err := internal.GetAgentService().SearchExecutions(cmd.Context(), agent.ExecutionFilter{
+		order, err := parseExecutionSort(execSort)
+		if err != nil {
+			return err
+		}
+		filter := agent.ExecutionFilter{
 			AgentName:     execName,
 			Status:        execStatus,
 			StartTimeFrom: from,
 			StartTimeTo:   to,
-			Start:         0,
-			Size:          defaultExecutionSearchSize,
-		})
+			Query:         execQuery,
+			FreeText:      execFreeText,
+			Sort:          order,
+			Start:         (execPage - 1) * execSize,
+			Size:          execSize,
+		}
+
+		svc := internal.GetAgentService()
+		if execAll {
+			filter.Start = 0
+			return streamExecutions(cmd.Context(), svc, filter, format, execNDJSON)
+		}
+		page, err := svc.SearchExecutions(cmd.Context(), filter)
 		if err != nil {
 			return err
 		}
-		return renderExecutionPage(page, format)
+		if execNDJSON {
+			return writeExecutionsNDJSON(os.Stdout, page.Results)
+		}
+		return renderExecutionPage(page, format, filter)
 	},
 }
 
-func renderExecutionPage(page agent.ExecutionPage, format OutputFormat) error {
+// parseExecutionSort turns --sort field[:asc|desc] into the search API's
+// "field:ASC" form; "" keeps the default order.
+func parseExecutionSort(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	field, dir, hasDir := strings.Cut(s, ":")
+	dir = strings.ToUpper(dir)
+	if !hasDir {
+		dir = "ASC"
+	}
+	if dir != "ASC" && dir != "DESC" {
+		return "", fmt.Errorf("invalid --sort direction %q: use asc or desc", dir)
+	}
+	for _, f := range executionSortFields {
+		if strings.EqualFold(f, field) {
+			return f + ":" + dir, nil
+		}
+	}
+	return "", fmt.Errorf("invalid --sort field %q: use one of %s", field, strings.Join(executionSortFields, ", "))
+}
+
+// streamExecutions is --all: it walks every page, writing NDJSON and CSV rows as
+// they arrive. The table and JSON array need every row before they can print.
+func streamExecutions(ctx context.Context, svc agent.Service, filter agent.ExecutionFilter, format OutputFormat, ndjson bool) error {
+	switch {
+	case ndjson:
+		enc := json.NewEncoder(os.Stdout)
+		return svc.EachExecution(ctx, filter, func(e agent.ExecutionSummary) error { return enc.Encode(e) })
+	case format == OutputFormatCSV:
+		w := NewCSVWriter()
+		w.WriteHeader("ID", "AGENT", "STATUS", "START_TIME", "DURATION")
+		return svc.EachExecution(ctx, filter, func(e agent.ExecutionSummary) error {
+			w.WriteRow(e.ExecutionID, e.AgentName, e.Status, truncateTimestamp(e.StartTime), formatMillis(e.ExecutionTime))
+			w.Flush()
+			return nil
+		})
+	}
+	all := agent.ExecutionPage{Results: []agent.ExecutionSummary{}}
+	if err := svc.EachExecution(ctx, filter, func(e agent.ExecutionSummary) error {
+		all.Results = append(all.Results, e)
+		return nil
+	}); err != nil {
+		return err
+	}
+	all.TotalHits = int64(len(all.Results))
+	return renderExecutionPage(all, format, agent.ExecutionFilter{Size: len(all.Results)})
+}
+
+func writeExecutionsNDJSON(w io.Writer, executions []agent.ExecutionSummary) error {
+	enc := json.NewEncoder(w)
+	for _, e := range executions {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderExecutionPage prints one page of results; filter says which page it is,
+// for the table's footer.
+func renderExecutionPage(page agent.ExecutionPage, format OutputFormat, filter agent.ExecutionFilter) error {
 	switch format {
 	case OutputFormatJSON:
 		data, err := json.MarshalIndent(page, "", "  ")
@@ -223,24 +326,54 @@ func renderExecutionPage(page agent.ExecutionPage, format OutputFormat) error {
 				e.ExecutionID, e.AgentName, e.Status, truncateTimestamp(e.StartTime), formatMillis(e.ExecutionTime))
 		}
 		w.Flush()
-		fmt.Printf("\n%d of %d execution(s).\n", len(page.Results), page.TotalHits)
+		fmt.Println()
+		fmt.Println(executionPageFooter(page, filter))
 	}
 	return nil
 }
 
+// executionPageFooter summarizes where a page sits in the full result set.
+func executionPageFooter(page agent.ExecutionPage, filter agent.ExecutionFilter) string {
+	if filter.Start == 0 && int64(len(page.Results)) >= page.TotalHits {
+		return fmt.Sprintf("%d of %d execution(s).", len(page.Results), page.TotalHits)
+	}
+	first := filter.Start + 1
+	last := filter.Start + len(page.Results)
+	s := fmt.Sprintf("%d-%d of %d execution(s).", first, last, page.TotalHits)
+	if int64(filter.Start+filter.Size) < page.TotalHits {
+		s += fmt.Sprintf(" Next page: --page %d, or --all for every page.", filter.Start/filter.Size+2)
+	}
+	return s
+}
+
 // ---- agent status ----
 
+var agentStatusJSON bool
+
 var agentStatusCmd = &cobra.Command{
-	Use:          "status <execution-id>",
-	Short:        "Get detailed status of an execution",
+	Use:   "status <execution-id>",
+	Short: "Get detailed status of an execution",
+	Long: `Show an execution's status: a header with its duration and token usage, a
+timeline of its turns with the tools each one called, the sub-agents it ran,
+pending human tasks, a Gantt chart of its tasks and the final output. --json
+prints the server's status detail unchanged, for scripts.`,
 	Args:         cobra.ExactArgs(1),
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		detail, err := internal.GetAgentService().Status(cmd.Context(), args[0])
+		svc := internal.GetAgentService()
+		if agentStatusJSON {
+			detail, err := svc.Status(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return printRawJSON(detail)
+		}
+		tree, err := fetchStatusTree(cmd.Context(), svc, args[0], maxSubAgentDepth)
 		if err != nil {
 			return err
 		}
-		return printRawJSON(detail)
+		renderExecutionStatus(os.Stdout, tree, time.Now(), terminalWidth())
+		return nil
 	},
 }
 
@@ -280,21 +413,54 @@ var (
 	pruneOlderThan int
 	pruneArchive   bool
 	pruneDryRun    bool
+	pruneName      string
+	pruneStatus    string
 )
 
 var agentPruneCmd = &cobra.Command{
-	Use:          "prune",
-	Short:        "Delete (or archive) old execution records",
+	Use:   "prune",
+	Short: "Delete (or archive) old execution records",
+	Long: `Delete (or archive) terminal executions older than --older-than days. Prune is
+irreversible, so it first counts what matches and asks for confirmation (skip
+with --yes).
+
+--dry-run counts the matching executions per agent, with a few IDs of each,
+without deleting anything; --name and --status narrow the listing. The server
+prunes every agent's terminal executions, so those filters only apply to a dry
+run.`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if pruneDryRun {
-			fmt.Printf("Dry run: would prune executions older than %d day(s) (archive=%t).\n", pruneOlderThan, pruneArchive)
-			return nil
+		if !pruneDryRun && (pruneName != "" || pruneStatus != "") {
+			return fmt.Errorf("--name and --status only apply with --dry-run: the server prunes every agent's terminal executions")
 		}
-		res, err := internal.GetAgentService().Prune(cmd.Context(), agent.PruneRequest{
+		req := agent.PruneRequest{
 			OlderThanDays: pruneOlderThan,
 			Archive:       pruneArchive,
-		})
+			AgentName:     pruneName,
+			Status:        pruneStatus,
+		}
+		svc := internal.GetAgentService()
+		plan, err := svc.PlanPrune(cmd.Context(), req, time.Now())
+		if err != nil {
+			return err
+		}
+		if pruneDryRun {
+			printPrunePlan(os.Stdout, plan, pruneArchive)
+			return nil
+		}
+		if plan.Total == 0 {
+			fmt.Printf("No executions older than %d day(s) to prune.\n", pruneOlderThan)
+			return nil
+		}
+		action := "Delete"
+		if pruneArchive {
+			action = "Archive"
+		}
+		if !yes && !confirm(fmt.Sprintf("%s %d execution record(s) older than %d day(s)? This cannot be undone.", action, plan.Total, pruneOlderThan)) {
+			fmt.Println("Prune cancelled.")
+			return nil
+		}
+		res, err := svc.Prune(cmd.Context(), agent.PruneRequest{OlderThanDays: pruneOlderThan, Archive: pruneArchive})
 		if err != nil {
 			return err
 		}
@@ -303,6 +469,33 @@ var agentPruneCmd = &cobra.Command{
 	},
 }
 
+// printPrunePlan renders a dry run: the total, then each agent's count with a
+// sample of its execution IDs.
+func printPrunePlan(w io.Writer, plan agent.PrunePlan, archive bool) {
+	action := "delete"
+	if archive {
+		action = "archive"
+	}
+	if plan.Total == 0 {
+		fmt.Fprintf(w, "Dry run: no executions started before %s to %s.\n", plan.Cutoff.Format(time.RFC3339), action)
+		return
+	}
+	fmt.Fprintf(w, "Dry run: would %s %d execution(s) started before %s.\n\n", action, plan.Total, plan.Cutoff.Format(time.RFC3339))
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "AGENT\tCOUNT\tSAMPLE IDS")
+	for _, a := range plan.Agents {
+		sample := strings.Join(a.SampleIDs, ", ")
+		switch {
+		case len(a.SampleIDs) == 0:
+			sample = "-"
+		case a.Count > len(a.SampleIDs):
+			sample += ", ..."
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", a.AgentName, a.Count, sample)
+	}
+	tw.Flush()
+}
+
 // ---- agent init (local scaffolding; no server) ----
 
 var (
@@ -469,8 +662,18 @@ func init() {
 	agentExecutionCmd.Flags().StringVar(&execStatus, "status", "", "Filter by status (RUNNING, COMPLETED, FAILED, ...)")
 	agentExecutionCmd.Flags().StringVar(&execSince, "since", "", "Show executions since (e.g. 30m, 1h, 1d)")
 	agentExecutionCmd.Flags().StringVar(&execWindow, "window", "", "Time window (e.g. now-1h, now-7d)")
+	agentExecutionCmd.Flags().StringVar(&execQuery, "query", "", "Conductor search expression, ANDed with the other filters")
+	agentExecutionCmd.Flags().StringVar(&execFreeText, "free-text", "", "Free-text search over execution contents")
+	agentExecutionCmd.Flags().StringVar(&execSort, "sort", "", "Sort by field[:asc|desc] (default: newest first)")
+	agentExecutionCmd.Flags().IntVar(&execPage, "page", 1, "Page number, starting at 1")
+	agentExecutionCmd.Flags().IntVar(&execSize, "size", defaultExecutionSearchSize, "Executions per page")
+	agentExecutionCmd.Flags().BoolVar(&execAll, "all", false, "Fetch every page")
+	agentExecutionCmd.Flags().BoolVar(&execNDJSON, "ndjson", false, "Output one JSON execution per line")
+	agentExecutionCmd.MarkFlagsMutuallyExclusive("all", "page")
 	AddOutputFlags(agentExecutionCmd)
 
+	agentStatusCmd.Flags().BoolVar(&agentStatusJSON, "json", false, "Print the raw status detail as JSON")
+
 	agentRespondCmd.Flags().BoolVar(&respondApprove, "approve", false, "Approve the pending action")
 	agentRespondCmd.Flags().BoolVar(&respondDeny, "deny", false, "Deny the pending action")
 	agentRespondCmd.Flags().StringVar(&respondReason, "reason", "", "Reason for the decision")
@@ -480,6 +683,8 @@ func init() {
 	agentPruneCmd.Flags().IntVar(&pruneOlderThan, "older-than", defaultPruneOlderThanDays, "Delete executions older than N days")
 	agentPruneCmd.Flags().BoolVar(&pruneArchive, "archive", false, "Archive tasks instead of hard-deleting")
 	agentPruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Show what would be pruned without deleting")
+	agentPruneCmd.Flags().StringVar(&pruneName, "name", "", "With --dry-run, only list this agent's executions")
+	agentPruneCmd.Flags().StringVar(&pruneStatus, "status", "", "With --dry-run, only list executions with this terminal status")
 
 	agentInitCmd.Flags().StringVarP(&initModel, "model", "", "", "LLM model (default: "+defaultInitModel+")")
 	agentInitCmd.Flags().StringVarP(&initStrategy, "strategy", "s", "", "Multi-agent strategy (handoff, sequential, parallel, ...)")
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/batch"
+)
+
+// batchResultsSuffix replaces the prompts file's extension to name the default
+// results file.
+const batchResultsSuffix = ".results.jsonl"
+
+var (
+	runBatch            string
+	runBatchConcurrency int
+	runBatchResults     string
+	runBatchTimeout     time.Duration
+)
+
+// runAgentBatch is agent run --batch: one execution per line of the prompts file,
+// tracked to completion, with one JSON line per result written as each finishes.
+func runAgentBatch(cmd *cobra.Command, base agent.RunRequest) (err error) {
+	f, err := os.Open(runBatch)
+	if err != nil {
+		return fmt.Errorf("open batch: %w", err)
+	}
+	items, err := batch.Parse(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", runBatch, err)
+	}
+
+	resultsPath := runBatchResults
+	if resultsPath == "" {
+		resultsPath = defaultBatchResults(runBatch)
+	}
+	out, err := os.Create(resultsPath)
+	if err != nil {
+		return fmt.Errorf("create results: %w", err)
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	var writeErr error
+	enc := json.NewEncoder(out)
+	runner := &batch.Runner{
+		Service:     internal.GetAgentService(),
+		Concurrency: runBatchConcurrency,
+		Timeout:     runBatchTimeout,
+		Approve:     runAutoApprove,
+		OnResult: func(r batch.Result) {
+			if err := enc.Encode(r); err != nil && writeErr == nil {
+				writeErr = fmt.Errorf("write results: %w", err)
+			}
+			printBatchResult(os.Stdout, r)
+		},
+	}
+	fmt.Printf("Running %d prompt(s) from %s\n\n", len(items), runBatch)
+	start := time.Now()
+	results := runner.Run(ctx, base, items)
+	if writeErr != nil {
+		return writeErr
+	}
+
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	fmt.Printf("\n%s in %s. Results: %s\n", batchSummary(counts), time.Since(start).Round(time.Millisecond), resultsPath)
+	if ok := counts[batch.StatusCompleted]; ok != len(results) {
+		return fmt.Errorf("%d of %d execution(s) did not complete", len(results)-ok, len(results))
+	}
+	return nil
+}
+
+// defaultBatchResults names the results file after the prompts file.
+func defaultBatchResults(prompts string) string {
+	return strings.TrimSuffix(prompts, filepath.Ext(prompts)) + batchResultsSuffix
+}
+
+func printBatchResult(w io.Writer, r batch.Result) {
+	label := fmt.Sprintf("line %d", r.Line)
+	if r.ID != "" {
+		label = r.ID
+	}
+	fmt.Fprintf(w, "%-11s %s", r.Status, label)
+	if r.ExecutionID != "" {
+		fmt.Fprintf(w, " (%s, %s)", r.ExecutionID, r.Duration.Round(time.Millisecond))
+	}
+	fmt.Fprintln(w)
+	if r.Error != "" {
+		fmt.Fprintf(w, "            %s\n", r.Error)
+	}
+}
+
+// batchSummary lists the status counts, completed first and the rest in a fixed order.
+func batchSummary(counts map[string]int) string {
+	var parts []string
+	for _, s := range []string{
+		batch.StatusCompleted, batch.StatusFailed, batch.StatusTerminated, batch.StatusTimedOut,
+		batch.StatusWaiting, batch.StatusNotStarted, batch.StatusCancelled,
+	} {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s], strings.ToLower(s)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// checkBatchFlags rejects the single-execution flags --batch cannot honour.
+func checkBatchFlags(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("--batch reads prompts from the file; do not pass a prompt")
+	}
+	for _, name := range []string{"no-stream", "tui", "record", "transcript", "output", "session", "serve-tools"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s cannot be combined with --batch", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/bundle"
+)
+
+const defaultBundleFile = "bundle.tgz"
+
+// ---- agent export ----
+
+var exportOutput string
+
+var agentExportCmd = &cobra.Command{
+	Use:   "export <name>...",
+	Short: "Bundle agents and their dependencies into a file",
+	Long: `Write the named agents to a bundle (.tgz) along with everything they need on
+another server: agents they reference, the task definitions of their tools and
+the workflows they start as sub-workflows. A manifest lists the definitions with
+their versions in dependency order. Recreate them with 'conductor agent import'.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src := newServerBundle()
+		b, notes, err := bundle.Export(cmd.Context(), src, args)
+		if err != nil {
+			return err
+		}
+		b.Source = internal.Transport().BaseURL
+		b.CreatedAt = time.Now()
+
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("create bundle: %w", err)
+		}
+		if err := bundle.Write(f, b); err != nil {
+			f.Close()
+			return fmt.Errorf("write bundle: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		for _, n := range notes {
+			fmt.Printf("Note: %s\n", n)
+		}
+		for _, it := range b.Items {
+			fmt.Printf("  %-9s %s\n", it.Kind, itemLabel(it.Name, it.Version))
+		}
+		fmt.Printf("Wrote %d definition(s) to %s.\n", len(b.Items), exportOutput)
+		return nil
+	},
+}
+
+func itemLabel(name string, version int) string {
+	if version > 0 {
+		return fmt.Sprintf("%s v%d", name, version)
+	}
+	return name
+}
+
+// ---- agent import ----
+
+var (
+	importOverwrite bool
+	importDryRun    bool
+	importJSON      bool
+)
+
+var agentImportCmd = &cobra.Command{
+	Use:   "import <bundle.tgz>",
+	Short: "Recreate the agents in a bundle on this server",
+	Long: `Recreate every definition in a bundle written by 'conductor agent export', in
+dependency order: task definitions, then workflows, then agents. Select the
+target server as usual, e.g. with --profile prod.
+
+A definition that already exists unchanged is skipped. One that exists with a
+different definition is a conflict: it is reported with the differing fields and
+left alone, and the command fails, unless --overwrite replaces it. --dry-run
+reports what would happen without writing anything.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("open bundle: %w", err)
+		}
+		b, err := bundle.Read(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		ctx := cmd.Context()
+		dst := newServerBundle()
+		opts := bundle.ImportOptions{Overwrite: importOverwrite, DryRun: importDryRun}
+		if importOverwrite && !importDryRun && !yes {
+			plan, err := bundle.Import(ctx, dst, b, bundle.ImportOptions{Overwrite: true, DryRun: true})
+			if err != nil {
+				return err
+			}
+			if n := countOutcome(plan, bundle.OutcomeUpdated); n > 0 &&
+				!confirm(fmt.Sprintf("Replace %d existing definition(s) on %s?", n, internal.Transport().BaseURL)) {
+				fmt.Println("Import cancelled.")
+				return nil
+			}
+		}
+
+		results, err := bundle.Import(ctx, dst, b, opts)
+		if importJSON {
+			data, merr := json.MarshalIndent(results, "", "  ")
+			if merr != nil {
+				return merr
+			}
+			fmt.Println(string(data))
+		} else {
+			printImportResults(os.Stdout, results, importDryRun)
+		}
+		if err != nil {
+			return err
+		}
+		if n := countOutcome(results, bundle.OutcomeConflict); n > 0 {
+			return fmt.Errorf("%d conflict(s) left unchanged; re-run with --overwrite to replace them", n)
+		}
+		return nil
+	},
+}
+
+func countOutcome(results []bundle.ImportResult, o bundle.Outcome) int {
+	n := 0
+	for _, r := range results {
+		if r.Outcome == o {
+			n++
+		}
+	}
+	return n
+}
+
+func printImportResults(w io.Writer, results []bundle.ImportResult, dryRun bool) {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tNAME\tOUTCOME\tDIFFERENCES")
+	for _, r := range results {
+		outcome := string(r.Outcome)
+		if dryRun && (r.Outcome == bundle.OutcomeCreated || r.Outcome == bundle.OutcomeUpdated) {
+			outcome = "would be " + outcome
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Kind, itemLabel(r.Name, r.Version), outcome, strings.Join(r.Differences, ", "))
+	}
+	tw.Flush()
+	prefix := ""
+	if dryRun {
+		prefix = "Dry run: "
+	}
+	fmt.Fprintf(w, "\n%s%s.\n", prefix, bundle.Summary(results))
+}
+
+// serverBundle reads and writes bundle definitions on the configured server: agents
+// through the agent service, task definitions and workflows through the metadata
+// API.
+type serverBundle struct {
+	agents   agent.Service
+	metadata *client.MetadataResourceApiService
+}
+
+func newServerBundle() serverBundle {
+	return serverBundle{agents: internal.GetAgentService(), metadata: internal.GetMetadataClient()}
+}
+
+func (s serverBundle) Agent(ctx context.Context, name string) (json.RawMessage, bool, error) {
+	def, err := s.agents.Get(ctx, name, nil)
+	if agent.IsNotFound(err) {
+		return nil, false, nil
+	}
+	return def, err == nil, err
+}
+
+func (s serverBundle) CompileAgent(ctx context.Context, def json.RawMessage) (json.RawMessage, error) {
+	return s.agents.Compile(ctx, def)
+}
+
+func (s serverBundle) TaskDef(ctx context.Context, name string) (json.RawMessage, bool, error) {
+	def, _, err := s.metadata.GetTaskDef(ctx, name)
+	if isSwaggerNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	// Some servers answer an unknown name with an empty definition instead of a 404.
+	if def.Name == "" {
+		return nil, false, nil
+	}
+	data, err := json.Marshal(def)
+	return data, err == nil, err
+}
+
+func (s serverBundle) Workflow(ctx context.Context, name string, version int) (json.RawMessage, bool, error) {
+	opts := &client.MetadataResourceApiGetOpts{}
+	if version > 0 {
+		opts.Version = optional.NewInt32(int32(version))
+	}
+	def, _, err := s.metadata.Get(ctx, name, opts)
+	if isSwaggerNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if def.Name == "" {
+		return nil, false, nil
+	}
+	data, err := json.Marshal(def)
+	return data, err == nil, err
+}
+
+func (s serverBundle) CreateTaskDef(ctx context.Context, def json.RawMessage) error {
+	var td model.TaskDef
+	if err := json.Unmarshal(def, &td); err != nil {
+		return err
+	}
+	_, err := s.metadata.RegisterTaskDef(ctx, []model.TaskDef{td})
+	return err
+}
+
+func (s serverBundle) UpdateTaskDef(ctx context.Context, def json.RawMessage) error {
+	var td model.TaskDef
+	if err := json.Unmarshal(def, &td); err != nil {
+		return err
+	}
+	_, err := s.metadata.UpdateTaskDef(ctx, td)
+	return err
+}
+
+func (s serverBundle) PutWorkflow(ctx context.Context, def json.RawMessage) error {
+	var wd model.WorkflowDef
+	if err := json.Unmarshal(def, &wd); err != nil {
+		return err
+	}
+	_, err := s.metadata.Update(ctx, []model.WorkflowDef{wd})
+	return err
+}
+
+func (s serverBundle) PutAgent(ctx context.Context, def json.RawMessage) error {
+	_, err := s.agents.Publish(ctx, def)
+	return err
+}
+
+// isSwaggerNotFound reports whether err is a 404 from the Conductor SDK.
+func isSwaggerNotFound(err error) bool {
+	var swaggerErr client.GenericSwaggerError
+	return errors.As(err, &swaggerErr) && swaggerErr.StatusCode() == http.StatusNotFound
+}
+
+func init() {
+	agentExportCmd.Flags().StringVarP(&exportOutput, "output", "o", defaultBundleFile, "Bundle file to write")
+
+	agentImportCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "Replace definitions that differ from the bundle's")
+	agentImportCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Report what would change without writing")
+	agentImportCmd.Flags().BoolVar(&importJSON, "json", false, "Print the per-definition results as JSON")
+
+	agentCmd.AddCommand(agentExportCmd, agentImportCmd)
+}
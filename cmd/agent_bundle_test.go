@@ -0,0 +1,37 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/bundle"
+)
+
+func TestPrintImportResults(t *testing.T) {
+	results := []bundle.ImportResult{
+		{Kind: string(bundle.KindTaskDef), Name: "lookup_order", Outcome: bundle.OutcomeCreated},
+		{Kind: string(bundle.KindAgent), Name: "triage", Version: 3, Outcome: bundle.OutcomeConflict, Differences: []string{"model", "tools"}},
+	}
+	var buf bytes.Buffer
+	printImportResults(&buf, results, true)
+	want := "KIND      NAME           OUTCOME            DIFFERENCES\n" +
+		"taskDef   lookup_order   would be created   \n" +
+		"agent     triage v3      conflict           model, tools\n" +
+		"\nDry run: 1 created, 1 conflict.\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
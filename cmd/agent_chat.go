@@ -0,0 +1,397 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/chat"
+	"github.com/conductor-oss/conductor-cli/internal/transcript"
+	"github.com/conductor-oss/conductor-cli/internal/updater"
+)
+
+const (
+	// chatIndexFile is the session index, kept in the CLI config directory.
+	chatIndexFile = "chat-sessions.json"
+	chatPrompt    = "you> "
+	chatContinue  = "...> "
+	// chatTitleWidth bounds the session title taken from its first prompt.
+	chatTitleWidth = 60
+)
+
+const chatHelp = `Commands:
+  /status                     show the status of the last execution
+  /respond approve [reason]   approve a pending human-input request
+  /respond deny [reason]      deny a pending human-input request
+  /respond message <text>     answer a pending request with a message
+  /save <file.md>             write this conversation as Markdown
+  /new                        start a new session with the same agent
+  /session                    show the session id
+  /help                       show this help
+  /exit                       leave (Ctrl-D works too)
+
+End a line with \ to continue it, or wrap a block in """ lines.`
+
+var (
+	chatName    string
+	chatConfig  string
+	chatSession string
+)
+
+var agentChatCmd = &cobra.Command{
+	Use:   "chat",
+	Short: "Hold a conversation with an agent",
+	Long: `Open an interactive conversation with an agent by --name or --config. Each
+prompt runs the agent in the same session, so it remembers earlier turns, and the
+reply is streamed as it is produced. Ctrl-C stops the reply; Ctrl-D leaves.
+
+Sessions are recorded in ~/.conductor-cli/` + chatIndexFile + ` and can be resumed
+with --session <id>; 'conductor agent chat sessions' lists them.
+
+` + chatHelp,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idx, err := loadChatIndex()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		session := chat.Session{ID: uuid.NewString(), Created: now, Updated: now}
+		if chatSession != "" {
+			found, ok := idx.Get(chatSession)
+			if !ok {
+				return fmt.Errorf("chat session '%s' not found in %s", chatSession, chatIndexFile)
+			}
+			session = found
+		}
+		if chatName != "" {
+			session.Agent, session.Config = chatName, ""
+		}
+		if chatConfig != "" {
+			session.Agent, session.Config = "", chatConfig
+		}
+
+		repl := &chatREPL{
+			svc:     internal.GetAgentService(),
+			idx:     idx,
+			session: session,
+			w:       os.Stdout,
+		}
+		switch {
+		case session.Config != "":
+			if repl.def, err = loadAgentConfig(session.Config); err != nil {
+				return err
+			}
+		case session.Agent == "":
+			return fmt.Errorf("specify --name, --config or --session")
+		}
+
+		repl.in = chat.NewInput(os.Stdin)
+		var raw chat.RawMode
+		if isInteractiveTerminal() {
+			raw = rawStdin
+			repl.interactive = true
+		}
+		repl.ed = chat.NewEditor(repl.in.Reader(), os.Stdout, raw)
+		repl.ed.History = append([]string(nil), session.History...)
+		repl.tr = &transcript.Transcript{Title: "Chat with " + repl.agentLabel(), SessionID: session.ID}
+		return repl.loop(cmd.Context())
+	},
+}
+
+var agentChatSessionsCmd = &cobra.Command{
+	Use:          "sessions",
+	Short:        "List saved chat sessions",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := GetOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		idx, err := loadChatIndex()
+		if err != nil {
+			return err
+		}
+		return renderChatSessions(idx.Recent(), format)
+	},
+}
+
+func renderChatSessions(sessions []chat.Session, format OutputFormat) error {
+	switch format {
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(sessions, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case OutputFormatCSV:
+		w := NewCSVWriter()
+		w.WriteHeader("ID", "AGENT", "TURNS", "UPDATED", "TITLE")
+		for _, s := range sessions {
+			w.WriteRow(s.ID, chatSessionAgent(s), fmt.Sprint(s.Turns), s.Updated.Format(time.RFC3339), s.Title)
+		}
+		w.Flush()
+	default:
+		if len(sessions) == 0 {
+			fmt.Println("No chat sessions found.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tAGENT\tTURNS\tUPDATED\tTITLE")
+		for _, s := range sessions {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+				s.ID, chatSessionAgent(s), s.Turns, s.Updated.Format(time.DateTime), s.Title)
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+func chatSessionAgent(s chat.Session) string {
+	if s.Agent != "" {
+		return s.Agent
+	}
+	return s.Config
+}
+
+func loadChatIndex() (*chat.Index, error) {
+	dir, err := updater.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return chat.LoadIndex(filepath.Join(dir, chatIndexFile))
+}
+
+// rawStdin puts the terminal in raw mode for the line editor.
+func rawStdin() (func(), error) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	return func() { term.Restore(fd, state) }, nil
+}
+
+// chatService is the slice of agent.Service a chat needs.
+type chatService interface {
+	humanResponder
+	Run(ctx context.Context, req agent.RunRequest) (agent.Execution, error)
+	StreamExecution(ctx context.Context, executionID, lastEventID string, sink agent.EventSink) error
+	Status(ctx context.Context, id string) (json.RawMessage, error)
+}
+
+// chatREPL runs the conversation: it reads prompts, runs a turn per prompt in the
+// session and dispatches slash commands. The index is saved after every turn, so a
+// session survives the process being killed.
+type chatREPL struct {
+	svc     chatService
+	idx     *chat.Index
+	session chat.Session
+	def     json.RawMessage
+	ed      *chat.Editor
+	in      *chat.Input
+	w       io.Writer
+	tr      *transcript.Transcript
+	// interactive lets the human-input prompt share the editor's input.
+	interactive bool
+}
+
+func (r *chatREPL) loop(ctx context.Context) error {
+	fmt.Fprintf(r.w, "Chatting with %s (session %s). Type /help for commands.\n", r.agentLabel(), r.session.ID)
+	for {
+		line, err := r.ed.ReadMessage(chatPrompt, chatContinue)
+		switch {
+		case errors.Is(err, chat.ErrInterrupted):
+			continue
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "/") {
+			quit, err := r.command(ctx, line)
+			if err != nil {
+				fmt.Fprintf(r.w, "Error: %v\n", err)
+			}
+			if quit {
+				return nil
+			}
+			continue
+		}
+		if err := r.turn(ctx, line); err != nil {
+			fmt.Fprintf(r.w, "Error: %v\n", err)
+		}
+	}
+}
+
+// turn runs the agent on one prompt and streams the reply. Ctrl-C cancels only the
+// turn: the context is scoped to it and the REPL carries on.
+func (r *chatREPL) turn(parent context.Context, prompt string) error {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	defer stop()
+
+	req := agent.RunRequest{Name: r.session.Agent, Prompt: prompt, SessionID: r.session.ID}
+	if r.def != nil {
+		req = agent.RunRequest{Definition: r.def, Prompt: prompt, SessionID: r.session.ID}
+	}
+	exec, err := r.svc.Run(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	r.session.Turns++
+	r.session.LastExecutionID = exec.ID
+	r.session.Updated = now
+	if r.session.Title == "" {
+		r.session.Title = truncate(strings.ReplaceAll(prompt, "\n", " "), chatTitleWidth)
+	}
+	r.session.Remember(prompt)
+	r.idx.Upsert(r.session)
+	if err := r.idx.Save(); err != nil {
+		fmt.Fprintf(r.w, "Warning: could not save session: %v\n", err)
+	}
+
+	r.tr.Begin(prompt, exec.ID, exec.AgentName)
+	render := newRespondingSink(ctx, terminalSink{w: r.w}, r.svc, exec.ID, runAutoApprove, false)
+	render.w = r.w
+	if r.interactive {
+		render.in = r.in
+	}
+	err = r.svc.StreamExecution(ctx, exec.ID, "", agent.MultiSink(render, r.tr))
+	fmt.Fprintln(r.w)
+	return err
+}
+
+// command runs a slash command. quit reports that the chat should end.
+func (r *chatREPL) command(ctx context.Context, line string) (quit bool, err error) {
+	name, rest, _ := strings.Cut(strings.TrimPrefix(line, "/"), " ")
+	rest = strings.TrimSpace(rest)
+	switch name {
+	case "exit", "quit":
+		return true, nil
+	case "help":
+		fmt.Fprintln(r.w, chatHelp)
+	case "session":
+		fmt.Fprintf(r.w, "Session %s with %s, %d turn(s).\n", r.session.ID, r.agentLabel(), r.session.Turns)
+	case "new":
+		now := time.Now()
+		r.session = chat.Session{ID: uuid.NewString(), Agent: r.session.Agent, Config: r.session.Config, Created: now, Updated: now}
+		r.tr.Reset(r.session.ID)
+		fmt.Fprintf(r.w, "Started session %s.\n", r.session.ID)
+	case "status":
+		if r.session.LastExecutionID == "" {
+			return false, fmt.Errorf("no execution in this session yet")
+		}
+		detail, err := r.svc.Status(ctx, r.session.LastExecutionID)
+		if err != nil {
+			return false, err
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, detail, "", "  "); err != nil {
+			fmt.Fprintln(r.w, string(detail))
+		} else {
+			fmt.Fprintln(r.w, buf.String())
+		}
+	case "respond":
+		return false, r.respond(ctx, rest)
+	case "save":
+		if rest == "" {
+			return false, fmt.Errorf("usage: /save <file.md>")
+		}
+		f, err := os.Create(rest)
+		if err != nil {
+			return false, err
+		}
+		if err := r.tr.WriteMarkdown(f); err != nil {
+			f.Close()
+			return false, err
+		}
+		if err := f.Close(); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(r.w, "Transcript saved to %s.\n", rest)
+	default:
+		return false, fmt.Errorf("unknown command /%s (try /help)", name)
+	}
+	return false, nil
+}
+
+// respond answers the pending request on the last execution:
+// approve|deny [reason] or message <text>.
+func (r *chatREPL) respond(ctx context.Context, args string) error {
+	if r.session.LastExecutionID == "" {
+		return fmt.Errorf("no execution in this session yet")
+	}
+	verb, text, _ := strings.Cut(args, " ")
+	text = strings.TrimSpace(text)
+	var resp agent.HumanResponse
+	switch verb {
+	case "approve":
+		resp = agent.HumanResponse{Approved: true, Reason: text}
+	case "deny":
+		resp = agent.HumanResponse{Approved: false, Reason: text}
+	case "message":
+		if text == "" {
+			return fmt.Errorf("usage: /respond message <text>")
+		}
+		resp = agent.HumanResponse{Approved: true, Message: text}
+	default:
+		return fmt.Errorf("usage: /respond approve|deny [reason] or /respond message <text>")
+	}
+	if err := r.svc.Respond(ctx, r.session.LastExecutionID, resp); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.w, "Response sent for execution '%s'.\n", r.session.LastExecutionID)
+	return nil
+}
+
+func (r *chatREPL) agentLabel() string {
+	return chatSessionAgent(r.session)
+}
+
+func init() {
+	agentChatCmd.Flags().StringVar(&chatName, "name", "", "Name of a registered agent to chat with")
+	agentChatCmd.Flags().StringVar(&chatConfig, "config", "", "Path to an agent config file (YAML/JSON)")
+	agentChatCmd.Flags().StringVar(&chatSession, "session", "", "Resume a saved chat session by id")
+	agentChatCmd.Flags().StringSliceVar(&runAutoApprove, "auto-approve-tools", nil, "Comma-separated tools whose human-input requests are approved automatically")
+	agentChatCmd.MarkFlagsMutuallyExclusive("name", "config")
+
+	AddOutputFlags(agentChatSessionsCmd)
+	agentChatCmd.AddCommand(agentChatSessionsCmd)
+	agentCmd.AddCommand(agentChatCmd)
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/chat"
+	"github.com/conductor-oss/conductor-cli/internal/transcript"
+)
+
+// fakeChatService runs every prompt as execution "e<n>" that replies with one message.
+type fakeChatService struct {
+	recordingResponder
+	runs []agent.RunRequest
+}
+
+func (f *fakeChatService) Run(ctx context.Context, req agent.RunRequest) (agent.Execution, error) {
+	f.runs = append(f.runs, req)
+	return agent.Execution{ID: fmt.Sprintf("e%d", len(f.runs)), AgentName: req.Name}, nil
+}
+
+func (f *fakeChatService) StreamExecution(ctx context.Context, id, lastEventID string, sink agent.EventSink) error {
+	return sink.OnEvent(agent.SSEEvent{Type: agent.EventMessage, Data: []byte(`{"content":"reply to ` + id + `"}`)})
+}
+
+func (f *fakeChatService) Status(ctx context.Context, id string) (json.RawMessage, error) {
+	return json.RawMessage(`{"executionId":"` + id + `"}`), nil
+}
+
+func newTestREPL(t *testing.T, svc chatService) (*chatREPL, *bytes.Buffer) {
+	t.Helper()
+	idx, err := chat.LoadIndex(filepath.Join(t.TempDir(), chatIndexFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	return &chatREPL{
+		svc:     svc,
+		idx:     idx,
+		session: chat.Session{ID: "s1", Agent: "triage"},
+		w:       &out,
+		tr:      &transcript.Transcript{SessionID: "s1"},
+	}, &out
+}
+
+func TestChatTurnsShareSessionAndAreIndexed(t *testing.T) {
+	svc := &fakeChatService{}
+	r, out := newTestREPL(t, svc)
+	ctx := context.Background()
+
+	for _, prompt := range []string{"hello", "and again"} {
+		if err := r.turn(ctx, prompt); err != nil {
+			t.Fatalf("turn(%q): %v", prompt, err)
+		}
+	}
+	for _, req := range svc.runs {
+		if req.SessionID != "s1" || req.Name != "triage" {
+			t.Errorf("run request = %+v, want agent triage in session s1", req)
+		}
+	}
+	if !strings.Contains(out.String(), "reply to e2") {
+		t.Errorf("output missing the streamed reply:\n%s", out.String())
+	}
+
+	saved, ok := r.idx.Get("s1")
+	if !ok || saved.Turns != 2 || saved.LastExecutionID != "e2" || saved.Title != "hello" {
+		t.Errorf("indexed session = %+v, want 2 turns ending at e2 titled by the first prompt", saved)
+	}
+	if len(r.tr.Turns) != 2 || r.tr.Turns[1].Prompt != "and again" {
+		t.Errorf("transcript turns = %+v, want both prompts recorded", r.tr.Turns)
+	}
+}
+
+func TestChatSlashCommands(t *testing.T) {
+	svc := &fakeChatService{}
+	r, _ := newTestREPL(t, svc)
+	ctx := context.Background()
+
+	if _, err := r.command(ctx, "/respond approve"); err == nil {
+		t.Error("/respond before any turn succeeded, want an error")
+	}
+	if err := r.turn(ctx, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.command(ctx, "/respond message ship it"); err != nil {
+		t.Fatalf("/respond message: %v", err)
+	}
+	if len(svc.resps) != 1 || svc.ids[0] != "e1" || svc.resps[0].Message != "ship it" || !svc.resps[0].Approved {
+		t.Errorf("responses = %+v to %v, want an approving message to e1", svc.resps, svc.ids)
+	}
+
+	path := filepath.Join(t.TempDir(), "chat.md")
+	if _, err := r.command(ctx, "/save "+path); err != nil {
+		t.Fatalf("/save: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "reply to e1") {
+		t.Errorf("saved transcript missing the reply:\n%s", data)
+	}
+
+	if _, err := r.command(ctx, "/new"); err != nil || r.session.ID == "s1" || r.session.Agent != "triage" || len(r.tr.Turns) != 0 {
+		t.Errorf("/new: session=%+v turns=%d err=%v, want a fresh session with the same agent", r.session, len(r.tr.Turns), err)
+	}
+	if quit, _ := r.command(ctx, "/exit"); !quit {
+		t.Error("/exit did not quit")
+	}
+	if _, err := r.command(ctx, "/bogus"); err == nil {
+		t.Error("/bogus succeeded, want an unknown-command error")
+	}
+}
+
+// promptWriter reports when the approval prompt has been shown.
+type promptWriter struct{ shown chan struct{} }
+
+func (w promptWriter) Write(p []byte) (int, error) {
+	if strings.Contains(string(p), "[a]pprove") {
+		close(w.shown)
+	}
+	return len(p), nil
+}
+
+// Ctrl-C at an approval prompt during a turn must not leave a read behind that
+// swallows the next line typed at the chat prompt.
+func TestChatInterruptedApprovalLeavesNextLineToEditor(t *testing.T) {
+	stdin, typed := io.Pipe()
+	defer typed.Close()
+	in := chat.NewInput(stdin)
+
+	ctx, interrupt := context.WithCancel(context.Background())
+	svc := &recordingResponder{}
+	sink := newRespondingSink(ctx, terminalSink{w: io.Discard}, svc, "e1", nil, false)
+	w := promptWriter{shown: make(chan struct{})}
+	sink.w, sink.in = w, in
+	done := make(chan error)
+	go func() { done <- sink.OnEvent(waitingEvent(1, "refund")) }()
+	<-w.shown
+	interrupt()
+	if err := <-done; err != nil {
+		t.Fatalf("OnEvent: %v", err)
+	}
+
+	go func() { _, _ = io.WriteString(typed, "what next?\n") }()
+	line, err := chat.NewEditor(in.Reader(), io.Discard, nil).ReadMessage(chatPrompt, chatContinue)
+	if err != nil || line != "what next?" {
+		t.Errorf("editor read %q, %v; want the line typed after the interrupt", line, err)
+	}
+	if len(svc.resps) != 0 {
+		t.Errorf("the interrupted prompt sent %+v", svc.resps)
+	}
+}
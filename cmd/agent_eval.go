@@ -0,0 +1,164 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/eval"
+)
+
+var (
+	evalConcurrency int
+	evalJUnitOut    string
+	evalJSONOut     string
+)
+
+var agentEvalCmd = &cobra.Command{
+	Use:   "eval <suite.yaml>",
+	Short: "Run an agent evaluation suite",
+	Long: `Run every case of an evaluation suite against the server and check the streamed
+events. Cases run in parallel (--concurrency); each prints PASS, FAIL or ERROR as
+it finishes, and the command exits non-zero unless all pass. --junit-out and
+--json-out write the report for CI.
+
+A suite sets defaults and lists cases; config paths are relative to the suite:
+
+  name: triage-regression
+  agent: triage            # or config: agents/triage.yaml
+  timeout: 2m
+  cases:
+    - name: order lookup
+      prompt: Where is order 42?
+      approve: [issue_refund]      # human-input requests to approve
+      expect:
+        tools: [lookup_order]      # must be called
+        noTools: [delete_order]    # must not be called
+        noGuardrailFail: true
+        maxTurns: 5                # model calls
+        maxDuration: 30s           # also the case timeout
+        output:
+          regex: shipped
+          jsonPath: $.orders[0].status
+          equals: shipped`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		suite, err := loadEvalSuite(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		runner := &eval.Runner{
+			Service:     internal.GetAgentService(),
+			Concurrency: evalConcurrency,
+			OnResult:    func(c eval.CaseResult) { printEvalResult(os.Stdout, c) },
+		}
+		fmt.Printf("Running %d case(s) from %s\n\n", len(suite.Cases), args[0])
+		report := runner.Run(ctx, suite)
+
+		if err := writeEvalReport(evalJUnitOut, report.WriteJUnit); err != nil {
+			return err
+		}
+		if err := writeEvalReport(evalJSONOut, report.WriteJSON); err != nil {
+			return err
+		}
+
+		passed, failed, errored := report.Counts()
+		fmt.Printf("\n%d passed, %d failed, %d errored in %s\n", passed, failed, errored, report.Duration.Round(time.Millisecond))
+		if passed != len(report.Cases) {
+			return fmt.Errorf("%d of %d case(s) did not pass", failed+errored, len(report.Cases))
+		}
+		return nil
+	},
+}
+
+// loadEvalSuite reads a suite and resolves each case's config file, relative to
+// the suite, into its definition.
+func loadEvalSuite(path string) (eval.Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return eval.Suite{}, fmt.Errorf("read suite: %w", err)
+	}
+	suite, err := eval.ParseSuite(data)
+	if err != nil {
+		return eval.Suite{}, fmt.Errorf("%s: %w", path, err)
+	}
+	for i := range suite.Cases {
+		c := &suite.Cases[i]
+		if c.Config == "" {
+			continue
+		}
+		cfg := c.Config
+		if !filepath.IsAbs(cfg) {
+			cfg = filepath.Join(filepath.Dir(path), cfg)
+		}
+		if c.Definition, err = loadAgentConfig(cfg); err != nil {
+			return eval.Suite{}, fmt.Errorf("case %q: %w", c.Name, err)
+		}
+	}
+	return suite, nil
+}
+
+func printEvalResult(w io.Writer, c eval.CaseResult) {
+	status := "PASS"
+	switch {
+	case c.Error != "":
+		status = "ERROR"
+	case !c.Passed:
+		status = "FAIL"
+	}
+	fmt.Fprintf(w, "%-5s %s (%s)\n", status, c.Name, c.Duration.Round(time.Millisecond))
+	if c.Error != "" {
+		fmt.Fprintf(w, "      %s\n", c.Error)
+	}
+	for _, f := range c.Failures {
+		fmt.Fprintf(w, "      %s\n", f)
+	}
+}
+
+// writeEvalReport writes one report format to path; an empty path skips it.
+func writeEvalReport(path string, write func(io.Writer) error) error {
+	if path == "" {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create report: %w", err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("write report %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+func init() {
+	agentEvalCmd.Flags().IntVar(&evalConcurrency, "concurrency", agent.DefaultConcurrency, "Number of cases to run at once")
+	agentEvalCmd.Flags().StringVar(&evalJUnitOut, "junit-out", "", "Write a JUnit XML report to this file")
+	agentEvalCmd.Flags().StringVar(&evalJSONOut, "json-out", "", "Write a JSON report to this file")
+
+	agentCmd.AddCommand(agentEvalCmd)
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+func TestParseExecutionSort(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                 "",
+		"startTime":        "startTime:ASC",
+		"endtime:desc":     "endTime:DESC",
+		"workflowType:AsC": "workflowType:ASC",
+	} {
+		got, err := parseExecutionSort(in)
+		if err != nil || got != want {
+			t.Errorf("parseExecutionSort(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"prompt", "startTime:up"} {
+		if _, err := parseExecutionSort(bad); err == nil {
+			t.Errorf("parseExecutionSort(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestExecutionPageFooter(t *testing.T) {
+	results := func(n int) []agent.ExecutionSummary { return make([]agent.ExecutionSummary, n) }
+	tests := []struct {
+		page   agent.ExecutionPage
+		filter agent.ExecutionFilter
+		want   string
+	}{
+		{agent.ExecutionPage{TotalHits: 3, Results: results(3)}, agent.ExecutionFilter{Size: 50}, "3 of 3 execution(s)."},
+		{agent.ExecutionPage{TotalHits: 120, Results: results(50)}, agent.ExecutionFilter{Start: 50, Size: 50},
+			"51-100 of 120 execution(s). Next page: --page 3, or --all for every page."},
+		{agent.ExecutionPage{TotalHits: 120, Results: results(20)}, agent.ExecutionFilter{Start: 100, Size: 50},
+			"101-120 of 120 execution(s)."},
+	}
+	for _, tt := range tests {
+		if got := executionPageFooter(tt.page, tt.filter); got != tt.want {
+			t.Errorf("footer = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestWriteExecutionsNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeExecutionsNDJSON(&buf, []agent.ExecutionSummary{{ExecutionID: "e1", Status: "FAILED"}, {ExecutionID: "e2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 || !bytes.Contains(lines[0], []byte(`"executionId":"e1"`)) {
+		t.Errorf("NDJSON = %s", buf.String())
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/chat"
+)
+
+// autoApproveReason is recorded on responses sent for allowlisted tools, so the
+// execution history shows no human looked at them.
+const autoApproveReason = "auto-approved by --auto-approve-tools"
+
+// humanResponder is the slice of agent.Service the inline responder needs; naming
+// it keeps the sink testable without a full service fake.
+type humanResponder interface {
+	Respond(ctx context.Context, id string, resp agent.HumanResponse) error
+}
+
+// respondingSink answers human-in-the-loop requests while a stream is running, so
+// a waiting execution does not need a second terminal and `agent respond`. It wraps
+// the rendering sink: every event is rendered first, then a waiting event is
+// answered — automatically when its tool is on the allowlist, by prompting when the
+// session is interactive, and not at all otherwise (the server keeps waiting).
+type respondingSink struct {
+	ctx         context.Context
+	next        agent.EventSink
+	svc         humanResponder
+	executionID string
+	autoApprove map[string]bool
+	// in is nil when stdin is not a terminal; prompts are then never shown.
+	in       *chat.Input
+	w        io.Writer
+	answered map[int64]bool
+}
+
+// newRespondingSink wraps next for executionID. interactive enables the prompt.
+func newRespondingSink(ctx context.Context, next agent.EventSink, svc humanResponder, executionID string, autoApprove []string, interactive bool) *respondingSink {
+	s := &respondingSink{
+		ctx:         ctx,
+		next:        next,
+		svc:         svc,
+		executionID: executionID,
+		autoApprove: make(map[string]bool, len(autoApprove)),
+		w:           os.Stdout,
+		answered:    map[int64]bool{},
+	}
+	for _, name := range cleanNames(autoApprove) {
+		s.autoApprove[name] = true
+	}
+	if interactive {
+		s.in = chat.NewInput(os.Stdin)
+	}
+	return s
+}
+
+func (s *respondingSink) OnEvent(e agent.SSEEvent) error {
+	if err := s.next.OnEvent(e); err != nil {
+		return err
+	}
+	if e.ResolvedType() != agent.EventWaiting {
+		return nil
+	}
+
+	p := e.Payload()
+	// A resumed stream can replay the waiting event that was already answered.
+	if p.ID != 0 {
+		if s.answered[p.ID] {
+			return nil
+		}
+		s.answered[p.ID] = true
+	}
+	id := p.ExecutionID
+	if id == "" {
+		id = s.executionID
+	}
+	tool := p.PendingToolName()
+
+	if tool != "" && s.autoApprove[tool] {
+		fmt.Fprintf(s.w, "  [respond] auto-approved %s\n", tool)
+		s.respond(id, agent.HumanResponse{Approved: true, Reason: autoApproveReason})
+		return nil
+	}
+	if s.in == nil {
+		fmt.Fprintf(s.w, "  [respond] answer with: conductor agent respond %s --approve|--deny\n", id)
+		return nil
+	}
+
+	s.printPending(p)
+	resp, ok, err := s.prompt()
+	if err != nil || !ok {
+		// Ctrl-C at the prompt ends the stream through ctx; a closed stdin just
+		// leaves the request pending, like skipping it.
+		if s.ctx.Err() == nil {
+			fmt.Fprintf(s.w, "  [respond] skipped; answer later with: conductor agent respond %s --approve|--deny\n", id)
+		}
+		return nil
+	}
+	s.respond(id, resp)
+	return nil
+}
+
+// respond sends the answer. A failure is reported but does not end the stream: the
+// execution is still waiting and can be answered from `agent respond`.
+func (s *respondingSink) respond(id string, resp agent.HumanResponse) {
+	if err := s.svc.Respond(s.ctx, id, resp); err != nil {
+		fmt.Fprintf(s.w, "  [respond] failed: %v\n", err)
+		return
+	}
+	fmt.Fprintln(s.w, "  [respond] response sent")
+}
+
+func (s *respondingSink) printPending(p agent.EventPayload) {
+	if tool := p.PendingToolName(); tool != "" {
+		fmt.Fprintf(s.w, "    tool: %s\n", tool)
+	}
+	if len(p.PendingTool) > 0 {
+		if data, err := json.MarshalIndent(p.PendingTool, "    ", "  "); err == nil {
+			fmt.Fprintf(s.w, "    details: %s\n", data)
+		}
+	}
+}
+
+// prompt asks for a decision until it gets a valid one. ok is false when the user
+// skips, leaving the request pending. A message answers the request rather than
+// rejecting it, so it is sent as an approval carrying the text.
+func (s *respondingSink) prompt() (resp agent.HumanResponse, ok bool, err error) {
+	for {
+		answer, err := s.readLine("  [a]pprove, [d]eny, [m]essage or [s]kip? ")
+		if err != nil {
+			return resp, false, err
+		}
+		switch strings.ToLower(answer) {
+		case "a", "approve", "y", "yes":
+			return agent.HumanResponse{Approved: true}, true, nil
+		case "d", "deny", "n", "no":
+			reason, err := s.readLine("  reason (optional): ")
+			if err != nil {
+				return resp, false, err
+			}
+			return agent.HumanResponse{Approved: false, Reason: reason}, true, nil
+		case "m", "message":
+			msg, err := s.readLine("  message: ")
+			if err != nil {
+				return resp, false, err
+			}
+			if msg == "" {
+				continue
+			}
+			return agent.HumanResponse{Approved: true, Message: msg}, true, nil
+		case "s", "skip", "":
+			return resp, false, nil
+		}
+	}
+}
+
+// readLine prompts and reads one line. The read gives up when Ctrl-C cancels ctx,
+// and leaves the input typed after that to the next reader.
+func (s *respondingSink) readLine(label string) (string, error) {
+	fmt.Fprint(s.w, label)
+	line, err := s.in.ReadLine(s.ctx)
+	if err != nil {
+		if s.ctx.Err() != nil {
+			fmt.Fprintln(s.w)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/chat"
+)
+
+type recordingResponder struct {
+	ids   []string
+	resps []agent.HumanResponse
+}
+
+func (r *recordingResponder) Respond(ctx context.Context, id string, resp agent.HumanResponse) error {
+	r.ids = append(r.ids, id)
+	r.resps = append(r.resps, resp)
+	return nil
+}
+
+func waitingEvent(id int64, tool string) agent.SSEEvent {
+	return agent.SSEEvent{
+		Type: agent.EventWaiting,
+		Data: []byte(fmt.Sprintf(`{"id":%d,"type":"waiting","executionId":"e1","pendingTool":{"name":%q}}`, id, tool)),
+	}
+}
+
+func newTestRespondingSink(input string, autoApprove ...string) (*respondingSink, *recordingResponder, *bytes.Buffer) {
+	var out bytes.Buffer
+	svc := &recordingResponder{}
+	s := newRespondingSink(context.Background(), terminalSink{w: &out}, svc, "e1", autoApprove, false)
+	s.w = &out
+	if input != "" {
+		s.in = chat.NewInput(strings.NewReader(input))
+	}
+	return s, svc, &out
+}
+
+func TestRespondingSinkPromptsAndResponds(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  agent.HumanResponse
+	}{
+		{name: "approve", input: "a\n", want: agent.HumanResponse{Approved: true}},
+		{name: "deny with reason", input: "d\ntoo risky\n", want: agent.HumanResponse{Approved: false, Reason: "too risky"}},
+		{name: "message", input: "m\nuse the EU account\n", want: agent.HumanResponse{Approved: true, Message: "use the EU account"}},
+		{name: "invalid answer asks again", input: "maybe\ny\n", want: agent.HumanResponse{Approved: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, svc, out := newTestRespondingSink(tt.input)
+			if err := s.OnEvent(waitingEvent(1, "refund")); err != nil {
+				t.Fatalf("OnEvent: %v", err)
+			}
+			if len(svc.resps) != 1 || svc.resps[0] != tt.want || svc.ids[0] != "e1" {
+				t.Fatalf("responses = %+v to %v, want %+v to e1", svc.resps, svc.ids, tt.want)
+			}
+			if !strings.Contains(out.String(), "tool: refund") {
+				t.Errorf("pending tool not shown:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestRespondingSinkSkipLeavesRequestPending(t *testing.T) {
+	s, svc, out := newTestRespondingSink("s\n")
+	if err := s.OnEvent(waitingEvent(1, "refund")); err != nil {
+		t.Fatalf("OnEvent: %v", err)
+	}
+	if len(svc.resps) != 0 {
+		t.Errorf("skip sent %+v", svc.resps)
+	}
+	if !strings.Contains(out.String(), "conductor agent respond e1") {
+		t.Errorf("skip gave no hint:\n%s", out.String())
+	}
+}
+
+func TestRespondingSinkAutoApprovesAllowlistedTools(t *testing.T) {
+	s, svc, _ := newTestRespondingSink("", "refund")
+
+	if err := s.OnEvent(waitingEvent(1, "refund")); err != nil {
+		t.Fatalf("OnEvent: %v", err)
+	}
+	if err := s.OnEvent(waitingEvent(2, "wire_transfer")); err != nil {
+		t.Fatalf("OnEvent: %v", err)
+	}
+	// The replay of an answered request must not answer it twice.
+	if err := s.OnEvent(waitingEvent(1, "refund")); err != nil {
+		t.Fatalf("OnEvent: %v", err)
+	}
+
+	want := agent.HumanResponse{Approved: true, Reason: autoApproveReason}
+	if len(svc.resps) != 1 || svc.resps[0] != want {
+		t.Errorf("responses = %+v, want only the allowlisted tool approved", svc.resps)
+	}
+}
+
+func TestRespondingSinkPassesOtherEventsThrough(t *testing.T) {
+	s, svc, out := newTestRespondingSink("a\n")
+	if err := s.OnEvent(agent.SSEEvent{Type: agent.EventHandoff, Data: []byte(`{"target":"billing"}`)}); err != nil {
+		t.Fatalf("OnEvent: %v", err)
+	}
+	if len(svc.resps) != 0 || out.String() != "  [handoff] -> billing\n" {
+		t.Errorf("responses=%+v out=%q", svc.resps, out.String())
+	}
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+func TestPrintPrunePlan(t *testing.T) {
+	cutoff := time.Date(2026, 9, 18, 0, 0, 0, 0, time.UTC)
+	plan := agent.PrunePlan{
+		Cutoff: cutoff,
+		Total:  5,
+		Agents: []agent.PruneAgentCounts{
+			{AgentName: "triage", Count: 4, SampleIDs: []string{"a", "b", "c"}},
+			{AgentName: "billing", Count: 1, SampleIDs: []string{"d"}},
+		},
+	}
+	var buf bytes.Buffer
+	printPrunePlan(&buf, plan, false)
+	want := "Dry run: would delete 5 execution(s) started before 2026-09-18T00:00:00Z.\n\n" +
+		"AGENT     COUNT   SAMPLE IDS\n" +
+		"triage    4       a, b, c, ...\n" +
+		"billing   1       d\n"
+	if buf.String() != want {
+		t.Errorf("plan =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	printPrunePlan(&buf, agent.PrunePlan{Cutoff: cutoff}, true)
+	if want := "Dry run: no executions started before 2026-09-18T00:00:00Z to archive.\n"; buf.String() != want {
+		t.Errorf("empty plan = %q, want %q", buf.String(), want)
+	}
+}
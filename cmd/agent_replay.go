@@ -0,0 +1,113 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/recording"
+)
+
+// speedInstant replays a recording without waiting between events.
+const speedInstant = "max"
+
+var (
+	replaySpeed string
+	replayTUI   bool
+)
+
+var agentReplayCmd = &cobra.Command{
+	Use:   "replay <events.jsonl>",
+	Short: "Play back a recorded event stream",
+	Long: `Play back events recorded with --record on 'agent run' or 'agent stream', with
+their original timing. The events go through the same renderers as a live stream:
+the terminal view by default, the full-screen view with --tui, or --output ndjson
+for the normalized event per line that 'agent run' and 'agent stream' print, so
+recorded and live streams can be fed to the same tools.
+
+--speed scales the timing: 2x plays twice as fast, 0.5x at half speed, and max
+without pauses. Nothing is sent to the server; human-input requests are shown but
+not answered.`,
+	Example: `  conductor agent run --name triage --record run.jsonl "Where is my order?"
+  conductor agent replay run.jsonl --speed 4x
+  conductor agent replay run.jsonl --speed max --output ndjson`,
+	Annotations:  map[string]string{offlineAnnotation: ""},
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		speed, err := parseReplaySpeed(replaySpeed)
+		if err != nil {
+			return err
+		}
+		if err := checkStreamOutput(replayTUI); err != nil {
+			return err
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("open recording: %w", err)
+		}
+		entries, err := recording.Read(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("read recording %s: %w", args[0], err)
+		}
+
+		exec := agent.Execution{}
+		if len(entries) > 0 {
+			exec.ID = entries[0].Event().Payload().ExecutionID
+		}
+		if replayTUI {
+			return showInTUI(cmd.Context(), exec, func(ctx context.Context, view agent.EventSink) error {
+				return recording.Replay(ctx, entries, speed, view)
+			})
+		}
+
+		var sink agent.EventSink = createTerminalSink()
+		if runOutput == streamOutputNDJSON {
+			sink = ndjsonSink{w: os.Stdout, executionID: exec.ID}
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		return recording.Replay(ctx, entries, speed, sink)
+	},
+}
+
+// parseReplaySpeed reads a playback speed such as 2x, 0.5 or max. Zero means no
+// pauses, as recording.Replay takes it.
+func parseReplaySpeed(s string) (float64, error) {
+	if strings.EqualFold(s, speedInstant) {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(s), "x"), 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid --speed %q: use a positive multiplier like 2x or 0.5x, or %s", s, speedInstant)
+	}
+	return v, nil
+}
+
+func init() {
+	agentReplayCmd.Flags().StringVar(&replaySpeed, "speed", "1x", "Playback speed multiplier (e.g. 2x, 0.5x) or max for no pauses")
+	agentReplayCmd.Flags().BoolVar(&replayTUI, "tui", false, "Play back in the interactive full-screen view")
+	agentReplayCmd.Flags().StringVarP(&runOutput, "output", "o", streamOutputText, "Playback output: text or ndjson (one normalized event per line)")
+
+	agentCmd.AddCommand(agentReplayCmd)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/recording"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files from the current renderers")
+
+// TestRenderersGolden replays every recording in testdata/replay through the
+// terminal and NDJSON sinks and compares the output with the golden files next to
+// it. Run with -update after an intended rendering change.
+func TestRenderersGolden(t *testing.T) {
+	recordings, err := filepath.Glob(filepath.Join("testdata", "replay", "*.jsonl"))
+	if err != nil || len(recordings) == 0 {
+		t.Fatalf("no recordings found: %v", err)
+	}
+	for _, path := range recordings {
+		base := strings.TrimSuffix(path, ".jsonl")
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries, err := recording.Read(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+
+		for _, r := range []struct {
+			suffix string
+			sink   func(*bytes.Buffer) agent.EventSink
+		}{
+			{".terminal.golden", func(b *bytes.Buffer) agent.EventSink { return terminalSink{w: b} }},
+			{".ndjson.golden", func(b *bytes.Buffer) agent.EventSink { return ndjsonSink{w: b, executionID: "e1"} }},
+		} {
+			golden := base + r.suffix
+			t.Run(filepath.Base(golden), func(t *testing.T) {
+				var out bytes.Buffer
+				if err := recording.Replay(context.Background(), entries, 0, r.sink(&out)); err != nil {
+					t.Fatalf("Replay: %v", err)
+				}
+				if *updateGolden {
+					if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("%v (run with -update to create it)", err)
+				}
+				if out.String() != string(want) {
+					t.Errorf("output differs from %s:\n--- got\n%s\n--- want\n%s", golden, out.String(), want)
+				}
+			})
+		}
+	}
+}
+
+func TestParseReplaySpeed(t *testing.T) {
+	for in, want := range map[string]float64{"1x": 1, "2x": 2, "0.5X": 0.5, "3": 3, "max": 0} {
+		if got, err := parseReplaySpeed(in); err != nil || got != want {
+			t.Errorf("parseReplaySpeed(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "fast", "0x", "-2x"} {
+		if _, err := parseReplaySpeed(in); err == nil {
+			t.Errorf("parseReplaySpeed(%q) succeeded, want an error", in)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// Layout of the status view.
+const (
+	statusLabelWidth   = 12 // header labels
+	ganttNameWidth     = 24 // task names in the Gantt chart
+	ganttDefaultWidth  = 80 // terminal width when stdout is not a terminal
+	ganttMinBarWidth   = 10
+	ganttDurationWidth = 9
+	truncStatusOutput  = 2000
+	// maxSubAgentDepth bounds how deep the sub-agent tree is fetched.
+	maxSubAgentDepth = 4
+)
+
+// Gantt bar glyphs: a finished task's run, a running task's run so far, and
+// idle time.
+const (
+	ganttDone    = "█"
+	ganttRunning = "▓"
+	ganttIdle    = "·"
+)
+
+// statusNode is an execution with the sub-agent executions it started.
+type statusNode struct {
+	status   agent.ExecutionStatus
+	children []*statusNode
+	err      error // why this node's status could not be fetched
+}
+
+// statusFetcher is the slice of agent.Service the status view needs.
+type statusFetcher interface {
+	Status(ctx context.Context, id string) (json.RawMessage, error)
+}
+
+// fetchStatusTree fetches an execution's status and, depth permitting, the status
+// of every sub-agent execution under it. A sub-agent that cannot be fetched stays
+// in the tree with its error.
+func fetchStatusTree(ctx context.Context, svc statusFetcher, id string, depth int) (*statusNode, error) {
+	raw, err := svc.Status(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	st, err := agent.ParseStatus(raw)
+	if err != nil {
+		return nil, err
+	}
+	if st.ExecutionID == "" {
+		st.ExecutionID = id
+	}
+	node := &statusNode{status: st}
+	if depth <= 0 {
+		return node, nil
+	}
+	for _, t := range st.Tasks {
+		if t.Kind != agent.TaskSubAgent || t.SubExecutionID == "" {
+			continue
+		}
+		child, err := fetchStatusTree(ctx, svc, t.SubExecutionID, depth-1)
+		if err != nil {
+			child = &statusNode{status: agent.ExecutionStatus{ExecutionID: t.SubExecutionID, AgentName: t.Name}, err: err}
+		}
+		node.children = append(node.children, child)
+	}
+	return node, nil
+}
+
+// renderExecutionStatus writes the human-readable status view: header, per-turn
+// timeline, sub-agent tree, pending human tasks, a Gantt chart of the tasks and
+// the final output. width is the terminal width for the chart.
+func renderExecutionStatus(w io.Writer, root *statusNode, now time.Time, width int) {
+	st := root.status
+	header := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "%-*s%s\n", statusLabelWidth, label, value)
+		}
+	}
+	header("Execution", st.ExecutionID)
+	header("Agent", st.AgentName)
+	header("Status", st.Status)
+	if !st.Start.IsZero() {
+		header("Started", st.Start.Local().Format(time.DateTime))
+		header("Duration", formatStatusDuration(st.Duration(now), st.End.IsZero()))
+	}
+	if !st.Usage.IsZero() {
+		header("Tokens", fmt.Sprintf("%d (prompt %d, completion %d)", st.Usage.Total, st.Usage.Prompt, st.Usage.Completion))
+	}
+	header("Prompt", truncate(st.Prompt, truncToolInput))
+	header("Reason", st.Reason)
+
+	if turns := st.Turns(); len(turns) > 0 {
+		fmt.Fprintln(w, "\nTimeline")
+		for i, turn := range turns {
+			label := fmt.Sprintf("Turn %d", i+1)
+			if turn.LLM.Name != "" {
+				label += "  " + taskSummary(turn.LLM, now)
+			}
+			fmt.Fprintf(w, "  %s\n", label)
+			for j, t := range turn.Tools {
+				branch := "├─"
+				if j == len(turn.Tools)-1 {
+					branch = "└─"
+				}
+				fmt.Fprintf(w, "    %s %s\n", branch, taskSummary(t, now))
+			}
+		}
+	}
+
+	if len(root.children) > 0 {
+		fmt.Fprintln(w, "\nSub-agents")
+		fmt.Fprintf(w, "  %s\n", nodeSummary(root, now))
+		renderStatusTree(w, root.children, "  ", now)
+	}
+
+	if pending := st.Pending(); len(pending) > 0 {
+		fmt.Fprintln(w, "\nPending human tasks")
+		for _, t := range pending {
+			since := ""
+			if !t.Start.IsZero() {
+				since = fmt.Sprintf(", waiting %s", formatStatusDuration(t.Duration(now), false))
+			}
+			fmt.Fprintf(w, "  %s (%s%s)\n", t.Name, t.Status, since)
+		}
+		fmt.Fprintf(w, "  Answer with: conductor agent respond %s --approve|--deny\n", st.ExecutionID)
+	}
+
+	if chart := ganttChart(st, now, width); len(chart) > 0 {
+		fmt.Fprintln(w, "\nTasks")
+		for _, line := range chart {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+
+	if len(st.Output) > 0 && string(st.Output) != "null" {
+		fmt.Fprintln(w, "\nOutput")
+		for _, line := range strings.Split(truncate(st.Output.String(), truncStatusOutput), "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+}
+
+func renderStatusTree(w io.Writer, nodes []*statusNode, indent string, now time.Time) {
+	for i, n := range nodes {
+		branch, next := "├─ ", "│  "
+		if i == len(nodes)-1 {
+			branch, next = "└─ ", "   "
+		}
+		fmt.Fprintf(w, "%s%s%s\n", indent, branch, nodeSummary(n, now))
+		renderStatusTree(w, n.children, indent+next, now)
+	}
+}
+
+func nodeSummary(n *statusNode, now time.Time) string {
+	st := n.status
+	if n.err != nil {
+		return fmt.Sprintf("%s (%s): %v", st.AgentName, st.ExecutionID, n.err)
+	}
+	s := fmt.Sprintf("%s (%s) %s", st.AgentName, st.ExecutionID, st.Status)
+	if d := st.Duration(now); d > 0 {
+		s += "  " + formatStatusDuration(d, st.End.IsZero())
+	}
+	return s
+}
+
+func taskSummary(t agent.StatusTask, now time.Time) string {
+	s := fmt.Sprintf("%s  %s", t.Name, t.Status)
+	if t.Kind == agent.TaskHuman {
+		s += "  (human)"
+	}
+	if d := t.Duration(now); d > 0 {
+		s += "  " + formatStatusDuration(d, t.Open())
+	}
+	if !t.Usage.IsZero() {
+		s += fmt.Sprintf("  %d tokens", t.Usage.Total)
+	}
+	return s
+}
+
+// formatStatusDuration rounds a duration for display, marking one still running.
+func formatStatusDuration(d time.Duration, running bool) string {
+	switch {
+	case d >= time.Minute:
+		d = d.Round(time.Second)
+	case d >= time.Second:
+		d = d.Round(100 * time.Millisecond)
+	default:
+		d = d.Round(time.Millisecond)
+	}
+	if running {
+		return d.String() + "+"
+	}
+	return d.String()
+}
+
+// ganttChart lays the started tasks out on one time axis from the execution's
+// start to its end (or now), one line per task, control tasks left out.
+func ganttChart(st agent.ExecutionStatus, now time.Time, width int) []string {
+	var tasks []agent.StatusTask
+	start, end := st.Start, st.End
+	for _, t := range st.Tasks {
+		if t.Kind == agent.TaskControl || t.Start.IsZero() {
+			continue
+		}
+		tasks = append(tasks, t)
+		if start.IsZero() || t.Start.Before(start) {
+			start = t.Start
+		}
+		tEnd := t.End
+		if tEnd.IsZero() {
+			tEnd = now
+		}
+		if tEnd.After(end) {
+			end = tEnd
+		}
+	}
+	if end.IsZero() {
+		end = now
+	}
+	total := end.Sub(start)
+	if len(tasks) == 0 || total <= 0 {
+		return nil
+	}
+
+	bar := max(width-ganttNameWidth-ganttDurationWidth-6, ganttMinBarWidth)
+	col := func(t time.Time) int {
+		return min(int(float64(t.Sub(start))/float64(total)*float64(bar)), bar)
+	}
+	lines := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		tEnd, glyph := t.End, ganttDone
+		if tEnd.IsZero() {
+			tEnd, glyph = now, ganttRunning
+		}
+		from, to := col(t.Start), col(tEnd)
+		to = max(to, from+1) // every task gets at least one cell
+		if to > bar {
+			from, to = bar-1, bar
+		}
+		cells := strings.Repeat(ganttIdle, from) + strings.Repeat(glyph, to-from) + strings.Repeat(ganttIdle, bar-to)
+		lines = append(lines, fmt.Sprintf("%-*s |%s| %*s", ganttNameWidth, truncate(t.Name, ganttNameWidth-3), cells,
+			ganttDurationWidth, formatStatusDuration(t.Duration(now), t.End.IsZero())))
+	}
+	return lines
+}
+
+// terminalWidth is stdout's width, or a default when it is not a terminal.
+func terminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	return ganttDefaultWidth
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeStatusFetcher map[string]string
+
+func (f fakeStatusFetcher) Status(ctx context.Context, id string) (json.RawMessage, error) {
+	if s, ok := f[id]; ok {
+		return json.RawMessage(s), nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestRenderExecutionStatus(t *testing.T) {
+	fetcher := fakeStatusFetcher{
+		"e1": `{"executionId": "e1", "agentName": "triage", "status": "RUNNING", "startTime": 1000,
+			"tasks": [
+				{"taskType": "LLM_CHAT_COMPLETE", "referenceTaskName": "call_llm", "status": "COMPLETED", "startTime": 1000, "endTime": 3000,
+				 "outputData": {"promptTokens": 10, "completionTokens": 5}},
+				{"taskType": "SIMPLE", "referenceTaskName": "lookup_order", "status": "COMPLETED", "startTime": 3000, "endTime": 5000},
+				{"taskType": "SUB_WORKFLOW", "referenceTaskName": "billing", "status": "COMPLETED", "startTime": 5000, "endTime": 7000, "subWorkflowId": "e2"},
+				{"taskType": "SUB_WORKFLOW", "referenceTaskName": "gone", "status": "FAILED", "subWorkflowId": "e9"},
+				{"taskType": "HUMAN", "referenceTaskName": "approve_refund", "status": "IN_PROGRESS", "startTime": 7000}
+			]}`,
+		"e2": `{"executionId": "e2", "agentName": "billing", "status": "COMPLETED", "startTime": 5000, "endTime": 7000, "output": "paid"}`,
+	}
+	tree, err := fetchStatusTree(context.Background(), fetcher, "e1", maxSubAgentDepth)
+	if err != nil {
+		t.Fatalf("fetchStatusTree: %v", err)
+	}
+	var buf bytes.Buffer
+	renderExecutionStatus(&buf, tree, time.UnixMilli(9000), 60)
+	out := buf.String()
+
+	for _, want := range []string{
+		"Status      RUNNING\n",
+		"Duration    8s+\n",
+		"Tokens      15 (prompt 10, completion 5)\n",
+		"  Turn 1  call_llm  COMPLETED  2s  15 tokens\n",
+		"    ├─ lookup_order  COMPLETED  2s\n",
+		"    ├─ approve_refund  IN_PROGRESS  (human)  2s+\n",
+		"    └─ gone  FAILED\n",
+		"  triage (e1) RUNNING  8s+\n  ├─ billing (e2) COMPLETED  2s\n  └─ gone (e9): not found\n",
+		"  approve_refund (IN_PROGRESS, waiting 2s)\n",
+		"  call_llm                 |█████················|        2s\n",
+		"  approve_refund           |···············▓▓▓▓▓▓|       2s+\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status view is missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\nOutput") {
+		t.Errorf("status view shows an output the execution does not have:\n%s", out)
+	}
+}
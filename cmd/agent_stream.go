@@ -14,16 +14,25 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/conductor-oss/conductor-cli/internal"
 	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/recording"
+	"github.com/conductor-oss/conductor-cli/internal/toolserve"
+	"github.com/conductor-oss/conductor-cli/internal/transcript"
+	"github.com/conductor-oss/conductor-cli/internal/tui"
 )
 
 // Display truncation widths for streamed events — named so they are not magic
@@ -35,22 +44,81 @@ const (
 	truncEventData  = 150
 )
 
+// tuiUnknownAgent labels the root of the handoff tree when streaming an execution
+// by id alone, where the agent name is not known up front.
+const tuiUnknownAgent = "agent"
+
+// Stream output formats for --output.
+const (
+	streamOutputText   = "text"
+	streamOutputNDJSON = "ndjson"
+)
+
 var (
 	runName     string
 	runConfig   string
 	runSession  string
 	runNoStream bool
+	runTUI      bool
+	// runAutoApprove lists tools whose human-in-the-loop requests are approved
+	// without asking; shared by run and stream.
+	runAutoApprove []string
+	// runRecord is the JSON Lines file every streamed event is written to; shared
+	// by run and stream.
+	runRecord string
+	// runOutput and runTranscript choose the machine-readable stdout format and the
+	// Markdown transcript file; shared by run and stream.
+	runOutput     string
+	runTranscript string
+	// runServeTools is the --serve-tools directory or manifest.
+	runServeTools string
 )
 
 var agentRunCmd = &cobra.Command{
 	Use:   "run [prompt]",
 	Short: "Start an agent and stream its output",
 	Long: `Start an agent by --name or --config with a prompt and stream its execution
-events in real time. Use --no-stream to start it and just print the execution id.`,
-	Args:         cobra.MinimumNArgs(1),
+events in real time. Use --no-stream to start it and just print the execution id,
+or --tui to follow it in a full-screen view with expandable tool calls.
+
+When the agent waits for human input, an interactive terminal prompts to approve,
+deny or answer with a message. Unattended runs can approve named tools with
+--auto-approve-tools; anything else stays pending for 'conductor agent respond'.
+
+--record writes every raw event with its arrival time to a JSON Lines file that
+'conductor agent replay' plays back. --output ndjson prints one normalized event
+per line for scripts instead of the terminal view (status lines go to stderr), and
+--transcript writes the conversation as Markdown once the stream ends.
+
+--batch prompts.jsonl starts one execution per line instead of taking a prompt,
+--concurrency at a time, and tracks each to completion. A line is a JSON string
+or an object: {"id": "...", "prompt": "Summarize {{ticket}}", "session": "...",
+"variables": {"ticket": "..."}}. One JSON line per execution (line, id,
+executionId, status, output, durationMs) goes to --results, by default next to
+the prompts file; the command fails unless every execution completed.
+
+--serve-tools runs the agent's tool workers for the length of the execution, from
+a directory of tool files (<task_type>.js, or an executable named after its task
+type) or a manifest mapping each task type to a handler:
+
+  tools:
+    lookup_order: ./tools/lookup_order.py --verbose   # stdio worker program
+    issue_refund: ./tools/refund.js                   # JavaScript worker
+    search_docs: http://localhost:8080/search         # POST each task here
+
+The workers stop once the execution reaches a terminal event.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if runBatch != "" {
+			return checkBatchFlags(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		prompt := strings.Join(args, " ")
+		if err := checkStreamOutput(runTUI); err != nil {
+			return err
+		}
 		svc := internal.GetAgentService()
 
 		var req agent.RunRequest
@@ -66,37 +134,261 @@ events in real time. Use --no-stream to start it and just print the execution id
 		default:
 			return fmt.Errorf("specify either --name or --config")
 		}
+		if runBatch != "" {
+			return runAgentBatch(cmd, req)
+		}
+
+		var tools []toolserve.Binding
+		if runServeTools != "" {
+			if runNoStream || runTUI {
+				return fmt.Errorf("--serve-tools cannot be combined with --no-stream or --tui")
+			}
+			// Started before the execution so its first tool call is picked up at once;
+			// the deferred stop runs when the stream ends on a terminal event.
+			var stopTools func()
+			var err error
+			if tools, stopTools, err = startToolWorkers(cmd.Context(), runServeTools, statusWriter()); err != nil {
+				return err
+			}
+			defer stopTools()
+		}
 
 		exec, err := svc.Run(cmd.Context(), req)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("Agent: %s (Execution: %s)\n", exec.AgentName, exec.ID)
+		fmt.Fprintf(statusWriter(), "Agent: %s (Execution: %s)\n", exec.AgentName, exec.ID)
+		if runServeTools != "" {
+			warnUnservedTools(statusWriter(), runServeTools, tools, exec.RequiredWorkers)
+		}
 		if runNoStream {
 			return nil
 		}
-
-		fmt.Println()
-		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
-		defer stop()
-		return svc.StreamExecution(ctx, exec.ID, "", createTerminalSink())
+		return followExecution(cmd.Context(), svc, exec, prompt, "", runTUI)
 	},
 }
 
-var streamLastEventID string
+var (
+	streamLastEventID string
+	streamTUI         bool
+)
 
 var agentStreamCmd = &cobra.Command{
-	Use:          "stream <execution-id>",
-	Short:        "Stream events from a running execution",
+	Use:   "stream <execution-id>",
+	Short: "Stream events from a running execution",
+	Long: `Stream events from a running execution until it finishes. A dropped connection
+is reopened automatically from the last event received, backing off between
+attempts; --last-event-id resumes a stream from an earlier session.`,
 	Args:         cobra.ExactArgs(1),
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
-		defer stop()
-		return internal.GetAgentService().StreamExecution(ctx, args[0], streamLastEventID, createTerminalSink())
+		if err := checkStreamOutput(streamTUI); err != nil {
+			return err
+		}
+		return followExecution(cmd.Context(), internal.GetAgentService(), agent.Execution{ID: args[0]}, "", streamLastEventID, streamTUI)
 	},
 }
 
+// checkStreamOutput validates --output against the chosen view, and that the TUI
+// has a terminal before anything is started on the server.
+func checkStreamOutput(useTUI bool) error {
+	if useTUI {
+		if err := checkTUITerminal(); err != nil {
+			return err
+		}
+	}
+	switch runOutput {
+	case streamOutputText:
+		return nil
+	case streamOutputNDJSON:
+		if useTUI {
+			return fmt.Errorf("--output %s cannot be combined with --tui", streamOutputNDJSON)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid --output %q: use %s or %s", runOutput, streamOutputText, streamOutputNDJSON)
+	}
+}
+
+// isInteractiveTerminal reports whether stdin and stdout are both a terminal. A
+// variable so tests can stand in for one.
+var isInteractiveTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// checkTUITerminal fails unless the full-screen view has a terminal to run in.
+func checkTUITerminal() error {
+	if !isInteractiveTerminal() {
+		return fmt.Errorf("--tui requires an interactive terminal")
+	}
+	return nil
+}
+
+// statusWriter is where human-oriented status lines go: stdout, unless stdout
+// carries NDJSON that they would corrupt.
+func statusWriter() io.Writer {
+	if runOutput == streamOutputNDJSON {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// followExecution streams an execution to the terminal, as NDJSON or to the TUI,
+// answering human-input requests inline. --record and --transcript tap the stream;
+// prompt, when known, opens the transcript.
+func followExecution(ctx context.Context, svc agent.Service, exec agent.Execution, prompt, lastEventID string, useTUI bool) (err error) {
+	tap, closeRecording, err := openRecording(runRecord)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := closeRecording(); err == nil {
+			err = cerr
+		}
+	}()
+
+	if runTranscript != "" {
+		tr := &transcript.Transcript{Title: transcriptTitle(exec)}
+		if prompt != "" {
+			tr.Begin(prompt, exec.ID, exec.AgentName)
+		}
+		tap = withTap(tap, tr)
+		// Written however the stream ends: a transcript of a failed run is the one
+		// most worth attaching.
+		defer func() {
+			if werr := writeTranscript(runTranscript, tr); err == nil {
+				err = werr
+			}
+		}()
+	}
+
+	if useTUI {
+		return streamToTUI(ctx, svc, exec, lastEventID, tap)
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	var render agent.EventSink = createTerminalSink()
+	if runOutput == streamOutputNDJSON {
+		render = ndjsonSink{w: os.Stdout, executionID: exec.ID}
+	} else {
+		fmt.Println()
+	}
+	sink := newRespondingSink(ctx, render, svc, exec.ID, runAutoApprove, isInteractiveTerminal())
+	sink.w = statusWriter()
+	return svc.StreamExecution(ctx, exec.ID, lastEventID, withTap(tap, sink))
+}
+
+func transcriptTitle(exec agent.Execution) string {
+	if exec.AgentName != "" {
+		return "Agent " + exec.AgentName
+	}
+	return "Agent execution " + exec.ID
+}
+
+// writeTranscript renders tr as Markdown to path.
+func writeTranscript(path string, tr *transcript.Transcript) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create transcript: %w", err)
+	}
+	if err := tr.WriteMarkdown(f); err != nil {
+		f.Close()
+		return fmt.Errorf("write transcript: %w", err)
+	}
+	return f.Close()
+}
+
+// ndjsonSink prints each event as one normalized agent.EventPayload per line, so
+// scripts get a single stable shape whichever way the server framed the event.
+type ndjsonSink struct {
+	w           io.Writer
+	executionID string
+}
+
+func (s ndjsonSink) OnEvent(e agent.SSEEvent) error {
+	p := e.Normalized()
+	if p.ExecutionID == "" {
+		p.ExecutionID = s.executionID
+	}
+	line, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.w, "%s\n", line)
+	return err
+}
+
+// openRecording creates the --record file and returns a sink writing to it, or a
+// nil sink when path is empty. The close function must be called once streaming ends.
+func openRecording(path string) (agent.EventSink, func() error, error) {
+	if path == "" {
+		return nil, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create recording: %w", err)
+	}
+	return recording.NewRecorder(f), f.Close, nil
+}
+
+// withTap puts tap ahead of sink, so an event is recorded even if rendering it
+// fails. A nil tap leaves sink as is.
+func withTap(tap, sink agent.EventSink) agent.EventSink {
+	if tap == nil {
+		return sink
+	}
+	return agent.MultiSink(tap, sink)
+}
+
+// streamToTUI follows an execution in the full-screen TUI, passing every event
+// through tap (which may be nil) before the view.
+func streamToTUI(ctx context.Context, svc agent.Service, exec agent.Execution, lastEventID string, tap agent.EventSink) error {
+	return showInTUI(ctx, exec, func(ctx context.Context, view agent.EventSink) error {
+		// The TUI owns the keyboard and the screen, so only the allowlist can answer
+		// a waiting request from here, and silently.
+		sink := newRespondingSink(ctx, view, svc, exec.ID, runAutoApprove, false)
+		sink.w = io.Discard
+		return svc.StreamExecution(ctx, exec.ID, lastEventID, withTap(tap, sink))
+	})
+}
+
+// showInTUI shows exec in the full-screen TUI while produce feeds it events through
+// the view sink. produce runs in the background; the TUI stays up after a terminal
+// event so the transcript can still be browsed, and quitting it ends produce. Once
+// the normal screen is back, a one-line summary is printed.
+func showInTUI(ctx context.Context, exec agent.Execution, produce func(ctx context.Context, view agent.EventSink) error) error {
+	if err := checkTUITerminal(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	agentName := exec.AgentName
+	if agentName == "" {
+		agentName = tuiUnknownAgent
+	}
+	prog := tui.NewProgram(tui.NewModel(exec.ID, agentName, time.Now()), os.Stdin, os.Stdout)
+
+	streamErr := make(chan error, 1)
+	go func() {
+		err := produce(ctx, prog.Sink())
+		if errors.Is(err, tui.ErrQuit) {
+			err = nil
+		}
+		streamErr <- err
+		prog.Send(tui.StreamEndedMsg{Err: err})
+	}()
+
+	final, err := prog.Run(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Println(final.Summary())
+	return <-streamErr
+}
+
 // terminalSink renders streamed agent events to a writer, stdout in production. It is
 // the cmd-layer presentation of agent.EventSink; the service and client know nothing
 // about it. Every field it reads comes from the typed payload, so a server-side rename
@@ -164,8 +456,24 @@ func init() {
 	agentRunCmd.Flags().StringVar(&runConfig, "config", "", "Path to an agent config file (YAML/JSON)")
 	agentRunCmd.Flags().StringVar(&runSession, "session", "", "Session id for conversation continuity")
 	agentRunCmd.Flags().BoolVar(&runNoStream, "no-stream", false, "Start the agent and print the execution id without streaming")
+	agentRunCmd.Flags().BoolVar(&runTUI, "tui", false, "Follow the execution in an interactive full-screen view")
+	agentRunCmd.MarkFlagsMutuallyExclusive("no-stream", "tui")
+	agentRunCmd.Flags().StringSliceVar(&runAutoApprove, "auto-approve-tools", nil, "Comma-separated tools whose human-input requests are approved automatically")
+	agentRunCmd.Flags().StringVar(&runRecord, "record", "", "Write every streamed event with timestamps to this JSON Lines file")
+	agentRunCmd.Flags().StringVarP(&runOutput, "output", "o", streamOutputText, "Stream output: text or ndjson (one normalized event per line)")
+	agentRunCmd.Flags().StringVar(&runTranscript, "transcript", "", "Write the conversation as Markdown to this file when the stream ends")
+	agentRunCmd.Flags().StringVar(&runServeTools, "serve-tools", "", serveToolsHelp)
+	agentRunCmd.Flags().StringVar(&runBatch, "batch", "", "Run one execution per line of this JSON Lines prompts file")
+	agentRunCmd.Flags().IntVar(&runBatchConcurrency, "concurrency", agent.DefaultConcurrency, "Executions to run at once with --batch")
+	agentRunCmd.Flags().StringVar(&runBatchResults, "results", "", "Results file for --batch (default: <prompts>"+batchResultsSuffix+")")
+	agentRunCmd.Flags().DurationVar(&runBatchTimeout, "timeout", 0, "Per-execution time limit with --batch (0 means none)")
 
 	agentStreamCmd.Flags().StringVar(&streamLastEventID, "last-event-id", "", "Resume streaming after this event id")
+	agentStreamCmd.Flags().BoolVar(&streamTUI, "tui", false, "Follow the execution in an interactive full-screen view")
+	agentStreamCmd.Flags().StringSliceVar(&runAutoApprove, "auto-approve-tools", nil, "Comma-separated tools whose human-input requests are approved automatically")
+	agentStreamCmd.Flags().StringVar(&runRecord, "record", "", "Write every streamed event with timestamps to this JSON Lines file")
+	agentStreamCmd.Flags().StringVarP(&runOutput, "output", "o", streamOutputText, "Stream output: text or ndjson (one normalized event per line)")
+	agentStreamCmd.Flags().StringVar(&runTranscript, "transcript", "", "Write the conversation as Markdown to this file when the stream ends")
 
 	agentCmd.AddCommand(agentRunCmd, agentStreamCmd)
 }
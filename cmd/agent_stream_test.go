@@ -135,3 +135,29 @@ func TestTerminalSinkTruncatesLongFields(t *testing.T) {
 		t.Errorf("rendered %q, want %q", out.String(), want)
 	}
 }
+
+func TestCheckStreamOutput(t *testing.T) {
+	defer func(prev string, prevTerminal func() bool) {
+		runOutput, isInteractiveTerminal = prev, prevTerminal
+	}(runOutput, isInteractiveTerminal)
+	for _, tt := range []struct {
+		output   string
+		tui      bool
+		terminal bool
+		ok       bool
+	}{
+		{streamOutputText, true, true, true},
+		{streamOutputText, false, false, true},
+		// Without a terminal --tui fails here, before an execution is started.
+		{streamOutputText, true, false, false},
+		{streamOutputNDJSON, false, false, true},
+		{streamOutputNDJSON, true, true, false},
+		{"yaml", false, true, false},
+	} {
+		runOutput = tt.output
+		isInteractiveTerminal = func() bool { return tt.terminal }
+		if err := checkStreamOutput(tt.tui); (err == nil) != tt.ok {
+			t.Errorf("checkStreamOutput(%s, tui=%t, terminal=%t) = %v, want ok=%t", tt.output, tt.tui, tt.terminal, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/taskworker"
+	"github.com/conductor-oss/conductor-cli/internal/toolserve"
+)
+
+// serveToolsHelp documents --serve-tools for both commands that take it.
+const serveToolsHelp = "Serve tool workers from this directory of tool files or tool manifest"
+
+// startToolWorkers loads the --serve-tools bindings at path and starts a worker
+// loop for each, reporting to w what it serves. The caller stops the workers when
+// it is done with them.
+func startToolWorkers(ctx context.Context, path string, w io.Writer) (bindings []toolserve.Binding, stop func(), err error) {
+	if bindings, err = loadToolBindings(path); err != nil {
+		return nil, nil, err
+	}
+	handlers, err := toolHandlers(bindings, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	types := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		types = append(types, b.TaskType)
+	}
+	fmt.Fprintf(w, "Serving %d tool(s): %s\n", len(types), strings.Join(types, ", "))
+
+	runner := taskworker.NewConductorRunner(internal.GetTaskClient(), taskworker.RunnerOptions{})
+	return bindings, toolserve.Start(ctx, runner, handlers), nil
+}
+
+// warnUnservedTools reports required task types that no binding serves: the agent
+// will wait on them until someone starts a worker.
+func warnUnservedTools(w io.Writer, path string, bindings []toolserve.Binding, required []string) {
+	if missing := toolserve.Missing(bindings, required); len(missing) > 0 {
+		fmt.Fprintf(w, "Warning: no handler in %s for required tool(s): %s\n", path, strings.Join(missing, ", "))
+	}
+}
+
+// loadToolBindings reads a tool manifest, or binds each tool file in a directory:
+// .js files and executables, named after their task type.
+func loadToolBindings(path string) ([]toolserve.Binding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("serve tools: %w", err)
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read tool manifest: %w", err)
+		}
+		bindings, err := toolserve.ParseManifest(data, filepath.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return bindings, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("read tools directory: %w", err)
+	}
+	var bindings []toolserve.Binding
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		b := toolserve.FileBinding(path, e.Name())
+		if b.Kind == toolserve.KindCommand {
+			fi, err := e.Info()
+			if err != nil || fi.Mode()&0o111 == 0 {
+				continue
+			}
+		}
+		if slices.ContainsFunc(bindings, func(o toolserve.Binding) bool { return o.TaskType == b.TaskType }) {
+			return nil, fmt.Errorf("%s: more than one file for tool %q", path, b.TaskType)
+		}
+		bindings = append(bindings, b)
+	}
+	if len(bindings) == 0 {
+		return nil, fmt.Errorf("%s has no tool files (.js files or executables named after their task type)", path)
+	}
+	return bindings, nil
+}
+
+// An HTTP tool's request may take toolHTTPTimeout, and has toolStopGrace to finish
+// once the tools are stopped, so a hung endpoint cannot keep the CLI from exiting.
+const (
+	toolHTTPTimeout = 2 * time.Minute
+	toolStopGrace   = 5 * time.Second
+)
+
+// toolHandlers builds each binding's handler. Stdio tools echo their output to w,
+// which keeps it off stdout when stdout carries NDJSON.
+func toolHandlers(bindings []toolserve.Binding, w io.Writer) (map[string]taskworker.Handler, error) {
+	handlers := make(map[string]taskworker.Handler, len(bindings))
+	for _, b := range bindings {
+		switch b.Kind {
+		case toolserve.KindCommand:
+			handlers[b.TaskType] = taskworker.NewStdioHandler(taskworker.StdioOptions{
+				Command: b.Target,
+				Args:    b.Args,
+				Env:     workerChildEnv(),
+				Echo:    w,
+			})
+		case toolserve.KindJS:
+			script, err := os.ReadFile(b.Target)
+			if err != nil {
+				return nil, fmt.Errorf("read tool %q: %w", b.TaskType, err)
+			}
+			h, err := taskworker.NewGojaHandler(string(script), b.Target)
+			if err != nil {
+				return nil, fmt.Errorf("tool %q: %w", b.TaskType, err)
+			}
+			handlers[b.TaskType] = h
+		case toolserve.KindHTTP:
+			handlers[b.TaskType] = taskworker.NewHTTPHandler(taskworker.HTTPOptions{
+				URL:       b.Target,
+				Timeout:   toolHTTPTimeout,
+				StopGrace: toolStopGrace,
+			})
+		}
+	}
+	return handlers, nil
+}
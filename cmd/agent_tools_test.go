@@ -0,0 +1,71 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/toolserve"
+)
+
+func TestLoadToolBindingsFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]os.FileMode{
+		"refund.js": 0o644,
+		"lookup.sh": 0o755,
+		"README.md": 0o644, // not executable: skipped
+		".env":      0o755, // hidden: skipped
+	}
+	for name, mode := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bindings, err := loadToolBindings(dir)
+	if err != nil {
+		t.Fatalf("loadToolBindings: %v", err)
+	}
+	got := map[string]toolserve.Kind{}
+	for _, b := range bindings {
+		got[b.TaskType] = b.Kind
+	}
+	if len(got) != 2 || got["refund"] != toolserve.KindJS || got["lookup"] != toolserve.KindCommand {
+		t.Errorf("bindings = %+v, want refund (js) and lookup (command)", bindings)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "lookup.js"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadToolBindings(dir); err == nil {
+		t.Error("loadToolBindings accepted two files for one tool")
+	}
+}
+
+func TestLoadToolBindingsFromManifest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(path, []byte("tools:\n  refund: ./refund.js\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	bindings, err := loadToolBindings(path)
+	if err != nil {
+		t.Fatalf("loadToolBindings: %v", err)
+	}
+	if len(bindings) != 1 || bindings[0].Target != filepath.Join(dir, "refund.js") {
+		t.Errorf("bindings = %+v, want refund resolved next to the manifest", bindings)
+	}
+}
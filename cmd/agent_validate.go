@@ -0,0 +1,130 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal/agentlint"
+)
+
+var (
+	validateJSON   bool
+	validateStrict bool
+	validateSchema bool
+)
+
+var agentValidateCmd = &cobra.Command{
+	Use:   "validate <config-file>...",
+	Short: "Check agent config files offline",
+	Long: `Check agent config files without a server: against the bundled JSON Schema
+(unknown keys, types, required fields), then model identifiers against the
+providers 'conductor doctor' knows and the credentials set in this environment,
+duplicate tool, guardrail and sub-agent names, worker tools without a task
+definition, and the fields each multi-agent strategy needs.
+
+Problems are printed as file:line:column. Errors fail the command; warnings do
+too with --strict. --schema prints the bundled schema for editors and CI tools.`,
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Args: func(cmd *cobra.Command, args []string) error {
+		if validateSchema {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if validateSchema {
+			fmt.Print(string(agentlint.SchemaJSON))
+			return nil
+		}
+
+		opts := agentlint.Options{Providers: lintProviders()}
+		type fileResult struct {
+			File        string                 `json:"file"`
+			Diagnostics []agentlint.Diagnostic `json:"diagnostics"`
+		}
+		var results []fileResult
+		errs, warns := 0, 0
+		for _, path := range args {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("read config file: %w", err)
+			}
+			diags := agentlint.Validate(data, opts)
+			for _, d := range diags {
+				if d.Severity == agentlint.SeverityError {
+					errs++
+				} else {
+					warns++
+				}
+				if !validateJSON {
+					fmt.Printf("%s:%s\n", path, d)
+				}
+			}
+			if diags == nil {
+				diags = []agentlint.Diagnostic{}
+			}
+			results = append(results, fileResult{File: path, Diagnostics: diags})
+		}
+
+		if validateJSON {
+			data, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		} else if errs == 0 && warns == 0 {
+			fmt.Printf("%d file(s) valid.\n", len(args))
+		}
+
+		if errs > 0 || (validateStrict && warns > 0) {
+			return fmt.Errorf("%d error(s), %d warning(s)", errs, warns)
+		}
+		return nil
+	},
+}
+
+// lintProviders turns doctor's provider registry into the one validation checks
+// model identifiers against: each provider's id prefix comes from its example
+// models.
+func lintProviders() []agentlint.Provider {
+	var out []agentlint.Provider
+	for _, p := range aiProviders {
+		if len(p.models) == 0 {
+			continue
+		}
+		prefix, _, _ := strings.Cut(p.models[0], "/")
+		out = append(out, agentlint.Provider{
+			Name:       p.name,
+			Prefix:     prefix,
+			EnvVars:    p.envVars,
+			Configured: isProviderConfigured(p),
+		})
+	}
+	return out
+}
+
+func init() {
+	agentValidateCmd.Flags().BoolVar(&validateJSON, "json", false, "Print the diagnostics as JSON")
+	agentValidateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Fail on warnings as well as errors")
+	agentValidateCmd.Flags().BoolVar(&validateSchema, "schema", false, "Print the bundled JSON Schema and exit")
+
+	agentCmd.AddCommand(agentValidateCmd)
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// Every example model in doctor's registry must pass validation, or validate would
+// reject the identifiers doctor recommends.
+func TestLintProvidersAcceptDoctorModels(t *testing.T) {
+	providers := lintProviders()
+	if len(providers) != len(aiProviders) {
+		t.Fatalf("got %d providers, want one per registry entry (%d)", len(providers), len(aiProviders))
+	}
+	for _, p := range aiProviders {
+		for _, m := range p.models {
+			prefix, _, _ := strings.Cut(m, "/")
+			found := false
+			for _, lp := range providers {
+				found = found || lp.Prefix == prefix
+			}
+			if !found {
+				t.Errorf("model %s: no provider with prefix %q", m, prefix)
+			}
+		}
+	}
+}
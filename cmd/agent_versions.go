@@ -0,0 +1,252 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+// versionLatest names the newest version wherever a version number is accepted.
+const versionLatest = "latest"
+
+// ---- agent versions ----
+
+var agentVersionsCmd = &cobra.Command{
+	Use:          "versions <name>",
+	Short:        "List the stored versions of an agent",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := GetOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		versions, err := internal.GetAgentService().Versions(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		return renderAgentVersions(versions, format)
+	},
+}
+
+func renderAgentVersions(versions []agent.AgentVersion, format OutputFormat) error {
+	switch format {
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(versions, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case OutputFormatCSV:
+		w := NewCSVWriter()
+		w.WriteHeader("VERSION", "LATEST", "MODEL", "TOOLS")
+		for _, v := range versions {
+			w.WriteRow(strconv.Itoa(v.Version), strconv.FormatBool(v.Latest), v.Model, strconv.Itoa(v.Tools))
+		}
+		w.Flush()
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tMODEL\tTOOLS\t")
+		for _, v := range versions {
+			marker := ""
+			if v.Latest {
+				marker = "(latest)"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", v.Version, v.Model, v.Tools, marker)
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+// ---- agent diff ----
+
+var agentDiffJSON bool
+
+var agentDiffCmd = &cobra.Command{
+	Use:   "diff <name> <from-version> <to-version>",
+	Short: "Compare two versions of an agent",
+	Long: `Compare two stored versions of an agent: model and instructions (line by line),
+tools and guardrails entry by entry, then any other field. Either version may be
+'latest'.`,
+	Args:         cobra.ExactArgs(3),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := parseAgentVersion(args[1])
+		if err != nil {
+			return err
+		}
+		to, err := parseAgentVersion(args[2])
+		if err != nil {
+			return err
+		}
+		svc := internal.GetAgentService()
+		a, err := svc.Get(cmd.Context(), args[0], from)
+		if err != nil {
+			return fmt.Errorf("get %s %s: %w", args[0], args[1], err)
+		}
+		b, err := svc.Get(cmd.Context(), args[0], to)
+		if err != nil {
+			return fmt.Errorf("get %s %s: %w", args[0], args[2], err)
+		}
+		changes, err := agent.DiffDefinitions(a, b)
+		if err != nil {
+			return err
+		}
+		if agentDiffJSON {
+			if changes == nil {
+				changes = []jsondiff.Change{}
+			}
+			data, err := json.MarshalIndent(changes, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Printf("%s: %s -> %s\n", args[0], args[1], args[2])
+		renderAgentDiff(os.Stdout, changes)
+		return nil
+	},
+}
+
+// parseAgentVersion reads a version argument: a positive number, or "latest" for nil.
+func parseAgentVersion(s string) (*int, error) {
+	if strings.EqualFold(s, versionLatest) {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 1 {
+		return nil, fmt.Errorf("invalid version %q: use a positive number or %s", s, versionLatest)
+	}
+	return &v, nil
+}
+
+// renderAgentDiff prints one line per change, marked + added, - removed or
+// ~ changed; a changed multi-line value is shown as a line diff.
+func renderAgentDiff(w io.Writer, changes []jsondiff.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No differences.")
+		return
+	}
+	for _, c := range changes {
+		switch c.Kind {
+		case jsondiff.Added:
+			fmt.Fprintf(w, "+ %s: %s\n", c.Path, c.New)
+		case jsondiff.Removed:
+			fmt.Fprintf(w, "- %s: %s\n", c.Path, c.Old)
+		default:
+			if !strings.Contains(c.Old, "\n") && !strings.Contains(c.New, "\n") {
+				fmt.Fprintf(w, "~ %s: %s -> %s\n", c.Path, c.Old, c.New)
+				continue
+			}
+			fmt.Fprintf(w, "~ %s:\n", c.Path)
+			for _, l := range lineDiff(strings.Split(c.Old, "\n"), strings.Split(c.New, "\n")) {
+				fmt.Fprintf(w, "    %s\n", l)
+			}
+		}
+	}
+}
+
+// lineDiff returns a minimal line diff of a and b: unchanged lines prefixed "  ",
+// removed "- " and added "+ ", from their longest common subsequence.
+func lineDiff(a, b []string) []string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var out []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, "  "+a[i])
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "- "+a[i])
+			i++
+		default:
+			out = append(out, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+ "+b[j])
+	}
+	return out
+}
+
+// ---- agent rollback ----
+
+var rollbackTo int
+
+var agentRollbackCmd = &cobra.Command{
+	Use:   "rollback <name> --to <version>",
+	Short: "Re-deploy an older version of an agent as the latest",
+	Long: `Re-deploy a stored version of an agent as a new latest version. History is kept:
+the versions after it stay stored and can be compared with 'agent diff'.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if rollbackTo < 1 {
+			return fmt.Errorf("--to must be a positive version number")
+		}
+		if !yes && !confirm(fmt.Sprintf("Re-deploy version %d of '%s' as the latest?", rollbackTo, args[0])) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+		res, err := internal.GetAgentService().Rollback(cmd.Context(), args[0], rollbackTo)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back '%s' to version %d.\n", args[0], rollbackTo)
+		if len(res.RequiredWorkers) > 0 {
+			fmt.Printf("Requires workers for: %s\n", strings.Join(res.RequiredWorkers, ", "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	AddOutputFlags(agentVersionsCmd)
+	agentDiffCmd.Flags().BoolVar(&agentDiffJSON, "json", false, "Print the changes as JSON")
+	agentRollbackCmd.Flags().IntVar(&rollbackTo, "to", 0, "Version to restore")
+	_ = agentRollbackCmd.MarkFlagRequired("to")
+
+	agentCmd.AddCommand(agentVersionsCmd, agentDiffCmd, agentRollbackCmd)
+}
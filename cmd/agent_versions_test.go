@@ -0,0 +1,62 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+func TestRenderAgentDiff(t *testing.T) {
+	var buf bytes.Buffer
+	renderAgentDiff(&buf, []jsondiff.Change{
+		{Path: "model", Kind: jsondiff.Changed, Old: "m1", New: "m2"},
+		{Path: "instructions", Kind: jsondiff.Changed, Old: "one\ntwo\nthree", New: "one\n2\nthree\nfour"},
+		{Path: "tools[x]", Kind: jsondiff.Removed, Old: "x"},
+	})
+	want := `~ model: m1 -> m2
+~ instructions:
+      one
+    - two
+    + 2
+      three
+    + four
+- tools[x]: x
+`
+	if buf.String() != want {
+		t.Errorf("renderAgentDiff =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	renderAgentDiff(&buf, nil)
+	if buf.String() != "No differences.\n" {
+		t.Errorf("empty diff = %q", buf.String())
+	}
+}
+
+func TestParseAgentVersion(t *testing.T) {
+	if v, err := parseAgentVersion("latest"); err != nil || v != nil {
+		t.Errorf("latest = %v, %v; want nil", v, err)
+	}
+	if v, err := parseAgentVersion("3"); err != nil || *v != 3 {
+		t.Errorf("3 = %v, %v", v, err)
+	}
+	for _, bad := range []string{"0", "-1", "v2"} {
+		if _, err := parseAgentVersion(bad); err == nil {
+			t.Errorf("parseAgentVersion(%q) succeeded, want an error", bad)
+		}
+	}
+}
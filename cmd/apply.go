@@ -0,0 +1,262 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/apply"
+)
+
+var (
+	applyFiles  []string
+	applyDryRun bool
+	applyPrune  bool
+	applyLabel  string
+	applyJSON   bool
+)
+
+// applyExtensions are the files apply reads from a directory.
+var applyExtensions = map[string]bool{".json": true, ".yaml": true, ".yml": true}
+
+// enterpriseKinds are the resource kinds only Orkes Conductor serves.
+var enterpriseKinds = map[apply.Kind]bool{
+	apply.KindWebhook:           true,
+	apply.KindGatewayAuthConfig: true,
+	apply.KindGatewayService:    true,
+}
+
+// applyPlanMarks prefix each resource in a plan, as in a diff.
+var applyPlanMarks = map[apply.Action]string{
+	apply.ActionCreate:    "+",
+	apply.ActionUpdate:    "~",
+	apply.ActionUnchanged: "=",
+	apply.ActionDelete:    "-",
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <file|dir>",
+	Short: "Create or update resources from declarative files",
+	Long: `Make the server match a set of resource files: task definitions, workflows,
+event handlers, schedules, webhooks, and API gateway services and auth configs.
+Each file is JSON or YAML and holds one resource, a list of them, or several YAML
+documents; each resource's kind is told from its keys. A directory is read
+recursively.
+
+Resources are created or updated in dependency order: task definitions, then
+workflows, then event handlers, schedules, webhooks and gateway configs. The plan
+is printed first, with each update's changes; --dry-run stops there. A field a
+file leaves out is shown as removed unless the server holds just the default it
+fills in.
+
+--label tags every resource apply writes, and --prune then deletes the resources
+with that label the files no longer declare, asking first unless --yes is given.
+Workflows are pruned by name, keeping the older versions of the ones still
+declared; event handlers and gateway configs carry no labels and are never
+pruned.`,
+	Example: `  # Preview what applying a directory would change
+  conductor apply -f resources/ --dry-run
+
+  # Apply it, deleting what was removed from the directory since the last apply
+  conductor apply -f resources/ --label app=orders --prune`,
+	GroupID:      "conductor",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runApply,
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	if len(applyFiles) == 0 {
+		return fmt.Errorf("name the files or directories to apply with -f")
+	}
+	if applyPrune && applyJSON && !applyDryRun && !yes {
+		return fmt.Errorf("--prune with --json cannot ask before deleting; pass --yes")
+	}
+	opts := apply.Options{Prune: applyPrune}
+	if applyLabel != "" {
+		label, err := apply.ParseLabel(applyLabel)
+		if err != nil {
+			return err
+		}
+		opts.Label = &label
+	}
+	resources, err := loadApplyResources(applyFiles)
+	if err != nil {
+		return err
+	}
+	if len(resources) == 0 {
+		return fmt.Errorf("no resources found in %s", strings.Join(applyFiles, ", "))
+	}
+	if !isEnterpriseServer() {
+		for _, r := range resources {
+			if enterpriseKinds[r.Kind] {
+				return fmt.Errorf("%s (%s): webhooks and gateway configs need Orkes Conductor", r, r.Source)
+			}
+		}
+	}
+
+	ctx := cmd.Context()
+	srv := apply.NewClient(internal.Transport())
+	steps, err := apply.Plan(ctx, srv, resources, opts)
+	if err != nil {
+		return err
+	}
+	if applyJSON {
+		return runApplyJSON(ctx, srv, steps)
+	}
+
+	fmt.Print(formatApplyPlan(steps))
+	if applyDryRun || !hasApplyWork(steps) {
+		return nil
+	}
+	if deletes := countApplyAction(steps, apply.ActionDelete); deletes > 0 && !yes &&
+		!confirm(fmt.Sprintf("Delete %d resource(s) labelled %s?", deletes, opts.Label)) {
+		fmt.Println("Apply cancelled.")
+		return nil
+	}
+	done, err := apply.Apply(ctx, srv, steps)
+	if err != nil {
+		return fmt.Errorf("%w (%d of %d step(s) done)", err, done, len(steps))
+	}
+	fmt.Println("\nApplied.")
+	return nil
+}
+
+func runApplyJSON(ctx context.Context, srv apply.Server, steps []apply.Step) error {
+	out := map[string]any{"plan": steps, "applied": false}
+	var applyErr error
+	if !applyDryRun {
+		var done int
+		done, applyErr = apply.Apply(ctx, srv, steps)
+		out["applied"] = applyErr == nil
+		if applyErr != nil {
+			out["error"] = applyErr.Error()
+			out["done"] = done
+		}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return applyErr
+}
+
+// loadApplyResources reads every resource in the named files and, recursively,
+// directories, skipping hidden directories such as .git.
+func loadApplyResources(paths []string) ([]apply.Resource, error) {
+	var resources []apply.Resource
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			rs, err := readApplyFile(root)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, rs...)
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !applyExtensions[strings.ToLower(filepath.Ext(path))] {
+				return nil
+			}
+			rs, err := readApplyFile(path)
+			resources = append(resources, rs...)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resources, nil
+}
+
+func readApplyFile(path string) ([]apply.Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return apply.Parse(path, data)
+}
+
+func formatApplyPlan(steps []apply.Step) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "\nPlan: %s.\n\n", apply.Summary(steps))
+	for _, s := range steps {
+		fmt.Fprintf(&buf, "  %s %s %s", applyPlanMarks[s.Action], s.Kind, s.Name)
+		if s.Version > 0 {
+			fmt.Fprintf(&buf, " v%d", s.Version)
+		}
+		fmt.Fprintf(&buf, " (%s)\n", s.Action)
+		if len(s.Changes) == 0 {
+			continue
+		}
+		var diff bytes.Buffer
+		renderWorkflowDiff(&diff, s.Changes)
+		writeIndented(&buf, diff.String())
+	}
+	return buf.String()
+}
+
+func writeIndented(w io.Writer, text string) {
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		fmt.Fprintf(w, "      %s\n", line)
+	}
+}
+
+func hasApplyWork(steps []apply.Step) bool {
+	return countApplyAction(steps, apply.ActionUnchanged) < len(steps)
+}
+
+func countApplyAction(steps []apply.Step, action apply.Action) int {
+	n := 0
+	for _, s := range steps {
+		if s.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+func init() {
+	applyCmd.Flags().StringSliceVarP(&applyFiles, "filename", "f", nil, "Resource file or directory to apply (repeatable)")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Print the plan without changing anything")
+	applyCmd.Flags().BoolVar(&applyPrune, "prune", false, "Delete resources with --label that the files no longer declare")
+	applyCmd.Flags().StringVar(&applyLabel, "label", "", "Label (key=value) to tag applied resources with and prune by")
+	applyCmd.Flags().BoolVar(&applyJSON, "json", false, "Output the plan as JSON")
+	rootCmd.AddCommand(applyCmd)
+}
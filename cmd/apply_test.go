@@ -0,0 +1,68 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/apply"
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+func TestLoadApplyResourcesWalksDirectory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"tasks/charge.json":   `{"name": "charge", "retryCount": 2}`,
+		"workflows/flow.yaml": "name: flow\ntasks: []\n",
+		"README.md":           "not a resource",
+		".git/config.json":    `{"not": "read"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resources, err := loadApplyResources([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 2 || resources[0].Kind != apply.KindTaskDef || resources[1].Kind != apply.KindWorkflow {
+		t.Fatalf("resources = %+v", resources)
+	}
+}
+
+func TestFormatApplyPlan(t *testing.T) {
+	steps := []apply.Step{
+		{Kind: apply.KindTaskDef, Name: "charge", Action: apply.ActionCreate},
+		{Kind: apply.KindWorkflow, Name: "flow", Version: 2, Action: apply.ActionUpdate, Changes: []jsondiff.Change{
+			{Task: "pay", Kind: jsondiff.Added, New: "SIMPLE at tasks[1]"},
+		}},
+		{Kind: apply.KindSchedule, Name: "nightly", Action: apply.ActionDelete},
+	}
+	want := "\nPlan: 1 to create, 1 to update, 0 unchanged, 1 to delete.\n\n" +
+		"  + taskDef charge (create)\n" +
+		"  ~ workflow flow v2 (update)\n" +
+		"      + task pay: SIMPLE at tasks[1]\n" +
+		"  - schedule nightly (delete)\n"
+	if got := formatApplyPlan(steps); got != want {
+		t.Errorf("plan =\n%s\nwant\n%s", got, want)
+	}
+}
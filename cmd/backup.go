@@ -0,0 +1,272 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/apply"
+	"github.com/conductor-oss/conductor-cli/internal/backup"
+	"github.com/conductor-oss/conductor-cli/internal/bundle"
+)
+
+const defaultBackupDir = "conductor-backup"
+
+// enterpriseSections are the snapshot sections only Orkes Conductor serves.
+var enterpriseSections = []string{"webhooks", "gateway", backup.SectionSecrets}
+
+// ---- backup ----
+
+var (
+	backupOutput string
+	backupOnly   []string
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Export all metadata to a snapshot directory",
+	Long: `Write the server's metadata to a new directory: every version of every workflow
+definition, task definitions, event handlers and schedules, and on Orkes Conductor
+webhooks, gateway services and auth configs, and the names of secrets. Each
+resource is a JSON file under a directory per kind, listed in manifest.json in
+dependency order. Secret values are never exported.
+
+Restore the snapshot, here or on another server, with 'conductor restore'.`,
+	Example: `  # Back up everything
+  conductor backup -o snapshot/
+
+  # Back up only workflows and their task definitions
+  conductor backup -o snapshot/ --only workflows,tasks`,
+	GroupID:      "conductor",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sections, err := backupSections(backupOnly)
+		if err != nil {
+			return err
+		}
+		if err := checkEmptyDir(backupOutput); err != nil {
+			return err
+		}
+		snapshot, err := backup.Take(cmd.Context(), newServerBackup(), sections)
+		if err != nil {
+			return err
+		}
+		snapshot.Source = internal.Transport().BaseURL
+		snapshot.CreatedAt = time.Now()
+
+		files, err := snapshot.Files()
+		if err != nil {
+			return err
+		}
+		if err := writeSnapshot(backupOutput, files); err != nil {
+			return err
+		}
+		printBackupSummary(os.Stdout, snapshot)
+		fmt.Printf("Wrote %d resource(s) to %s.\n", len(snapshot.Resources), backupOutput)
+		return nil
+	},
+}
+
+// backupSections resolves --only, leaving out the sections this server does not
+// serve.
+func backupSections(only []string) (map[string]bool, error) {
+	sections, err := backup.ParseSections(only)
+	if err != nil {
+		return nil, err
+	}
+	if isEnterpriseServer() {
+		return sections, nil
+	}
+	var skipped []string
+	for _, s := range enterpriseSections {
+		if sections[s] {
+			sections[s] = false
+			skipped = append(skipped, s)
+		}
+	}
+	if len(skipped) > 0 && len(only) > 0 {
+		fmt.Fprintf(os.Stderr, "Note: skipping %s, which need Orkes Conductor\n", strings.Join(skipped, ", "))
+	}
+	return sections, nil
+}
+
+func checkEmptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s is not empty; choose a new directory with -o", dir)
+	}
+	return nil
+}
+
+func writeSnapshot(dir string, files map[string][]byte) error {
+	for p, data := range files {
+		target := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printBackupSummary(w io.Writer, s backup.Snapshot) {
+	counts := map[apply.Kind]int{}
+	for _, r := range s.Resources {
+		counts[r.Kind]++
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for _, kind := range apply.Kinds {
+		if counts[kind] > 0 {
+			fmt.Fprintf(tw, "  %s\t%d\n", kind, counts[kind])
+		}
+	}
+	if len(s.Secrets) > 0 {
+		fmt.Fprintf(tw, "  secret names\t%d\n", len(s.Secrets))
+	}
+	tw.Flush()
+}
+
+// ---- restore ----
+
+var (
+	restoreOnly     []string
+	restoreConflict string
+	restoreDryRun   bool
+	restoreJSON     bool
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <snapshot-dir>",
+	Short: "Recreate the metadata in a snapshot on this server",
+	Long: `Recreate the resources in a snapshot written by 'conductor backup', in dependency
+order: task definitions, workflows, event handlers, schedules, webhooks, then
+gateway configs. Select the target server as usual, e.g. with --profile staging,
+to clone one environment into another.
+
+A resource that already exists unchanged is left alone. One that exists with a
+different definition is left alone as a conflict, or replaced with --conflict
+overwrite. --only restores some sections: tasks, workflows, events, schedules,
+webhooks, gateway or secrets. Secret values are not in a snapshot, so the secrets
+it names that this server lacks are listed to be set again by hand.`,
+	Example: `  # Restore everything, leaving existing resources that differ alone
+  conductor restore snapshot/
+
+  # Replace this server's workflows with the snapshot's
+  conductor restore snapshot/ --only workflows --conflict overwrite`,
+	GroupID:      "conductor",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conflict, err := backup.ParseConflict(restoreConflict)
+		if err != nil {
+			return err
+		}
+		sections, err := backupSections(restoreOnly)
+		if err != nil {
+			return err
+		}
+		snapshot, err := backup.Load(os.DirFS(args[0]))
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		ctx := cmd.Context()
+		dst := newServerBackup()
+		opts := backup.RestoreOptions{Sections: sections, Conflict: conflict, DryRun: restoreDryRun}
+		if conflict == backup.ConflictOverwrite && !restoreDryRun && !yes {
+			plan, _, err := backup.Restore(ctx, dst, snapshot, backup.RestoreOptions{Sections: sections, Conflict: conflict, DryRun: true})
+			if err != nil {
+				return err
+			}
+			if n := countOutcome(plan, bundle.OutcomeUpdated); n > 0 &&
+				!confirm(fmt.Sprintf("Replace %d existing resource(s) on %s?", n, internal.Transport().BaseURL)) {
+				fmt.Println("Restore cancelled.")
+				return nil
+			}
+		}
+
+		results, missing, err := backup.Restore(ctx, dst, snapshot, opts)
+		if restoreJSON {
+			out := map[string]any{"results": results, "missingSecrets": missing}
+			data, merr := json.MarshalIndent(out, "", "  ")
+			if merr != nil {
+				return merr
+			}
+			fmt.Println(string(data))
+		} else {
+			printRestoreResults(os.Stdout, results, missing, restoreDryRun)
+		}
+		return err
+	},
+}
+
+// printRestoreResults prints the results as import does, then the secrets to set
+// again by hand.
+func printRestoreResults(w io.Writer, results []bundle.ImportResult, missingSecrets []string, dryRun bool) {
+	printImportResults(w, results, dryRun)
+	if len(missingSecrets) > 0 {
+		fmt.Fprintf(w, "\nSet these secrets, whose values are not in the snapshot, with 'conductor secret put':\n")
+		for _, name := range missingSecrets {
+			fmt.Fprintf(w, "  %s\n", name)
+		}
+	}
+}
+
+// serverBackup is the backup.Server for the configured server: resources over
+// the REST client apply uses, which keeps every key of a definition, and secret
+// names through the SDK.
+type serverBackup struct {
+	apply.Server
+}
+
+func newServerBackup() serverBackup {
+	return serverBackup{Server: apply.NewClient(internal.Transport())}
+}
+
+func (serverBackup) SecretNames(ctx context.Context) ([]string, error) {
+	names, _, err := internal.GetSecretsClient().ListAllSecretNames(ctx)
+	return names, err
+}
+
+func init() {
+	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", defaultBackupDir, "Directory to write the snapshot to (must be new or empty)")
+	backupCmd.Flags().StringSliceVar(&backupOnly, "only", nil, "Back up only these sections: "+strings.Join(backup.Sections, ", "))
+
+	restoreCmd.Flags().StringSliceVar(&restoreOnly, "only", nil, "Restore only these sections: "+strings.Join(backup.Sections, ", "))
+	restoreCmd.Flags().StringVar(&restoreConflict, "conflict", string(backup.ConflictSkip), "What to do with resources that differ: skip or overwrite")
+	restoreCmd.Flags().BoolVar(&restoreDryRun, "dry-run", false, "Report what would change without writing")
+	restoreCmd.Flags().BoolVar(&restoreJSON, "json", false, "Print the per-resource results as JSON")
+
+	rootCmd.AddCommand(backupCmd, restoreCmd)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/bundle"
+)
+
+func TestCheckEmptyDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := checkEmptyDir(filepath.Join(dir, "new")); err != nil {
+		t.Errorf("a missing directory was refused: %v", err)
+	}
+	if err := checkEmptyDir(dir); err != nil {
+		t.Errorf("an empty directory was refused: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "old.json"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkEmptyDir(dir); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestPrintRestoreResultsListsMissingSecrets(t *testing.T) {
+	var out strings.Builder
+	results := []bundle.ImportResult{
+		{Kind: "workflow", Name: "flow", Version: 2, Outcome: bundle.OutcomeCreated},
+		{Kind: "taskDef", Name: "charge", Outcome: bundle.OutcomeConflict, Differences: []string{"retryCount"}},
+	}
+	printRestoreResults(&out, results, []string{"stripe_key"}, true)
+	for _, want := range []string{"flow v2", "would be created", "conflict", "retryCount",
+		"Dry run: 1 created, 1 conflict.", "  stripe_key\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+}
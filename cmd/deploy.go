@@ -18,35 +18,95 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/agent"
 	"github.com/conductor-oss/conductor-cli/internal/deploy"
 )
 
 const (
 	langPython     = "python"
 	langTypeScript = "typescript"
+	langJava       = "java"
+	langGo         = "go"
 )
 
-// discoveredAgent is one agent reported by the discover subprocess.
+// languageMarkers are the files whose presence identifies a project's language.
+// TypeScript is also recognised by a TypeScript dependency in package.json.
+var languageMarkers = []struct {
+	language string
+	files    []string
+}{
+	{langPython, []string{"pyproject.toml", "setup.py", "setup.cfg", "requirements.txt"}},
+	{langTypeScript, []string{"tsconfig.json"}},
+	{langJava, []string{"pom.xml", "build.gradle", "build.gradle.kts"}},
+	{langGo, []string{"go.mod"}},
+}
+
+// languageTool names one operation's entry point in each SDK: a Python module, a
+// TypeScript bin script, a Java main class and a Go package for `go run`. All of
+// them speak the same JSON protocol on stdout.
+type languageTool struct {
+	python, typescript, java, golang string
+}
+
+var (
+	discoverTool = languageTool{
+		python:     "agentspan.cli.discover",
+		typescript: "discover.ts",
+		java:       "dev.agentspan.cli.Discover",
+		golang:     "github.com/agentspan/agentspan-go/cli/discover",
+	}
+	deployTool = languageTool{
+		python:     "agentspan.cli.deploy",
+		typescript: "deploy.ts",
+		java:       "dev.agentspan.cli.Deploy",
+		golang:     "github.com/agentspan/agentspan-go/cli/deploy",
+	}
+)
+
+// gradleInitScript registers a task that runs a main class, named by the
+// agentspanMain property, on the project's runtime classpath, so Gradle projects
+// need no build changes to be deployed.
+const gradleInitScript = `allprojects {
+    afterEvaluate { p ->
+        if (p.plugins.hasPlugin('java')) {
+            p.tasks.register('agentspanCli', JavaExec) {
+                classpath = p.sourceSets.main.runtimeClasspath
+                mainClass = p.findProperty('agentspanMain')
+            }
+        }
+    }
+}
+`
+
+// discoveredAgent is one agent reported by the discover subprocess. Definition is
+// the agent as the server would store it, which --plan compares against; SDKs that
+// predate --plan leave it out.
 type discoveredAgent struct {
-	Name      string `json:"name"`
-	Framework string `json:"framework"`
+	Name       string          `json:"name"`
+	Framework  string          `json:"framework"`
+	Definition json.RawMessage `json:"definition,omitempty"`
 }
 
 // deployResult is the outcome of deploying one agent, as reported by the subprocess.
+// RequiredWorkers lists the tool task types the deployed agent needs served.
 type deployResult struct {
-	AgentName      string  `json:"agent_name"`
-	RegisteredName *string `json:"registered_name"`
-	Success        bool    `json:"success"`
-	Error          *string `json:"error"`
+	AgentName       string   `json:"agent_name"`
+	RegisteredName  *string  `json:"registered_name"`
+	Success         bool     `json:"success"`
+	Error           *string  `json:"error"`
+	RequiredWorkers []string `json:"required_workers,omitempty"`
 }
 
 var (
@@ -54,11 +114,57 @@ var (
 	deployLanguage string
 	deployPackage  string
 	deployJSON     bool
+	deployServe    string
+	deployPlan     bool
+	deployWatch    bool
+	deployPrune    bool
+	deployProject  string
+	deployTimeout  time.Duration
 )
 
 var deployCmd = &cobra.Command{
-	Use:          "deploy",
-	Short:        "Deploy agents from your project to the Conductor server",
+	Use:   "deploy",
+	Short: "Deploy agents from your project to the Conductor server",
+	Long: `Discover the agents in your project and deploy them to the Conductor server.
+
+--plan deploys nothing: it compares each discovered agent with the version on the
+server and shows which would be created, updated (with a structural diff) or left
+unchanged. Like 'terraform plan' it exits non-zero when changes are pending, so CI
+can catch an undeployed change.
+
+In a project with several agent packages, list them in a conductor.yaml at the
+project root. deploy then deploys every package in order, each with its own
+language, agent selection, environment and target profile, and reports once:
+
+  packages:
+    - name: support
+      path: services/support
+      language: python
+      exclude: [draft_agent]
+    - name: billing
+      path: services/billing
+      include: [invoicer]
+      env: {REGION: eu}
+      profile: prod
+
+--language or --package deploys a single package and ignores the manifest.
+
+--watch deploys once, then watches the source files of each package and, a
+moment after they stop changing, rediscovers that package and redeploys the agents
+whose definitions changed. Failures are reported and watching continues.
+
+Deploy tags every agent it deploys with project:<name>. --prune uses the tag to
+find agents an earlier deploy registered that the project no longer defines, such
+as one renamed or deleted in code, and deletes them after confirmation. Agents
+without the project's tag are never pruned. --prune needs the project named with
+--project or conductor.yaml's project: the directory's name, used otherwise, may
+be shared by unrelated projects. It also needs the whole project deployed, so it
+is refused with --language or --package when there is a manifest, and it fails
+if the SDK stored the deployed agents without the tag.
+
+SDKs that report progress have it shown per agent as they work. Ctrl-C interrupts
+the deploy tool, which runs in the same terminal and so receives it too, and reports
+which agents were already deployed; press it again to stop at once.`,
 	GroupID:      "development",
 	SilenceUsage: true,
 	RunE:         runDeploy,
@@ -71,6 +177,29 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	}
 
 	agentNames := cleanNames(deployAgents)
+	if deployPrune && deployJSON && !yes {
+		return fmt.Errorf("--prune with --json cannot ask before deleting; pass --yes")
+	}
+	if err := checkPruneScope(wd); err != nil {
+		return err
+	}
+	if deployServe != "" {
+		// Checked up front so a bad path fails before anything is deployed.
+		if _, err := loadToolBindings(deployServe); err != nil {
+			return err
+		}
+	}
+
+	// --language and --package describe a single package, so they bypass the manifest.
+	if deployLanguage == "" && deployPackage == "" {
+		manifest, ok, err := loadDeployManifest(wd)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return runManifestDeploy(cmd.Context(), wd, manifest, agentNames)
+		}
+	}
 
 	language, err := detectLanguage(wd, deployLanguage)
 	if err != nil {
@@ -89,13 +218,18 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	tag, err := projectTag(wd, "")
+	if err != nil {
+		return err
+	}
 	ctx := cmd.Context()
 	t := internal.Transport()
-	env, err := deploy.EnvBuilder{BaseURL: t.BaseURL, Tokens: t.Tokens, BaseEnv: os.Environ()}.Build(ctx)
+	env, err := deploy.EnvBuilder{BaseURL: t.BaseURL, Tokens: t.Tokens, BaseEnv: os.Environ(), Tags: []string{tag}}.Build(ctx)
 	if err != nil {
 		return err
 	}
-	runner := deploy.NewRunner()
+	progress := &deployProgress{w: deployStatusWriter()}
+	runner := deploy.NewRunner(deploy.RunnerOptions{Timeout: deployTimeout, Progress: progress.handle})
 
 	discovered, err := execDiscover(ctx, runner, env, language, pythonBin, wd, pkg)
 	if err != nil {
@@ -110,6 +244,22 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if deployWatch {
+		return runDeployWatch(ctx, []*manifestPackage{{
+			spec:      deploy.Package{Name: pkg.Value, Path: "."},
+			dir:       wd,
+			language:  language,
+			pythonBin: pythonBin,
+			pkg:       pkg,
+			env:       env,
+			runner:    runner,
+			svc:       internal.GetAgentService(),
+			agents:    discovered,
+		}}, agentNames)
+	}
+	if deployPlan {
+		return runDeployPlan(ctx, discovered)
+	}
 	if !deployJSON {
 		fmt.Println(formatDiscoveryTable(discovered, pkg.Value))
 		if !yes && !confirm("Deploy these agents?") {
@@ -121,13 +271,16 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	names := make([]string, len(discovered))
-	for i, a := range discovered {
-		names[i] = a.Name
+	deployCtx, cancel := context.WithCancel(ctx)
+	stopSignals := interruptWithEscalation(cancel)
+	results, err := execDeploy(deployCtx, runner, env, language, pythonBin, wd, pkg, namesOf(discovered))
+	stopSignals()
+	interrupted := deployCtx.Err() != nil
+	cancel()
+	if interrupted && len(results) == 0 {
+		return progress.interrupted(nil)
 	}
-
-	results, err := execDeploy(ctx, runner, env, language, pythonBin, wd, pkg, names)
-	if err != nil {
+	if err != nil && !interrupted {
 		return err
 	}
 
@@ -138,12 +291,29 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Under --json the prune runs first, so the report can include it.
+	prune := deployPrune && failed == 0 && !interrupted
+	var pruneTargets []pruneTarget
+	if prune {
+		deployed := registeredNames(results)
+		keep := append(namesOf(allDiscovered), deployed...)
+		pruneTargets = []pruneTarget{{server: t.BaseURL, svc: internal.GetAgentService(), keep: keep, deployed: deployed}}
+	}
+	var pruned []prunedAgent
+	var pruneErr error
+	if prune && deployJSON {
+		pruned, pruneErr = pruneProject(ctx, deployStatusWriter(), tag, pruneTargets)
+	}
+
 	if deployJSON {
 		out := map[string]any{
 			"discovered": allDiscovered,
 			"deployed":   results,
 			"summary":    map[string]int{"total": len(results), "succeeded": len(results) - failed, "failed": failed},
 		}
+		if deployPrune {
+			out["pruned"] = pruned
+		}
 		data, mErr := json.MarshalIndent(out, "", "  ")
 		if mErr != nil {
 			return mErr
@@ -153,12 +323,167 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		fmt.Println(formatDeployOutput(results))
 	}
 
+	if interrupted {
+		return progress.interrupted(results)
+	}
 	if failed > 0 {
 		return fmt.Errorf("%d agent(s) failed to deploy", failed)
 	}
+	if prune && !deployJSON {
+		_, pruneErr = pruneProject(ctx, deployStatusWriter(), tag, pruneTargets)
+	}
+	if pruneErr != nil {
+		return pruneErr
+	}
+	if deployServe != "" {
+		return serveDeployedTools(ctx, results)
+	}
+	return nil
+}
+
+// runDeployPlan compares each agent's discovered definition with the server's and
+// fails when any would change.
+func runDeployPlan(ctx context.Context, agents []discoveredAgent) error {
+	plans, err := planAgents(ctx, internal.GetAgentService(), agents)
+	if err != nil {
+		return err
+	}
+	return reportDeployPlan(plans)
+}
+
+// planAgents plans each agent's deployment against the server svc talks to.
+func planAgents(ctx context.Context, svc agent.Service, agents []discoveredAgent) ([]agent.DeployPlan, error) {
+	var missing []string
+	for _, a := range agents {
+		if len(a.Definition) == 0 {
+			missing = append(missing, a.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the SDK did not report definitions for %s; upgrade it to use --plan", strings.Join(missing, ", "))
+	}
+	if err := svc.CheckSupported(ctx); err != nil {
+		return nil, err
+	}
+
+	plans := make([]agent.DeployPlan, 0, len(agents))
+	for _, a := range agents {
+		plan, err := svc.PlanDeploy(ctx, a.Name, a.Definition)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// reportDeployPlan prints plans and fails when any agent would change.
+func reportDeployPlan(plans []agent.DeployPlan) error {
+	pending := 0
+	for _, p := range plans {
+		if p.Action != agent.DeployUnchanged {
+			pending++
+		}
+	}
+
+	if deployJSON {
+		data, err := json.MarshalIndent(map[string]any{"plan": plans, "pending": pending}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(formatDeployPlan(plans))
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d agent(s) have changes pending", pending)
+	}
 	return nil
 }
 
+// deployStatusWriter is where deploy prints progress: stderr under --json, so
+// stdout stays a single JSON document.
+func deployStatusWriter() io.Writer {
+	if deployJSON {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// deployProgress renders the language tools' progress events and records the
+// agents they report deployed, so an interrupted deploy can say what it finished.
+type deployProgress struct {
+	w        io.Writer
+	deployed []string
+}
+
+func (p *deployProgress) handle(e deploy.ProgressEvent) {
+	if e.Stage == deploy.StageDeployed && e.Agent != "" {
+		p.deployed = append(p.deployed, e.Agent)
+	}
+	line := "  " + e.Stage
+	if e.Agent != "" {
+		line += " " + e.Agent
+	}
+	if e.Message != "" {
+		line += ": " + e.Message
+	}
+	fmt.Fprintln(p.w, line)
+}
+
+// interrupted is the error for a deploy the user cancelled. The agents deployed
+// are those reported by progress events or in results, since SDKs that predate
+// progress events only report at the end.
+func (p *deployProgress) interrupted(results []deployResult) error {
+	deployed := slices.Clone(p.deployed)
+	for _, r := range results {
+		if r.Success && !slices.Contains(deployed, r.AgentName) {
+			deployed = append(deployed, r.AgentName)
+		}
+	}
+	if len(deployed) == 0 {
+		return fmt.Errorf("deploy interrupted before any agent was deployed")
+	}
+	return fmt.Errorf("deploy interrupted; %d agent(s) were already deployed: %s", len(deployed), strings.Join(deployed, ", "))
+}
+
+// serveDeployedTools runs the --serve-tools workers for the deployed agents until
+// the user interrupts. Status lines go to stderr under --json.
+func serveDeployedTools(ctx context.Context, results []deployResult) error {
+	w := deployStatusWriter()
+	var required []string
+	for _, r := range results {
+		for _, t := range r.RequiredWorkers {
+			if !slices.Contains(required, t) {
+				required = append(required, t)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stopSignals := interruptWithEscalation(cancel)
+	defer stopSignals()
+
+	tools, stopTools, err := startToolWorkers(ctx, deployServe, w)
+	if err != nil {
+		return err
+	}
+	warnUnservedTools(w, deployServe, tools, required)
+	fmt.Fprintln(w, "Press Ctrl-C to stop.")
+	<-ctx.Done()
+	stopTools()
+	return nil
+}
+
+func namesOf(agents []discoveredAgent) []string {
+	names := make([]string, len(agents))
+	for i, a := range agents {
+		names[i] = a.Name
+	}
+	return names
+}
+
 func cleanNames(names []string) []string {
 	out := names[:0]
 	for _, n := range names {
@@ -185,34 +510,35 @@ func detectLanguage(dir, override string) (string, error) {
 			return langPython, nil
 		case "typescript", "ts":
 			return langTypeScript, nil
+		case "java":
+			return langJava, nil
+		case "go", "golang":
+			return langGo, nil
 		default:
-			return "", fmt.Errorf("unsupported language %q (supported: python, typescript)", override)
+			return "", fmt.Errorf("unsupported language %q (supported: python, typescript, java, go)", override)
 		}
 	}
 
-	hasPython := false
-	for _, m := range []string{"pyproject.toml", "setup.py", "setup.cfg", "requirements.txt"} {
-		if _, err := os.Stat(filepath.Join(dir, m)); err == nil {
-			hasPython = true
-			break
+	var found []string
+	for _, m := range languageMarkers {
+		for _, f := range m.files {
+			if _, err := os.Stat(filepath.Join(dir, f)); err == nil {
+				found = append(found, m.language)
+				break
+			}
 		}
 	}
-	hasTS := false
-	if _, err := os.Stat(filepath.Join(dir, "tsconfig.json")); err == nil {
-		hasTS = true
-	} else if hasTSDependency(filepath.Join(dir, "package.json")) {
-		hasTS = true
+	if !slices.Contains(found, langTypeScript) && hasTSDependency(filepath.Join(dir, "package.json")) {
+		found = append(found, langTypeScript)
 	}
 
-	switch {
-	case hasPython && hasTS:
-		return "", fmt.Errorf("both Python and TypeScript markers found; use --language to disambiguate")
-	case hasPython:
-		return langPython, nil
-	case hasTS:
-		return langTypeScript, nil
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("no Python, TypeScript, Java or Go project markers found; use --language to specify")
+	case 1:
+		return found[0], nil
 	default:
-		return "", fmt.Errorf("no Python or TypeScript project markers found; use --language to specify")
+		return "", fmt.Errorf("markers for several languages found (%s); use --language to disambiguate", strings.Join(found, ", "))
 	}
 }
 
@@ -261,6 +587,13 @@ func inferPackage(dir, language, override string) (packageInfo, error) {
 			return packageInfo{Value: "./src", IsPath: true}, nil
 		}
 		return packageInfo{Value: ".", IsPath: true}, nil
+	case langJava:
+		if info, err := os.Stat(filepath.Join(dir, "src", "main", "java")); err == nil && info.IsDir() {
+			return packageInfo{Value: "./src/main/java", IsPath: true}, nil
+		}
+		return packageInfo{Value: ".", IsPath: true}, nil
+	case langGo:
+		return packageInfo{Value: ".", IsPath: true}, nil
 	default:
 		return packageInfo{}, fmt.Errorf("cannot infer package for language %q", language)
 	}
@@ -316,7 +649,7 @@ func findPythonBinary(dir string) string {
 }
 
 func execDiscover(ctx context.Context, runner deploy.Runner, env []string, language, pythonBin, projectDir string, pkg packageInfo) ([]discoveredAgent, error) {
-	data, err := runLanguageTool(ctx, runner, env, language, pythonBin, projectDir, pkg, "discover", "agentspan.cli.discover", "discover.ts", nil)
+	data, err := runLanguageTool(ctx, runner, env, language, pythonBin, projectDir, pkg, "discover", discoverTool, nil)
 	if err != nil && len(data) == 0 {
 		return nil, err
 	}
@@ -328,7 +661,7 @@ func execDiscover(ctx context.Context, runner deploy.Runner, env []string, langu
 }
 
 func execDeploy(ctx context.Context, runner deploy.Runner, env []string, language, pythonBin, projectDir string, pkg packageInfo, agentNames []string) ([]deployResult, error) {
-	data, err := runLanguageTool(ctx, runner, env, language, pythonBin, projectDir, pkg, "deploy", "agentspan.cli.deploy", "deploy.ts", agentNames)
+	data, err := runLanguageTool(ctx, runner, env, language, pythonBin, projectDir, pkg, "deploy", deployTool, agentNames)
 	if err != nil && len(data) == 0 {
 		return nil, err
 	}
@@ -339,31 +672,88 @@ func execDeploy(ctx context.Context, runner deploy.Runner, env []string, languag
 	return results, nil
 }
 
-// runLanguageTool invokes the python module or the TypeScript bin script for an
-// operation, passing the package/path and optional agent names.
-func runLanguageTool(ctx context.Context, runner deploy.Runner, env []string, language, pythonBin, projectDir string, pkg packageInfo, op, pyModule, tsScript string, agentNames []string) ([]byte, error) {
+// runLanguageTool invokes an operation's entry point for the project's language,
+// passing the package/path and optional agent names: the Python module, the
+// TypeScript bin script, the Java main class through Maven or Gradle, or the Go
+// package through `go run` inside the project's module.
+func runLanguageTool(ctx context.Context, runner deploy.Runner, env []string, language, pythonBin, projectDir string, pkg packageInfo, op string, tool languageTool, agentNames []string) ([]byte, error) {
+	flag := "--package"
+	if pkg.IsPath {
+		flag = "--path"
+	}
+	toolArgs := appendAgents([]string{flag, pkg.Value}, agentNames)
+
 	switch language {
 	case langPython:
-		flag := "--package"
-		if pkg.IsPath {
-			flag = "--path"
-		}
-		args := []string{"-m", pyModule, flag, pkg.Value}
-		args = appendAgents(args, agentNames)
-		return runner.Run(ctx, env, pythonBin, args...)
+		return runner.Run(ctx, env, pythonBin, append([]string{"-m", tool.python}, toolArgs...)...)
 	case langTypeScript:
-		script, err := findTSBinScript(projectDir, tsScript)
+		script, err := findTSBinScript(projectDir, tool.typescript)
 		if err != nil {
 			return nil, err
 		}
-		args := []string{"tsx", script, "--path", pkg.Value}
-		args = appendAgents(args, agentNames)
-		return runner.Run(ctx, env, "npx", args...)
+		return runner.Run(ctx, env, "npx", append([]string{"tsx", script, "--path", pkg.Value}, appendAgents(nil, agentNames)...)...)
+	case langJava:
+		return runJavaTool(ctx, runner, env, projectDir, tool.java, toolArgs)
+	case langGo:
+		return runner.Run(ctx, env, "go", append([]string{"run", tool.golang}, toolArgs...)...)
 	default:
 		return nil, fmt.Errorf("unsupported language for %s: %s", op, language)
 	}
 }
 
+// runJavaTool runs mainClass on the project's classpath with whichever build tool
+// the project uses, preferring its wrapper script.
+func runJavaTool(ctx context.Context, runner deploy.Runner, env []string, projectDir, mainClass string, args []string) ([]byte, error) {
+	if fileExists(filepath.Join(projectDir, "pom.xml")) {
+		return runner.Run(ctx, env, buildTool(projectDir, "mvnw", "mvn"),
+			"-q", "compile", "exec:java", "-Dexec.mainClass="+mainClass, "-Dexec.args="+joinToolArgs(args))
+	}
+
+	script, err := os.CreateTemp("", "agentspan-*.gradle")
+	if err != nil {
+		return nil, fmt.Errorf("write Gradle init script: %w", err)
+	}
+	defer os.Remove(script.Name())
+	if _, err := script.WriteString(gradleInitScript); err != nil {
+		script.Close()
+		return nil, fmt.Errorf("write Gradle init script: %w", err)
+	}
+	if err := script.Close(); err != nil {
+		return nil, err
+	}
+	return runner.Run(ctx, env, buildTool(projectDir, "gradlew", "gradle"),
+		"-q", "--init-script", script.Name(), "agentspanCli", "-PagentspanMain="+mainClass, "--args="+joinToolArgs(args))
+}
+
+// buildTool returns the project's wrapper script when it has one, else the tool
+// on PATH.
+func buildTool(projectDir, wrapper, tool string) string {
+	if p := filepath.Join(projectDir, wrapper); fileExists(p) {
+		return p
+	}
+	return tool
+}
+
+// joinToolArgs joins arguments into the single string Maven's exec.args and
+// Gradle's --args take, shell-quoting any that need it.
+func joinToolArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = shellQuote(a)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote returns a unchanged when it is made only of characters no shell
+// treats specially, and otherwise wraps it in single quotes. A single quote inside
+// closes the quoting, is written escaped, and reopens it.
+func shellQuote(a string) string {
+	if a != "" && strings.Trim(a, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,+@%") == "" {
+		return a
+	}
+	return "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+}
+
 func appendAgents(args, agentNames []string) []string {
 	if len(agentNames) > 0 {
 		args = append(args, "--agents", strings.Join(agentNames, ","))
@@ -435,46 +825,98 @@ func formatDiscoveryTable(agents []discoveredAgent, pkg string) string {
 	return buf.String()
 }
 
+// deployPlanMarks prefix each agent in a plan, as in a diff.
+var deployPlanMarks = map[agent.DeployAction]string{
+	agent.DeployCreate:    "+",
+	agent.DeployUpdate:    "~",
+	agent.DeployUnchanged: "=",
+}
+
+func formatDeployPlan(plans []agent.DeployPlan) string {
+	var buf bytes.Buffer
+	counts := map[agent.DeployAction]int{}
+	for _, p := range plans {
+		counts[p.Action]++
+	}
+	fmt.Fprintf(&buf, "\nPlan: %d to create, %d to update, %d unchanged.\n\n",
+		counts[agent.DeployCreate], counts[agent.DeployUpdate], counts[agent.DeployUnchanged])
+	for _, p := range plans {
+		fmt.Fprintf(&buf, "  %s %s (%s", deployPlanMarks[p.Action], p.AgentName, p.Action)
+		if p.Version > 0 && p.Action == agent.DeployUpdate {
+			fmt.Fprintf(&buf, " from v%d", p.Version)
+		}
+		fmt.Fprintln(&buf, ")")
+		if len(p.Changes) == 0 {
+			continue
+		}
+		var diff bytes.Buffer
+		renderAgentDiff(&diff, p.Changes)
+		for _, line := range strings.Split(strings.TrimSuffix(diff.String(), "\n"), "\n") {
+			fmt.Fprintf(&buf, "      %s\n", line)
+		}
+	}
+	return buf.String()
+}
+
 func formatDeployOutput(results []deployResult) string {
 	var buf bytes.Buffer
-	succeeded, failed := 0, 0
 	fmt.Fprintln(&buf)
+	succeeded, failed := writeDeployResults(&buf, results)
+	fmt.Fprintln(&buf)
+	writeDeploySummary(&buf, succeeded, failed)
+	return buf.String()
+}
+
+// writeDeployResults writes one line per result and counts the outcomes.
+func writeDeployResults(buf *bytes.Buffer, results []deployResult) (succeeded, failed int) {
 	for _, r := range results {
 		if r.Success {
 			succeeded++
-			fmt.Fprintf(&buf, "  ok  %s", r.AgentName)
+			fmt.Fprintf(buf, "  ok  %s", r.AgentName)
 			if r.RegisteredName != nil && *r.RegisteredName != "" && *r.RegisteredName != r.AgentName {
-				fmt.Fprintf(&buf, " (registered as %s)", *r.RegisteredName)
+				fmt.Fprintf(buf, " (registered as %s)", *r.RegisteredName)
 			}
-			fmt.Fprintln(&buf)
+			fmt.Fprintln(buf)
 		} else {
 			failed++
 			msg := "unknown error"
 			if r.Error != nil {
 				msg = *r.Error
 			}
-			fmt.Fprintf(&buf, "  fail %s: %s\n", r.AgentName, msg)
+			fmt.Fprintf(buf, "  fail %s: %s\n", r.AgentName, msg)
 		}
 	}
-	fmt.Fprintln(&buf)
+	return succeeded, failed
+}
+
+func writeDeploySummary(buf *bytes.Buffer, succeeded, failed int) {
 	switch {
 	case failed == 0:
-		fmt.Fprintf(&buf, "All %d agent(s) deployed successfully.\n", succeeded)
+		fmt.Fprintf(buf, "All %d agent(s) deployed successfully.\n", succeeded)
 	case succeeded == 0:
-		fmt.Fprintf(&buf, "All %d agent(s) failed to deploy. Check 'conductor doctor'.\n", failed)
+		fmt.Fprintf(buf, "All %d agent(s) failed to deploy. Check 'conductor doctor'.\n", failed)
 	default:
-		fmt.Fprintf(&buf, "%d deployed, %d failed.\n", succeeded, failed)
+		fmt.Fprintf(buf, "%d deployed, %d failed.\n", succeeded, failed)
 	}
 	if succeeded > 0 {
-		fmt.Fprintln(&buf, "\nRun with: conductor agent run --name <agent> \"your prompt\"")
+		fmt.Fprintln(buf, "\nRun with: conductor agent run --name <agent> \"your prompt\"")
 	}
-	return buf.String()
 }
 
 func init() {
 	deployCmd.Flags().StringSliceVarP(&deployAgents, "agents", "a", nil, "Comma-separated agent names to deploy (default: all)")
-	deployCmd.Flags().StringVarP(&deployLanguage, "language", "l", "", "Project language: python or typescript")
+	deployCmd.Flags().StringVarP(&deployLanguage, "language", "l", "", "Project language: python, typescript, java or go")
 	deployCmd.Flags().StringVarP(&deployPackage, "package", "p", "", "Package or path to scan for agents")
 	deployCmd.Flags().BoolVar(&deployJSON, "json", false, "Output results as JSON")
+	deployCmd.Flags().BoolVar(&deployPlan, "plan", false, "Show what would change without deploying; exits non-zero if anything would")
+	deployCmd.Flags().StringVar(&deployServe, "serve-tools", "", serveToolsHelp+" after deploying, until Ctrl-C")
+	deployCmd.Flags().BoolVarP(&deployWatch, "watch", "w", false, "Redeploy changed agents whenever their source files change, until Ctrl-C")
+	deployCmd.Flags().BoolVar(&deployPrune, "prune", false, "After deploying, delete agents tagged with this project that it no longer defines")
+	deployCmd.Flags().StringVar(&deployProject, "project", "", "Project name deploy tags agents with (default: conductor.yaml's project, else the directory name)")
+	deployCmd.Flags().DurationVar(&deployTimeout, "timeout", deploy.DefaultTimeout, "Time limit for each discover or deploy subprocess; 0 for none")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "serve-tools", "watch")
+	deployCmd.MarkFlagsMutuallyExclusive("prune", "plan")
+	deployCmd.MarkFlagsMutuallyExclusive("prune", "watch")
+	deployCmd.MarkFlagsMutuallyExclusive("json", "watch")
 	rootCmd.AddCommand(deployCmd)
 }
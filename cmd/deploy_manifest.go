@@ -0,0 +1,377 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/deploy"
+)
+
+// manifestPackage is a manifest entry resolved for deployment: how to run its SDK,
+// the environment to run it in, the server it deploys to and the agents selected.
+type manifestPackage struct {
+	spec      deploy.Package
+	dir       string
+	language  string
+	pythonBin string
+	pkg       packageInfo
+	env       []string
+	runner    deploy.Runner
+	server    string
+	svc       agent.Service
+	agents    []discoveredAgent
+	all       []string // every discovered agent, selected or not
+
+	// deployed holds the definition last deployed successfully for each agent,
+	// which --watch compares against to find the agents a change affected.
+	deployed map[string]json.RawMessage
+}
+
+// packageReport is one package's entry in the combined --json report.
+type packageReport struct {
+	Name       string            `json:"name"`
+	Path       string            `json:"path"`
+	Language   string            `json:"language"`
+	Profile    string            `json:"profile,omitempty"`
+	Discovered []discoveredAgent `json:"discovered"`
+	Deployed   []deployResult    `json:"deployed"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// loadDeployManifest reads conductor.yaml from dir. ok is false when there is none.
+func loadDeployManifest(dir string) (m deploy.Manifest, ok bool, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, deploy.ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return deploy.Manifest{}, false, nil
+	}
+	if err != nil {
+		return deploy.Manifest{}, false, err
+	}
+	m, err = deploy.ParseManifest(data)
+	return m, err == nil, err
+}
+
+// runManifestDeploy discovers every package in the manifest, then deploys them in
+// order with one confirmation and one combined report. Discovery fails fast, since
+// nothing has been deployed yet; a package that fails to deploy does not stop the
+// ones after it.
+func runManifestDeploy(ctx context.Context, root string, m deploy.Manifest, agentNames []string) error {
+	if err := checkServeProfiles(m); err != nil {
+		return err
+	}
+	tag, err := projectTag(root, m.Project)
+	if err != nil {
+		return err
+	}
+	progress := &deployProgress{w: deployStatusWriter()}
+	opts := deploy.RunnerOptions{Timeout: deployTimeout, Progress: progress.handle}
+	packages := make([]*manifestPackage, 0, len(m.Packages))
+	for _, spec := range m.Packages {
+		p, err := resolveManifestPackage(ctx, root, spec, tag, opts)
+		if err != nil {
+			return fmt.Errorf("package %q: %w", spec.Name, err)
+		}
+		packages = append(packages, p)
+	}
+	if err := selectManifestAgents(packages, agentNames); err != nil {
+		return err
+	}
+
+	if deployWatch {
+		return runDeployWatch(ctx, packages, agentNames)
+	}
+	if deployPlan {
+		var plans []agent.DeployPlan
+		for _, p := range packages {
+			if len(p.agents) == 0 {
+				continue
+			}
+			pp, err := planAgents(ctx, p.svc, p.agents)
+			if err != nil {
+				return fmt.Errorf("package %q: %w", p.spec.Name, err)
+			}
+			plans = append(plans, pp...)
+		}
+		return reportDeployPlan(plans)
+	}
+
+	if !deployJSON {
+		for _, p := range packages {
+			if len(p.agents) > 0 {
+				fmt.Print(formatDiscoveryTable(p.agents, packageLabel(p.spec)))
+			}
+		}
+		fmt.Println()
+		if !yes && !confirm("Deploy these agents?") {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	// An interrupt stops the package in progress and skips the rest.
+	deployCtx, cancel := context.WithCancel(ctx)
+	stopSignals := interruptWithEscalation(cancel)
+	reports := make([]packageReport, 0, len(packages))
+	var all []deployResult
+	for _, p := range packages {
+		if deployCtx.Err() != nil {
+			break
+		}
+		report := packageReport{Name: p.spec.Name, Path: p.spec.Path, Language: p.language, Profile: p.spec.Profile, Discovered: p.agents}
+		if len(p.agents) > 0 {
+			results, err := deployManifestPackage(deployCtx, p, p.agents)
+			if err != nil {
+				report.Error = err.Error()
+			}
+			report.Deployed = results
+			all = append(all, results...)
+		}
+		reports = append(reports, report)
+	}
+	stopSignals()
+	interrupted := deployCtx.Err() != nil
+	cancel()
+	if interrupted {
+		return progress.interrupted(all)
+	}
+
+	failed := 0
+	for _, r := range all {
+		if !r.Success {
+			failed++
+		}
+	}
+
+	// Under --json the prune runs first, so the report can include it.
+	prune := deployPrune && failed == 0
+	var pruned []prunedAgent
+	var pruneErr error
+	if prune && deployJSON {
+		pruned, pruneErr = pruneProject(ctx, deployStatusWriter(), tag, manifestPruneTargets(packages, reports))
+	}
+	if deployJSON {
+		out := map[string]any{
+			"packages": reports,
+			"summary":  map[string]int{"packages": len(reports), "total": len(all), "succeeded": len(all) - failed, "failed": failed},
+		}
+		if deployPrune {
+			out["pruned"] = pruned
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(formatManifestDeployOutput(reports))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d agent(s) failed to deploy", failed)
+	}
+	if prune && !deployJSON {
+		_, pruneErr = pruneProject(ctx, deployStatusWriter(), tag, manifestPruneTargets(packages, reports))
+	}
+	if pruneErr != nil {
+		return pruneErr
+	}
+	if deployServe != "" {
+		return serveDeployedTools(ctx, all)
+	}
+	return nil
+}
+
+// checkServeProfiles refuses --serve-tools for a manifest with a package on another
+// profile: the tool workers poll only the active profile's server, so that
+// package's agents would wait on tasks nobody picks up.
+func checkServeProfiles(m deploy.Manifest) error {
+	if deployServe == "" {
+		return nil
+	}
+	for _, spec := range m.Packages {
+		if !isActiveProfile(spec.Profile) {
+			return fmt.Errorf("--serve-tools polls only the active profile's server, but package %q deploys to profile %q", spec.Name, spec.Profile)
+		}
+	}
+	return nil
+}
+
+// resolveManifestPackage prepares one package and discovers its agents, keeping
+// those its include/exclude lists select.
+func resolveManifestPackage(ctx context.Context, root string, spec deploy.Package, tag string, opts deploy.RunnerOptions) (*manifestPackage, error) {
+	dir := filepath.Join(root, spec.Path)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", spec.Path)
+	}
+	language, err := detectLanguage(dir, spec.Language)
+	if err != nil {
+		return nil, err
+	}
+	pythonBin := ""
+	if language == langPython {
+		if pythonBin = findPythonBinary(dir); pythonBin == "" {
+			return nil, fmt.Errorf("no Python interpreter found; install Python or set the PYTHON environment variable")
+		}
+	}
+	pkg, err := inferPackage(dir, language, spec.Package)
+	if err != nil {
+		return nil, err
+	}
+	opts.Dir = dir
+
+	t, err := profileTransport(spec.Profile)
+	if err != nil {
+		return nil, err
+	}
+	env, err := deploy.EnvBuilder{BaseURL: t.BaseURL, Tokens: t.Tokens, BaseEnv: os.Environ(), Tags: []string{tag}}.Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p := &manifestPackage{
+		spec:      spec,
+		dir:       dir,
+		language:  language,
+		pythonBin: pythonBin,
+		pkg:       pkg,
+		env:       spec.WithEnv(env),
+		runner:    deploy.NewRunner(opts),
+		server:    t.BaseURL,
+		svc:       agent.NewService(agent.NewClient(t)),
+	}
+
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// discover runs the package's discovery and keeps the agents its include/exclude
+// lists select.
+func (p *manifestPackage) discover(ctx context.Context) error {
+	discovered, err := execDiscover(ctx, p.runner, p.env, p.language, p.pythonBin, p.dir, p.pkg)
+	if err != nil {
+		return err
+	}
+	if len(discovered) == 0 {
+		return fmt.Errorf("no agents found in %q", p.pkg.Value)
+	}
+	if len(p.spec.Include) > 0 {
+		// Reports include entries that name no discovered agent.
+		if _, err := filterDiscoveredAgents(discovered, p.spec.Include); err != nil {
+			return err
+		}
+	}
+	p.agents, p.all = p.agents[:0], p.all[:0]
+	for _, a := range discovered {
+		p.all = append(p.all, a.Name)
+		if p.spec.Selects(a.Name) {
+			p.agents = append(p.agents, a)
+		}
+	}
+	return nil
+}
+
+// selectManifestAgents narrows the packages' agents to --agents, which may name
+// agents from any package.
+func selectManifestAgents(packages []*manifestPackage, names []string) error {
+	total := 0
+	if len(names) == 0 {
+		for _, p := range packages {
+			total += len(p.agents)
+		}
+	} else {
+		found := map[string]bool{}
+		var available []string
+		for _, p := range packages {
+			kept := p.agents[:0]
+			for _, a := range p.agents {
+				available = append(available, a.Name)
+				if slices.Contains(names, a.Name) {
+					found[a.Name] = true
+					kept = append(kept, a)
+				}
+			}
+			p.agents = kept
+			total += len(kept)
+		}
+		var notFound []string
+		for _, n := range names {
+			if !found[n] && !slices.Contains(notFound, n) {
+				notFound = append(notFound, n)
+			}
+		}
+		if len(notFound) > 0 {
+			return fmt.Errorf("agent(s) not found: %s (available: %s)", strings.Join(notFound, ", "), strings.Join(available, ", "))
+		}
+	}
+	if total == 0 {
+		return fmt.Errorf("%s selects no agents", deploy.ManifestFile)
+	}
+	return nil
+}
+
+// deployManifestPackage deploys agents from one package to its server. When the
+// package fails before reporting per-agent results, each agent is reported failed
+// with the package's error, so the summary still counts them.
+func deployManifestPackage(ctx context.Context, p *manifestPackage, agents []discoveredAgent) ([]deployResult, error) {
+	names := namesOf(agents)
+	err := p.svc.CheckSupported(ctx)
+	var results []deployResult
+	if err == nil {
+		results, err = execDeploy(ctx, p.runner, p.env, p.language, p.pythonBin, p.dir, p.pkg, names)
+	}
+	if err != nil && len(results) == 0 {
+		msg := err.Error()
+		for _, n := range names {
+			results = append(results, deployResult{AgentName: n, Error: &msg})
+		}
+	}
+	return results, err
+}
+
+// packageLabel names a package and, when it has one, its target profile.
+func packageLabel(spec deploy.Package) string {
+	if spec.Profile == "" {
+		return spec.Name
+	}
+	return fmt.Sprintf("%s (profile %s)", spec.Name, spec.Profile)
+}
+
+func formatManifestDeployOutput(reports []packageReport) string {
+	var buf bytes.Buffer
+	succeeded, failed := 0, 0
+	for _, r := range reports {
+		if len(r.Deployed) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\n%s:\n", r.Name)
+		s, f := writeDeployResults(&buf, r.Deployed)
+		succeeded += s
+		failed += f
+	}
+	fmt.Fprintln(&buf)
+	writeDeploySummary(&buf, succeeded, failed)
+	return buf.String()
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/deploy"
+	"github.com/conductor-oss/conductor-cli/internal/transport"
+)
+
+func TestLoadDeployManifest(t *testing.T) {
+	dir := t.TempDir()
+	if _, ok, err := loadDeployManifest(dir); ok || err != nil {
+		t.Fatalf("no manifest: ok=%v err=%v", ok, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, deploy.ManifestFile), []byte("packages:\n  - path: svc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, ok, err := loadDeployManifest(dir)
+	if !ok || err != nil || m.Packages[0].Name != "svc" {
+		t.Fatalf("manifest = %+v, ok=%v err=%v", m, ok, err)
+	}
+}
+
+func TestSelectManifestAgents(t *testing.T) {
+	newPackages := func() []*manifestPackage {
+		return []*manifestPackage{
+			{agents: []discoveredAgent{{Name: "support"}, {Name: "triage"}}},
+			{agents: []discoveredAgent{{Name: "invoicer"}}},
+		}
+	}
+
+	packages := newPackages()
+	if err := selectManifestAgents(packages, []string{"triage", "invoicer"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(packages[0].agents) != 1 || packages[0].agents[0].Name != "triage" || len(packages[1].agents) != 1 {
+		t.Errorf("selection = %+v, %+v", packages[0].agents, packages[1].agents)
+	}
+
+	err := selectManifestAgents(newPackages(), []string{"missing"})
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("unknown name: err = %v", err)
+	}
+	if err := selectManifestAgents([]*manifestPackage{{}}, nil); err == nil {
+		t.Error("a manifest selecting no agents should fail")
+	}
+}
+
+func TestFormatManifestDeployOutput(t *testing.T) {
+	msg := "boom"
+	out := formatManifestDeployOutput([]packageReport{
+		{Name: "support", Deployed: []deployResult{{AgentName: "support", Success: true}}},
+		{Name: "empty"},
+		{Name: "billing", Deployed: []deployResult{{AgentName: "invoicer", Error: &msg}}},
+	})
+	for _, want := range []string{"\nsupport:\n  ok  support\n", "\nbilling:\n  fail invoicer: boom\n", "1 deployed, 1 failed."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "empty") {
+		t.Errorf("package with nothing deployed should be omitted:\n%s", out)
+	}
+}
+
+// A package without a profile deploys to the active profile's server, even when
+// that is not the default profile.
+func TestProfileTransportDefaultsToActiveProfile(t *testing.T) {
+	prevProfile, prevTransport := activeProfile, internal.Transport()
+	defer func() {
+		activeProfile = prevProfile
+		internal.SetTransport(prevTransport)
+	}()
+	activeProfile = "dev"
+	internal.SetTransport(transport.Config{BaseURL: "https://dev.example.com/api"})
+
+	got, err := profileTransport("")
+	if err != nil || got.BaseURL != "https://dev.example.com/api" {
+		t.Errorf("profileTransport(\"\") = %q, %v; want the active dev transport", got.BaseURL, err)
+	}
+}
+
+// Tool workers poll the active profile's server, so --serve-tools refuses a
+// manifest with a package deployed elsewhere before anything is deployed.
+func TestCheckServeProfilesRejectsOtherProfiles(t *testing.T) {
+	prevProfile, prevServe := activeProfile, deployServe
+	defer func() { activeProfile, deployServe = prevProfile, prevServe }()
+	activeProfile, deployServe = "dev", "tools"
+
+	same := deploy.Manifest{Packages: []deploy.Package{{Name: "a"}, {Name: "b", Profile: "dev"}}}
+	if err := checkServeProfiles(same); err != nil {
+		t.Errorf("checkServeProfiles(active profile only) = %v, want nil", err)
+	}
+	other := deploy.Manifest{Packages: []deploy.Package{{Name: "a"}, {Name: "b", Profile: "prod"}}}
+	if err := checkServeProfiles(other); err == nil || !strings.Contains(err.Error(), `"prod"`) {
+		t.Errorf("checkServeProfiles(prod package) = %v, want an error naming prod", err)
+	}
+	deployServe = ""
+	if err := checkServeProfiles(other); err != nil {
+		t.Errorf("checkServeProfiles without --serve-tools = %v, want nil", err)
+	}
+}
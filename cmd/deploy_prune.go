@@ -0,0 +1,184 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/deploy"
+)
+
+// pruneTarget is one server --prune cleans up.
+type pruneTarget struct {
+	server   string
+	profile  string
+	svc      agent.Service
+	keep     []string // every agent the project defines for this server
+	deployed []string // the names this run registered
+}
+
+// prunedAgent is one agent --prune deleted, or failed to, for the --json report.
+type prunedAgent struct {
+	Name    string `json:"name"`
+	Profile string `json:"profile,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// projectTag resolves the project's tag: --project, else the manifest's project,
+// else the project directory's name. --prune refuses the directory's name, which
+// unrelated projects in directories such as app or src share, and would then
+// delete each other's agents.
+func projectTag(root, manifestProject string) (string, error) {
+	project := deployProject
+	if project == "" {
+		project = manifestProject
+	}
+	if project == "" {
+		if deployPrune {
+			return "", fmt.Errorf("--prune needs the project named: pass --project or set project in %s, "+
+				"so agents of another project in a directory also called %q are not pruned", deploy.ManifestFile, filepath.Base(root))
+		}
+		project = filepath.Base(root)
+	}
+	return deploy.ProjectTag(project)
+}
+
+// checkPruneScope refuses --prune when --package or --language deploys a single
+// package from a directory with a manifest: the other packages' agents carry the
+// same project tag but are not being deployed, so they would look like orphans.
+func checkPruneScope(dir string) error {
+	if !deployPrune || (deployLanguage == "" && deployPackage == "") {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, deploy.ManifestFile)); err == nil {
+		return fmt.Errorf("--prune needs the whole project deployed, but --package and --language bypass %s; deploy without them to prune", deploy.ManifestFile)
+	}
+	return nil
+}
+
+// registeredNames returns the names the server stored the successful results under.
+func registeredNames(results []deployResult) []string {
+	var names []string
+	for _, r := range results {
+		if !r.Success {
+			continue
+		}
+		if r.RegisteredName != nil && *r.RegisteredName != "" {
+			names = append(names, *r.RegisteredName)
+		} else {
+			names = append(names, r.AgentName)
+		}
+	}
+	return names
+}
+
+// pruneProject deletes, after confirmation, the agents tagged as the project's
+// that it no longer defines. It first checks that the agents this run deployed
+// were stored with the tag: an SDK that ignores deploy's tags leaves --prune
+// nothing to find, which is refused rather than reported as nothing to prune.
+func pruneProject(ctx context.Context, w io.Writer, tag string, targets []pruneTarget) ([]prunedAgent, error) {
+	var untagged []string
+	for _, t := range targets {
+		for _, name := range t.deployed {
+			def, err := t.svc.Get(ctx, name, nil)
+			if err != nil {
+				return nil, fmt.Errorf("check the %s tag on %q: %w", tag, name, err)
+			}
+			if !deploy.Tagged(def, tag) {
+				untagged = append(untagged, name)
+			}
+		}
+	}
+	if len(untagged) > 0 {
+		return nil, fmt.Errorf("--prune cannot track %s: the SDK stored them without the %s tag; upgrade it and deploy again",
+			strings.Join(untagged, ", "), tag)
+	}
+
+	type orphan struct {
+		target *pruneTarget
+		agent  agent.AgentSummary
+	}
+	var orphans []orphan
+	for i := range targets {
+		t := &targets[i]
+		listed, err := t.svc.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list agents for --prune: %w", err)
+		}
+		for _, a := range deploy.ProjectOrphans(listed, tag, t.keep) {
+			orphans = append(orphans, orphan{target: t, agent: a})
+		}
+	}
+
+	if len(orphans) == 0 {
+		fmt.Fprintf(w, "\nNothing to prune: every agent tagged %s is still defined.\n", tag)
+		return nil, nil
+	}
+	fmt.Fprintf(w, "\nAgents tagged %s that the project no longer defines:\n", tag)
+	for _, o := range orphans {
+		fmt.Fprintf(w, "  - %s (v%d)", o.agent.Name, o.agent.Version)
+		if o.target.profile != "" {
+			fmt.Fprintf(w, " on profile %s", o.target.profile)
+		}
+		fmt.Fprintln(w)
+	}
+	if !yes && !confirm(fmt.Sprintf("Delete these %d agent(s) tagged %s?", len(orphans), tag)) {
+		fmt.Fprintln(w, "Prune skipped.")
+		return nil, nil
+	}
+
+	pruned := make([]prunedAgent, 0, len(orphans))
+	failed := 0
+	for _, o := range orphans {
+		p := prunedAgent{Name: o.agent.Name, Profile: o.target.profile}
+		if err := o.target.svc.Delete(ctx, o.agent.Name, nil); err != nil {
+			p.Error = err.Error()
+			failed++
+			fmt.Fprintf(w, "  fail %s: %v\n", p.Name, err)
+		} else {
+			fmt.Fprintf(w, "  deleted %s\n", p.Name)
+		}
+		pruned = append(pruned, p)
+	}
+	if failed > 0 {
+		return pruned, fmt.Errorf("%d agent(s) could not be pruned", failed)
+	}
+	return pruned, nil
+}
+
+// manifestPruneTargets groups the manifest's packages by server, since an agent is
+// an orphan only if no package deploying to that server defines it, whichever
+// profile the package names it by.
+func manifestPruneTargets(packages []*manifestPackage, reports []packageReport) []pruneTarget {
+	var targets []pruneTarget
+	for i, p := range packages {
+		j := slices.IndexFunc(targets, func(t pruneTarget) bool { return t.server == p.server })
+		if j < 0 {
+			targets = append(targets, pruneTarget{server: p.server, profile: p.spec.Profile, svc: p.svc})
+			j = len(targets) - 1
+		}
+		deployed := registeredNames(reports[i].Deployed)
+		targets[j].keep = append(targets[j].keep, p.all...)
+		targets[j].keep = append(targets[j].keep, deployed...)
+		targets[j].deployed = append(targets[j].deployed, deployed...)
+	}
+	return targets
+}
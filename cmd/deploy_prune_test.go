@@ -0,0 +1,175 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/deploy"
+)
+
+// fakePruneService implements the calls --prune makes; any other call panics.
+type fakePruneService struct {
+	agent.Service
+	listed  []agent.AgentSummary
+	deleted []string
+}
+
+// Get returns a stored definition carrying the tags the listing shows.
+func (f *fakePruneService) Get(_ context.Context, name string, _ *int) (json.RawMessage, error) {
+	for _, a := range f.listed {
+		if a.Name == name {
+			return json.Marshal(map[string]any{"name": a.Name, "tags": a.Tags})
+		}
+	}
+	return nil, fmt.Errorf("agent %q not found", name)
+}
+
+func (f *fakePruneService) List(context.Context) ([]agent.AgentSummary, error) {
+	return f.listed, nil
+}
+
+func (f *fakePruneService) Delete(_ context.Context, name string, _ *int) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func TestPruneProjectDeletesOnlyTaggedOrphans(t *testing.T) {
+	const tag = "project:shop"
+	svc := &fakePruneService{listed: []agent.AgentSummary{
+		{Name: "support", Version: 3, Tags: []string{tag}},
+		{Name: "support_v1", Version: 2, Tags: []string{tag}},
+		{Name: "billing", Version: 1, Tags: []string{"team:x", tag}},
+		{Name: "someone_elses", Version: 1},
+	}}
+	oldYes := yes
+	yes = true
+	defer func() { yes = oldYes }()
+
+	var out bytes.Buffer
+	pruned, err := pruneProject(context.Background(), &out, tag, []pruneTarget{{
+		svc:      svc,
+		keep:     []string{"support", "billing"},
+		deployed: []string{"support", "billing"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(svc.deleted, []string{"support_v1"}) || len(pruned) != 1 {
+		t.Errorf("deleted = %q, pruned = %+v", svc.deleted, pruned)
+	}
+	for _, want := range []string{"  - support_v1 (v2)", "  deleted support_v1"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+// An SDK that ignores deploy's tags would leave --prune nothing to find; that is
+// an error, not an empty prune.
+func TestPruneProjectRefusesUntaggedDeploys(t *testing.T) {
+	const tag = "project:shop"
+	svc := &fakePruneService{listed: []agent.AgentSummary{
+		{Name: "support", Tags: []string{tag}},
+		{Name: "old", Tags: []string{tag}},
+		{Name: "billing"},
+	}}
+	_, err := pruneProject(context.Background(), io.Discard, tag, []pruneTarget{{
+		svc:      svc,
+		keep:     []string{"support", "billing"},
+		deployed: []string{"support", "billing"},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "billing") {
+		t.Errorf("err = %v, want one naming the untagged billing", err)
+	}
+	if len(svc.deleted) > 0 {
+		t.Errorf("deleted %q, want nothing", svc.deleted)
+	}
+}
+
+// Deploying one package of a manifest cannot tell its siblings' agents from
+// orphans, so --prune is refused there.
+func TestCheckPruneScope(t *testing.T) {
+	defer func(prune bool, pkg string) { deployPrune, deployPackage = prune, pkg }(deployPrune, deployPackage)
+	withManifest := t.TempDir()
+	if err := os.WriteFile(filepath.Join(withManifest, deploy.ManifestFile), []byte("project: shop\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deployPrune, deployPackage = true, "agents"
+	if err := checkPruneScope(withManifest); err == nil {
+		t.Error("--prune --package beside a manifest succeeded, want an error")
+	}
+	if err := checkPruneScope(t.TempDir()); err != nil {
+		t.Errorf("--prune --package without a manifest = %v, want nil", err)
+	}
+	deployPackage = ""
+	if err := checkPruneScope(withManifest); err != nil {
+		t.Errorf("--prune through the manifest = %v, want nil", err)
+	}
+}
+
+func TestManifestPruneTargetsGroupsByServer(t *testing.T) {
+	registered := "billing_agent"
+	packages := []*manifestPackage{
+		{server: "http://a/api", all: []string{"support", "draft"}},
+		{server: "http://b/api", spec: deploy.Package{Profile: "prod"}, all: []string{"billing"}},
+		{server: "http://a/api", all: []string{"triage"}},
+	}
+	reports := []packageReport{
+		{Deployed: []deployResult{{AgentName: "support", Success: true}}},
+		{Deployed: []deployResult{{AgentName: "billing", RegisteredName: &registered, Success: true}}},
+		{Deployed: []deployResult{{AgentName: "triage"}}},
+	}
+	targets := manifestPruneTargets(packages, reports)
+	if len(targets) != 2 {
+		t.Fatalf("targets = %+v, want one per server", targets)
+	}
+	if want := []string{"support", "draft", "support", "triage"}; !reflect.DeepEqual(targets[0].keep, want) {
+		t.Errorf("server a keep = %q, want %q", targets[0].keep, want)
+	}
+	if want := []string{"billing", "billing_agent"}; !reflect.DeepEqual(targets[1].keep, want) || targets[1].profile != "prod" {
+		t.Errorf("server b = %+v", targets[1])
+	}
+}
+
+func TestProjectTagNeedsExplicitProjectToPrune(t *testing.T) {
+	defer func(prune bool, project string) { deployPrune, deployProject = prune, project }(deployPrune, deployProject)
+
+	deployPrune, deployProject = false, ""
+	if tag, err := projectTag("/work/app", ""); tag != "project:app" || err != nil {
+		t.Errorf("without --prune: projectTag = %q, %v; want the directory's name", tag, err)
+	}
+	deployPrune = true
+	if _, err := projectTag("/work/app", ""); err == nil || !strings.Contains(err.Error(), "--project") {
+		t.Errorf("--prune without a project: err = %v, want one asking for --project", err)
+	}
+	if tag, err := projectTag("/work/app", "shop"); tag != "project:shop" || err != nil {
+		t.Errorf("--prune with the manifest's project: projectTag = %q, %v", tag, err)
+	}
+	deployProject = "store"
+	if tag, err := projectTag("/work/app", "shop"); tag != "project:store" || err != nil {
+		t.Errorf("--prune with --project: projectTag = %q, %v", tag, err)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/deploy"
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+func TestFormatDeployPlan(t *testing.T) {
+	plans := []agent.DeployPlan{
+		{AgentName: "support", Action: agent.DeployCreate},
+		{AgentName: "triage", Action: agent.DeployUpdate, Version: 3, Changes: []jsondiff.Change{
+			{Path: "model", Kind: jsondiff.Changed, Old: "openai/gpt-4o", New: "openai/gpt-4o-mini"},
+			{Path: "tools[lookup]", Kind: jsondiff.Added, New: `"lookup"`},
+		}},
+		{AgentName: "billing", Action: agent.DeployUnchanged, Version: 1},
+	}
+	want := "\nPlan: 1 to create, 1 to update, 1 unchanged.\n\n" +
+		"  + support (create)\n" +
+		"  ~ triage (update from v3)\n" +
+		"      ~ model: openai/gpt-4o -> openai/gpt-4o-mini\n" +
+		"      + tools[lookup]: \"lookup\"\n" +
+		"  = billing (unchanged)\n"
+	if got := formatDeployPlan(plans); got != want {
+		t.Errorf("plan =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		want    string
+		wantErr string
+	}{
+		{name: "python", files: []string{"pyproject.toml"}, want: langPython},
+		{name: "typescript", files: []string{"tsconfig.json"}, want: langTypeScript},
+		{name: "maven", files: []string{"pom.xml"}, want: langJava},
+		{name: "gradle kotlin", files: []string{"build.gradle.kts"}, want: langJava},
+		{name: "go", files: []string{"go.mod"}, want: langGo},
+		{name: "none", wantErr: "no Python"},
+		{name: "several", files: []string{"go.mod", "requirements.txt"}, wantErr: "python, go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, f), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := detectLanguage(dir, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("detectLanguage = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+type recordingRunner struct {
+	name string
+	args []string
+}
+
+func (r *recordingRunner) Run(_ context.Context, _ []string, name string, args ...string) ([]byte, error) {
+	r.name, r.args = name, args
+	return nil, nil
+}
+
+func TestRunLanguageToolJavaAndGo(t *testing.T) {
+	maven := t.TempDir()
+	if err := os.WriteFile(filepath.Join(maven, "pom.xml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pkg := packageInfo{Value: "./src/main/java", IsPath: true}
+	agents := []string{"support bot"}
+
+	var r recordingRunner
+	if _, err := runLanguageTool(context.Background(), &r, nil, langJava, "", maven, pkg, "deploy", deployTool, agents); err != nil {
+		t.Fatal(err)
+	}
+	wantMaven := []string{"-q", "compile", "exec:java", "-Dexec.mainClass=" + deployTool.java,
+		`-Dexec.args=--path ./src/main/java --agents 'support bot'`}
+	if r.name != "mvn" || !reflect.DeepEqual(r.args, wantMaven) {
+		t.Errorf("maven = %s %q, want mvn %q", r.name, r.args, wantMaven)
+	}
+
+	gradle := t.TempDir()
+	if err := os.WriteFile(filepath.Join(gradle, "gradlew"), nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runLanguageTool(context.Background(), &r, nil, langJava, "", gradle, pkg, "discover", discoverTool, nil); err != nil {
+		t.Fatal(err)
+	}
+	if r.name != filepath.Join(gradle, "gradlew") || !slicesHasPrefix(r.args, "-q", "--init-script") ||
+		r.args[len(r.args)-1] != "--args=--path ./src/main/java" {
+		t.Errorf("gradle = %s %q", r.name, r.args)
+	}
+
+	if _, err := runLanguageTool(context.Background(), &r, nil, langGo, "", t.TempDir(), packageInfo{Value: ".", IsPath: true}, "discover", discoverTool, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"run", discoverTool.golang, "--path", "."}; r.name != "go" || !reflect.DeepEqual(r.args, want) {
+		t.Errorf("go = %s %q, want go %q", r.name, r.args, want)
+	}
+}
+
+func TestJoinToolArgsShellQuotes(t *testing.T) {
+	got := joinToolArgs([]string{"--path", "./src", "", "support bot", "it's", `a"b`, "$HOME", "x;y"})
+	want := `--path ./src '' 'support bot' 'it'\''s' 'a"b' '$HOME' 'x;y'`
+	if got != want {
+		t.Errorf("joinToolArgs = %s, want %s", got, want)
+	}
+}
+
+func slicesHasPrefix(s []string, prefix ...string) bool {
+	return len(s) >= len(prefix) && reflect.DeepEqual(s[:len(prefix)], prefix)
+}
+
+func TestDeployProgressRendersAndReportsInterrupt(t *testing.T) {
+	var out strings.Builder
+	p := &deployProgress{w: &out}
+	p.handle(deploy.ProgressEvent{Stage: deploy.StageDeploying, Agent: "support"})
+	p.handle(deploy.ProgressEvent{Stage: deploy.StageDeployed, Agent: "support"})
+	p.handle(deploy.ProgressEvent{Stage: deploy.StageFailed, Agent: "triage", Message: "bad model"})
+
+	if want := "  deploying support\n  deployed support\n  failed triage: bad model\n"; out.String() != want {
+		t.Errorf("rendered =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	err := p.interrupted([]deployResult{{AgentName: "support", Success: true}, {AgentName: "billing", Success: true}})
+	if want := "deploy interrupted; 2 agent(s) were already deployed: support, billing"; err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+	if err := (&deployProgress{}).interrupted(nil); err == nil || !strings.Contains(err.Error(), "before any agent") {
+		t.Errorf("err = %v", err)
+	}
+}
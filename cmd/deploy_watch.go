@@ -0,0 +1,146 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/deploy"
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+// watchPatterns are the files --watch treats as a package's source, by language:
+// the sources themselves plus the build files that can change what is discovered.
+var watchPatterns = map[string][]string{
+	langPython:     {"*.py", "pyproject.toml", "setup.py", "setup.cfg", "requirements.txt"},
+	langTypeScript: {"*.ts", "*.tsx", "*.js", "*.mjs", "package.json", "tsconfig.json"},
+	langJava:       {"*.java", "*.kt", "pom.xml", "build.gradle", "build.gradle.kts"},
+	langGo:         {"*.go", "go.mod", "go.sum"},
+}
+
+// runDeployWatch deploys the packages' agents, then redeploys the agents a change
+// affects until the user interrupts. Nothing after the first confirmation fails the
+// command: errors are printed and watching continues.
+func runDeployWatch(ctx context.Context, packages []*manifestPackage, agentNames []string) error {
+	for _, p := range packages {
+		if len(p.agents) > 0 {
+			fmt.Print(formatDiscoveryTable(p.agents, packageLabel(p.spec)))
+		}
+	}
+	fmt.Println()
+	if !yes && !confirm("Deploy these agents, and redeploy them as they change?") {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stopSignals := interruptWithEscalation(cancel)
+	defer stopSignals()
+
+	roots := make([]deploy.WatchRoot, 0, len(packages))
+	for _, p := range packages {
+		p.deployed = map[string]json.RawMessage{}
+		if len(p.agents) > 0 {
+			deployWatched(ctx, p, p.agents)
+		}
+		roots = append(roots, deploy.WatchRoot{Dir: p.dir, Patterns: watchPatterns[p.language]})
+	}
+
+	fmt.Println("\nWatching for changes. Press Ctrl-C to stop.")
+	return deploy.Watch(ctx, roots, deploy.WatchOptions{}, func(changed []string) {
+		for _, p := range packages {
+			if files := filesUnder(p.dir, changed); len(files) > 0 {
+				redeployChanged(ctx, p, files, agentNames)
+			}
+		}
+	})
+}
+
+// redeployChanged rediscovers a package after its files changed and redeploys
+// the agents whose definitions differ from the last successful deploy.
+func redeployChanged(ctx context.Context, p *manifestPackage, files, agentNames []string) {
+	fmt.Printf("\n[%s] %s: %s changed\n", time.Now().Format(time.TimeOnly), packageLabel(p.spec), strings.Join(files, ", "))
+	if err := p.discover(ctx); err != nil {
+		fmt.Printf("  discovery failed: %v\n", err)
+		return
+	}
+	if len(agentNames) > 0 {
+		p.agents = slices.DeleteFunc(p.agents, func(a discoveredAgent) bool {
+			return !slices.Contains(agentNames, a.Name)
+		})
+	}
+
+	affected := affectedAgents(p.deployed, p.agents)
+	if len(affected) == 0 {
+		fmt.Println("  no agent definitions changed")
+		return
+	}
+	deployWatched(ctx, p, affected)
+}
+
+// deployWatched deploys agents and prints the results inline, remembering each
+// success so the next change is compared against what the server now has.
+func deployWatched(ctx context.Context, p *manifestPackage, agents []discoveredAgent) {
+	results, _ := deployManifestPackage(ctx, p, agents)
+	for _, r := range results {
+		if !r.Success {
+			// Retried on the next change even if its definition is the same.
+			// maps.DeleteFunc because webhook_metadata.go's delete shadows the builtin.
+			maps.DeleteFunc(p.deployed, func(name string, _ json.RawMessage) bool { return name == r.AgentName })
+			continue
+		}
+		for _, a := range agents {
+			if a.Name == r.AgentName {
+				p.deployed[a.Name] = a.Definition
+			}
+		}
+	}
+	var buf bytes.Buffer
+	writeDeployResults(&buf, results)
+	fmt.Print(buf.String())
+}
+
+// affectedAgents returns the agents that are new since the last deploy or whose
+// definitions changed. An SDK that reports no definitions gives nothing to
+// compare, so all of its agents are affected.
+func affectedAgents(deployed map[string]json.RawMessage, agents []discoveredAgent) []discoveredAgent {
+	var affected []discoveredAgent
+	for _, a := range agents {
+		prev, ok := deployed[a.Name]
+		if !ok || len(a.Definition) == 0 || !jsondiff.Equal(prev, a.Definition) {
+			affected = append(affected, a)
+		}
+	}
+	return affected
+}
+
+// filesUnder returns the changed files inside dir, relative to it.
+func filesUnder(dir string, changed []string) []string {
+	var files []string
+	for _, path := range changed {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			files = append(files, rel)
+		}
+	}
+	return files
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAffectedAgents(t *testing.T) {
+	deployed := map[string]json.RawMessage{
+		"same":    json.RawMessage(`{"model": "m1"}`),
+		"changed": json.RawMessage(`{"model":"m1"}`),
+	}
+	agents := []discoveredAgent{
+		{Name: "same", Definition: json.RawMessage(`{"model":"m1"}`)},
+		{Name: "changed", Definition: json.RawMessage(`{"model":"m2"}`)},
+		{Name: "new", Definition: json.RawMessage(`{}`)},
+		{Name: "opaque"},
+	}
+	var names []string
+	for _, a := range affectedAgents(deployed, agents) {
+		names = append(names, a.Name)
+	}
+	if want := []string{"changed", "new", "opaque"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("affected = %q, want %q", names, want)
+	}
+}
+
+func TestFilesUnder(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "repo", "svc")
+	changed := []string{
+		filepath.Join(root, "agents", "a.py"),
+		filepath.Join(root+"-other", "b.py"),
+		filepath.Join(filepath.Dir(root), "c.py"),
+	}
+	want := []string{filepath.Join("agents", "a.py")}
+	if got := filesUnder(root, changed); !reflect.DeepEqual(got, want) {
+		t.Errorf("filesUnder = %q, want %q", got, want)
+	}
+}
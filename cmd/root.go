@@ -83,10 +83,19 @@ var localOnlyCommands = map[string]bool{
 	"update": true,
 }
 
-// isLocalOnlyCommand reports whether cmd belongs to a local-only command tree.
-// Matching is anchored to the top-level command so that same-named subcommands
-// elsewhere (e.g. "schedule update") still get an API client.
+// offlineAnnotation marks a subcommand that runs locally inside a command tree
+// that otherwise needs the server, such as a linter. Its value, if not empty,
+// names a boolean flag that makes the command need the server after all.
+const offlineAnnotation = "offline"
+
+// isLocalOnlyCommand reports whether cmd belongs to a local-only command tree, or
+// is marked offline. Matching is anchored to the top-level command so that
+// same-named subcommands elsewhere (e.g. "schedule update") still get an API client.
 func isLocalOnlyCommand(cmd *cobra.Command) bool {
+	if online, ok := cmd.Annotations[offlineAnnotation]; ok {
+		f := cmd.Flags().Lookup(online)
+		return f == nil || f.Value.String() != "true"
+	}
 	topLevel := cmd
 	for topLevel.Parent() != nil && topLevel.Parent().Parent() != nil {
 		topLevel = topLevel.Parent()
@@ -148,11 +157,7 @@ var rootCmd = &cobra.Command{
 			url = detectedURL
 		}
 
-		// Ensure URL has /api suffix for SDK
-		url = strings.TrimSuffix(url, "/")
-		if !strings.HasSuffix(url, "/api") {
-			url = url + "/api"
-		}
+		url = apiURL(url)
 
 		httpSettings := settings.NewHttpSettings(url)
 
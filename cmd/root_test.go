@@ -163,6 +163,10 @@ func TestIsLocalOnlyCommand(t *testing.T) {
 		{name: "workflow update", args: []string{"workflow", "update"}, want: false},
 		{name: "workflow list", args: []string{"workflow", "list"}, want: false},
 		{name: "api-gateway service list", args: []string{"api-gateway", "service", "list"}, want: false},
+		// Offline subcommands of trees that need the server.
+		{name: "agent validate", args: []string{"agent", "validate"}, want: true},
+		{name: "agent replay", args: []string{"agent", "replay"}, want: true},
+		{name: "workflow lint", args: []string{"workflow", "lint"}, want: true},
 	}
 
 	for _, tt := range tests {
@@ -181,3 +185,13 @@ func TestIsLocalOnlyCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowLintOnlineNeedsServer(t *testing.T) {
+	if err := workflowLintCmd.Flags().Set("online", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = workflowLintCmd.Flags().Set("online", "false") }()
+	if isLocalOnlyCommand(workflowLintCmd) {
+		t.Error("workflow lint --online is local-only; it needs an API client")
+	}
+}
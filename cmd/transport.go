@@ -15,10 +15,15 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/spf13/viper"
 
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/cliconfig"
 	"github.com/conductor-oss/conductor-cli/internal/transport"
 )
 
@@ -49,3 +54,55 @@ func newTokenProvider(m sdkTokenManager, hs *settings.HttpSettings) transport.To
 func (p tokenProvider) Token(context.Context) (string, error) {
 	return p.manager.RefreshToken(p.httpSettings, p.httpClient)
 }
+
+// apiURL ensures a server URL ends in the /api suffix the SDK expects.
+func apiURL(url string) string {
+	url = strings.TrimSuffix(url, "/")
+	if !strings.HasSuffix(url, "/api") {
+		url += "/api"
+	}
+	return url
+}
+
+// isActiveProfile reports whether a package's profile name, "" included, means the
+// profile this invocation runs as.
+func isActiveProfile(name string) bool {
+	return name == "" || name == activeProfile || (cliconfig.IsDefault(name) && cliconfig.IsDefault(activeProfile))
+}
+
+// profileTransport returns the agent transport for a profile other than the active
+// one, read from its config file with the same precedence startup applies:
+// auth-token, then auth-key/secret, else anonymous. "" means the active profile,
+// whose transport is returned unchanged, so flags and environment still apply.
+func profileTransport(name string) (transport.Config, error) {
+	if isActiveProfile(name) {
+		return internal.Transport(), nil
+	}
+	dir, err := cliconfig.Dir()
+	if err != nil {
+		return transport.Config{}, err
+	}
+	path := cliconfig.Resolve(dir, name)
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return transport.Config{}, fmt.Errorf("profile %q: %w", name, err)
+	}
+	server := v.GetString("server")
+	if server == "" {
+		return transport.Config{}, fmt.Errorf("profile %q has no server configured (%s)", name, path)
+	}
+
+	url := apiURL(server)
+	hs := settings.NewHttpSettings(url)
+	var m sdkTokenManager
+	if tok := v.GetString("auth-token"); tok != "" {
+		if err := validateUserToken(tok); err != nil {
+			return transport.Config{}, fmt.Errorf("profile %q: %w", name, err)
+		}
+		m = ConfigTokenManager{Token: tok}
+	} else if key, secret := v.GetString("auth-key"), v.GetString("auth-secret"); key != "" && secret != "" {
+		m = NewCachedTokenManager(key, secret, v.GetString("cached-token"), v.GetInt64("cached-token-expiry"), path, hs)
+	}
+	return transport.Config{BaseURL: url, Tokens: newTokenProvider(m, hs)}, nil
+}
@@ -27,6 +27,7 @@ import (
 	neturl "net/url"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -322,22 +323,19 @@ func getAllWorkflowMetadata(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return parseAPIError(err, "Failed to get workflows")
 	}
-	fmt.Println("[")
-	for i, data := range metadata {
-		if regex != nil {
-			if regex.Match([]byte(data.Name)) {
-				bytes, _ := json.MarshalIndent(data, "", "   ")
-				fmt.Println(string(bytes))
-			}
-		} else {
-			bytes, _ := json.MarshalIndent(data, "", "   ")
-			fmt.Println(string(bytes))
-		}
-		if i < len(metadata)-1 {
-			fmt.Print(",")
-		}
+	if regex != nil {
+		metadata = slices.DeleteFunc(metadata, func(def model.WorkflowDef) bool {
+			return !regex.MatchString(def.Name)
+		})
+	}
+	if metadata == nil {
+		metadata = []model.WorkflowDef{}
 	}
-	fmt.Println("]")
+	data, err := json.MarshalIndent(metadata, "", "   ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
 
 	return nil
 }
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+	"github.com/conductor-oss/conductor-cli/internal/workflowdef"
+)
+
+var (
+	workflowDiffJSON     bool
+	workflowDiffExitCode bool
+)
+
+var workflowDiffCmd = &cobra.Command{
+	Use:   "diff <from> <to>",
+	Short: "Compare two workflow definitions",
+	Long: `Compare two workflow definitions semantically: workflow-level fields, then tasks
+matched by taskReferenceName wherever they are nested. Tasks are reported added,
+removed or moved (to another branch, or out of order), and for tasks in both, each
+changed field such as an input parameter, timeout or retry setting.
+
+Each side is a local JSON file, or a stored definition given as name, name@version
+or profile:name@version. Without a version the latest is used; without a profile,
+the active one.`,
+	Example: `  # Review a local edit against what the server runs
+  conductor workflow diff order_flow order_flow.json
+
+  # Compare two stored versions
+  conductor workflow diff order_flow@3 order_flow@4
+
+  # Check staging against production before promoting
+  conductor workflow diff staging:order_flow prod:order_flow --exit-code`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := loadWorkflowSource(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		to, err := loadWorkflowSource(cmd.Context(), args[1])
+		if err != nil {
+			return err
+		}
+		changes, err := workflowdef.Diff(from, to)
+		if err != nil {
+			return err
+		}
+
+		if workflowDiffJSON {
+			if changes == nil {
+				changes = []jsondiff.Change{}
+			}
+			data, err := json.MarshalIndent(changes, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		} else {
+			fmt.Printf("%s -> %s\n", args[0], args[1])
+			renderWorkflowDiff(os.Stdout, changes)
+		}
+		if workflowDiffExitCode && len(changes) > 0 {
+			return fmt.Errorf("%d difference(s) found", len(changes))
+		}
+		return nil
+	},
+}
+
+// loadWorkflowSource reads one side of a diff: the local file the argument names,
+// if there is one, else the stored definition it references.
+func loadWorkflowSource(ctx context.Context, arg string) (json.RawMessage, error) {
+	if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, err
+		}
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return nil, parseJSONError(err, string(data), arg)
+		}
+		return data, nil
+	}
+
+	profileName, name, version, err := workflowdef.ParseRef(arg)
+	if err != nil {
+		return nil, fmt.Errorf("%w; or name an existing file", err)
+	}
+	t, err := profileTransport(profileName)
+	if err != nil {
+		return nil, err
+	}
+	def, err := workflowdef.Fetch(ctx, t, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", arg, err)
+	}
+	return def, nil
+}
+
+// renderWorkflowDiff prints one line per change: + added, - removed and > moved
+// tasks, then field changes as agent diff shows them, labelled with their task.
+func renderWorkflowDiff(w io.Writer, changes []jsondiff.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No differences.")
+		return
+	}
+	for _, c := range changes {
+		if c.Task != "" && c.Path == "" {
+			switch c.Kind {
+			case jsondiff.Added:
+				fmt.Fprintf(w, "+ task %s: %s\n", c.Task, c.New)
+			case jsondiff.Removed:
+				fmt.Fprintf(w, "- task %s: %s\n", c.Task, c.Old)
+			case jsondiff.Moved:
+				fmt.Fprintf(w, "> task %s: %s -> %s\n", c.Task, c.Old, c.New)
+			}
+			continue
+		}
+		label := c.Path
+		if c.Task != "" {
+			label = "task " + c.Task + " " + c.Path
+		}
+		c.Path = label
+		renderAgentDiff(w, []jsondiff.Change{c})
+	}
+}
+
+func init() {
+	workflowDiffCmd.Flags().BoolVar(&workflowDiffJSON, "json", false, "Output the changes as JSON")
+	workflowDiffCmd.Flags().BoolVar(&workflowDiffExitCode, "exit-code", false, "Exit non-zero when the definitions differ")
+	workflowCmd.AddCommand(workflowDiffCmd)
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+func TestRenderWorkflowDiff(t *testing.T) {
+	var out strings.Builder
+	renderWorkflowDiff(&out, []jsondiff.Change{
+		{Path: "timeoutSeconds", Kind: jsondiff.Changed, Old: "60", New: "120"},
+		{Task: "fetch", Path: "inputParameters.url", Kind: jsondiff.Changed, Old: "http://a", New: "http://b"},
+		{Task: "fetch", Path: "retryLogic", Kind: jsondiff.Added, New: "FIXED"},
+		{Task: "ship", Kind: jsondiff.Moved, Old: "route.defaultCase[0]", New: "tasks[3]"},
+		{Task: "charge", Kind: jsondiff.Added, New: "SIMPLE at tasks[4]"},
+		{Task: "audit", Kind: jsondiff.Removed, Old: "SIMPLE at tasks[5]"},
+	})
+	want := "~ timeoutSeconds: 60 -> 120\n" +
+		"~ task fetch inputParameters.url: http://a -> http://b\n" +
+		"+ task fetch retryLogic: FIXED\n" +
+		"> task ship: route.defaultCase[0] -> tasks[3]\n" +
+		"+ task charge: SIMPLE at tasks[4]\n" +
+		"- task audit: SIMPLE at tasks[5]\n"
+	if out.String() != want {
+		t.Errorf("rendered =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestLoadWorkflowSourceReadsLocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "wf.json")
+	if err := os.WriteFile(good, []byte(`{"name": "wf", "tasks": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if def, err := loadWorkflowSource(context.Background(), good); err != nil || !strings.Contains(string(def), `"wf"`) {
+		t.Errorf("def = %s, err = %v", def, err)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`[1, 2]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadWorkflowSource(context.Background(), bad); err == nil {
+		t.Error("a file that is not a JSON object should fail")
+	}
+}
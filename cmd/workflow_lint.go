@@ -0,0 +1,217 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/conductor-oss/conductor-cli/internal"
+	"github.com/conductor-oss/conductor-cli/internal/apply"
+	"github.com/conductor-oss/conductor-cli/internal/transport"
+	"github.com/conductor-oss/conductor-cli/internal/workflowdef"
+	"github.com/conductor-oss/conductor-cli/internal/workflowlint"
+)
+
+var (
+	workflowLintJSON   bool
+	workflowLintSARIF  bool
+	workflowLintStrict bool
+	workflowLintOnline bool
+)
+
+var workflowLintCmd = &cobra.Command{
+	Use:   "lint <file>...",
+	Short: "Check workflow definition files offline",
+	Long: `Check workflow definition files (JSON or YAML; a file may hold a list of
+workflows) without a server, for mistakes the server accepts but that break at
+run time:
+
+  - tasks without a taskReferenceName, or sharing one
+  - ${...} expressions naming a task or workflow input that does not exist
+  - SWITCH cases that can never run, because the switch value is a constant
+  - DO_WHILE loop conditions reading values the loop does not provide
+  - FORK_JOIN tasks not followed by a JOIN on their own branches
+  - SUB_WORKFLOW tasks that do not name a workflow
+  - tasks whose timeoutSeconds exceeds the workflow's
+
+With --online the server is asked as well: whether each sub-workflow exists, and
+the timeouts of registered task definitions.
+
+Problems are printed as file:line:column with a JSON pointer to the field.
+Errors fail the command; warnings do too with --strict. --sarif prints a SARIF
+2.1.0 log for code scanning, so linting can gate pull requests.`,
+	Example: `  # Check every workflow in a directory
+  conductor workflow lint workflows/*.json
+
+  # Also check sub-workflows and task definitions against the server
+  conductor workflow lint order_flow.yaml --online
+
+  # Produce a report for code scanning in CI
+  conductor workflow lint workflows/*.json --sarif > workflow-lint.sarif`,
+	Annotations:  map[string]string{offlineAnnotation: "online"},
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := workflowlint.Options{}
+		if workflowLintOnline {
+			opts.Resolver = newServerLintResolver(internal.Transport())
+		}
+
+		var files []workflowlint.File
+		errs, warns := 0, 0
+		for _, path := range args {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("read workflow file: %w", err)
+			}
+			diags, err := workflowlint.Lint(cmd.Context(), data, opts)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			for _, d := range diags {
+				if d.Severity == workflowlint.SeverityError {
+					errs++
+				} else {
+					warns++
+				}
+			}
+			files = append(files, workflowlint.File{Path: path, Diagnostics: diags})
+		}
+
+		switch {
+		case workflowLintSARIF:
+			if err := workflowlint.WriteSARIF(os.Stdout, Version, files); err != nil {
+				return err
+			}
+		case workflowLintJSON:
+			if err := writeWorkflowLintJSON(os.Stdout, files); err != nil {
+				return err
+			}
+		default:
+			for _, f := range files {
+				for _, d := range f.Diagnostics {
+					fmt.Printf("%s:%s\n", f.Path, d)
+				}
+			}
+			if errs == 0 && warns == 0 {
+				fmt.Printf("%d file(s) valid.\n", len(args))
+			}
+		}
+
+		if errs > 0 || (workflowLintStrict && warns > 0) {
+			return fmt.Errorf("%d error(s), %d warning(s)", errs, warns)
+		}
+		return nil
+	},
+}
+
+// writeWorkflowLintJSON prints the diagnostics per file, as agent validate does.
+func writeWorkflowLintJSON(w io.Writer, files []workflowlint.File) error {
+	type fileResult struct {
+		File        string                    `json:"file"`
+		Diagnostics []workflowlint.Diagnostic `json:"diagnostics"`
+	}
+	results := []fileResult{}
+	for _, f := range files {
+		diags := f.Diagnostics
+		if diags == nil {
+			diags = []workflowlint.Diagnostic{}
+		}
+		results = append(results, fileResult{File: f.Path, Diagnostics: diags})
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// serverLintResolver answers lint's questions from the configured server,
+// remembering task definitions since workflows often reuse them.
+type serverLintResolver struct {
+	t        transport.Config
+	server   apply.Server
+	timeouts map[string]*int64
+}
+
+func newServerLintResolver(t transport.Config) *serverLintResolver {
+	return &serverLintResolver{t: t, server: apply.NewClient(t), timeouts: map[string]*int64{}}
+}
+
+func (r *serverLintResolver) WorkflowExists(ctx context.Context, name string, version *int) (bool, error) {
+	if version != nil {
+		_, found, err := r.server.Get(ctx, apply.KindWorkflow, name, *version)
+		return found, err
+	}
+	def, err := workflowdef.Fetch(ctx, r.t, name, nil)
+	var apiErr *transport.APIError
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var probe struct {
+		Name string `json:"name"`
+	}
+	// Some servers answer an unknown name with an empty body instead of a 404.
+	return json.Unmarshal(def, &probe) == nil && probe.Name != "", nil
+}
+
+func (r *serverLintResolver) TaskTimeout(ctx context.Context, taskName string) (int64, bool, error) {
+	if s, seen := r.timeouts[taskName]; seen {
+		return derefTimeout(s)
+	}
+	def, found, err := r.server.Get(ctx, apply.KindTaskDef, taskName, 0)
+	if err != nil {
+		return 0, false, err
+	}
+	var s *int64
+	if found {
+		var td struct {
+			TimeoutSeconds int64 `json:"timeoutSeconds"`
+		}
+		if err := json.Unmarshal(def, &td); err != nil {
+			return 0, false, fmt.Errorf("decode task definition %s: %w", taskName, err)
+		}
+		s = &td.TimeoutSeconds
+	}
+	r.timeouts[taskName] = s
+	return derefTimeout(s)
+}
+
+func derefTimeout(s *int64) (int64, bool, error) {
+	if s == nil {
+		return 0, false, nil
+	}
+	return *s, true, nil
+}
+
+func init() {
+	workflowLintCmd.Flags().BoolVar(&workflowLintJSON, "json", false, "Print the diagnostics as JSON")
+	workflowLintCmd.Flags().BoolVar(&workflowLintSARIF, "sarif", false, "Print the diagnostics as a SARIF 2.1.0 log")
+	workflowLintCmd.Flags().BoolVar(&workflowLintStrict, "strict", false, "Fail on warnings as well as errors")
+	workflowLintCmd.Flags().BoolVar(&workflowLintOnline, "online", false, "Also check sub-workflows and task definitions on the server")
+	workflowLintCmd.MarkFlagsMutuallyExclusive("json", "sarif")
+	workflowCmd.AddCommand(workflowLintCmd)
+}
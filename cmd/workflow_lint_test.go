@@ -0,0 +1,84 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/transport"
+	"github.com/conductor-oss/conductor-cli/internal/workflowlint"
+)
+
+func TestServerLintResolver(t *testing.T) {
+	taskFetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metadata/workflow/child":
+			_, _ = io.WriteString(w, `{"name":"child","version":1,"tasks":[]}`)
+		case "/metadata/taskdefs/charge":
+			taskFetches++
+			_, _ = io.WriteString(w, `{"name":"charge","timeoutSeconds":900}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	r := newServerLintResolver(transport.Config{BaseURL: srv.URL})
+	ctx := context.Background()
+
+	one := 1
+	for _, tc := range []struct {
+		name    string
+		version *int
+		want    bool
+	}{{"child", nil, true}, {"child", &one, true}, {"gone", nil, false}, {"gone", &one, false}} {
+		if got, err := r.WorkflowExists(ctx, tc.name, tc.version); got != tc.want || err != nil {
+			t.Errorf("WorkflowExists(%s, %v) = %t, %v; want %t", tc.name, tc.version, got, err, tc.want)
+		}
+	}
+
+	for range 2 {
+		if s, found, err := r.TaskTimeout(ctx, "charge"); s != 900 || !found || err != nil {
+			t.Errorf("TaskTimeout(charge) = %d, %t, %v", s, found, err)
+		}
+	}
+	if taskFetches != 1 {
+		t.Errorf("task definition fetched %d times, want once", taskFetches)
+	}
+	if _, found, err := r.TaskTimeout(ctx, "unknown"); found || err != nil {
+		t.Errorf("TaskTimeout(unknown) = %t, %v; want not found", found, err)
+	}
+}
+
+func TestWriteWorkflowLintJSONListsCleanFiles(t *testing.T) {
+	var out strings.Builder
+	files := []workflowlint.File{
+		{Path: "a.json"},
+		{Path: "b.yaml", Diagnostics: []workflowlint.Diagnostic{{Line: 3, Column: 5, Severity: workflowlint.SeverityError,
+			Rule: workflowlint.RuleMissingRef, Pointer: "/tasks/0", Message: "no ref"}}},
+	}
+	if err := writeWorkflowLintJSON(&out, files); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"file": "a.json",` + "\n    \"diagnostics\": []", `"pointer": "/tasks/0"`, `"rule": "missing-task-ref"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -14,6 +14,7 @@
 package agent
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -105,6 +106,9 @@ type restClient struct {
 
 const unsupportedAPIMessage = "Agents API is not available on this Conductor server. Update to the latest version of Conductor with Agents enabled."
 
+// errUnsupportedAPI is returned when the server has no Agents API.
+var errUnsupportedAPI = errors.New(unsupportedAPIMessage)
+
 // do and doJSON preserve normal API errors, but turn a missing Agents API into an
 // actionable compatibility error. A 404 from a resource endpoint is ambiguous: it
 // can mean either "this agent/execution does not exist" or "this server predates the
@@ -132,7 +136,7 @@ func (c *restClient) agentAPIError(ctx context.Context, path string, err error)
 		return err
 	}
 	if path == pathList {
-		return errors.New(unsupportedAPIMessage)
+		return errUnsupportedAPI
 	}
 
 	resp, probeErr := c.t.Do(ctx, http.MethodGet, pathList, nil, nil)
@@ -142,11 +146,17 @@ func (c *restClient) agentAPIError(ctx context.Context, path string, err error)
 	}
 	var probeAPIErr *transport.APIError
 	if errors.As(probeErr, &probeAPIErr) && probeAPIErr.Status == http.StatusNotFound {
-		return errors.New(unsupportedAPIMessage)
+		return errUnsupportedAPI
 	}
 	return err
 }
 
+// IsNotFound reports whether err is the server saying the resource does not exist.
+func IsNotFound(err error) bool {
+	var apiErr *transport.APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
+
 // Wire DTOs — private to the client so the JSON shape never leaks across a boundary.
 
 type startRequest struct {
@@ -163,8 +173,9 @@ type frameworkRequest struct {
 }
 
 type startResponse struct {
-	ExecutionID string `json:"executionId"`
-	AgentName   string `json:"agentName"`
+	ExecutionID     string   `json:"executionId"`
+	AgentName       string   `json:"agentName"`
+	RequiredWorkers []string `json:"requiredWorkers,omitempty"`
 }
 
 type deployRequest struct {
@@ -205,7 +216,7 @@ func (c *restClient) Run(ctx context.Context, req RunRequest) (Execution, error)
 	if err := c.doJSON(ctx, http.MethodPost, pathStart, body, &sr); err != nil {
 		return Execution{}, err
 	}
-	return Execution{ID: sr.ExecutionID, AgentName: sr.AgentName}, nil
+	return Execution{ID: sr.ExecutionID, AgentName: sr.AgentName, RequiredWorkers: sr.RequiredWorkers}, nil
 }
 
 // Deploy publishes and activates a framework agent definition without starting an
@@ -225,6 +236,7 @@ func (c *restClient) Deploy(ctx context.Context, framework string, rawConfig jso
 // channel carries the terminal error (nil on a clean end). Cancelling ctx ends the
 // stream — that surfaces as a context error which the service treats as a clean stop
 // — and also releases a send blocked on an event channel the caller stopped reading.
+// A connect error that retrying cannot fix is wrapped in permanentError.
 func (c *restClient) Stream(ctx context.Context, executionID, lastEventID string) (<-chan SSEEvent, <-chan error) {
 	events := make(chan SSEEvent, sseChannelBuffer)
 	errc := make(chan error, 1)
@@ -239,6 +251,9 @@ func (c *restClient) Stream(ctx context.Context, executionID, lastEventID string
 		}
 		resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf(pathStreamFmt, url.PathEscape(executionID)), nil, header)
 		if err != nil {
+			if !streamRetryable(err) {
+				err = &permanentError{err: err}
+			}
 			errc <- err
 			return
 		}
@@ -248,6 +263,27 @@ func (c *restClient) Stream(ctx context.Context, executionID, lastEventID string
 	return events, errc
 }
 
+// permanentError marks a stream error that reconnecting cannot fix — the server
+// answered and refused — so the service returns it instead of retrying.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// streamRetryable reports whether a failed stream connect may succeed on a retry:
+// network errors, throttling and server errors may; any other API error — an
+// unknown execution, bad credentials, a server without the Agents API — will not.
+func streamRetryable(err error) bool {
+	if errors.Is(err, errUnsupportedAPI) {
+		return false
+	}
+	var apiErr *transport.APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	return apiErr.Status == http.StatusTooManyRequests || apiErr.Status >= http.StatusInternalServerError
+}
+
 func (c *restClient) List(ctx context.Context) ([]AgentSummary, error) {
 	var out []AgentSummary
 	if err := c.doJSON(ctx, http.MethodGet, pathList, nil, &out); err != nil {
@@ -289,8 +325,8 @@ func (c *restClient) SearchExecutions(ctx context.Context, filter ExecutionFilte
 	q := url.Values{}
 	q.Set(queryStart, strconv.Itoa(filter.Start))
 	q.Set(querySize, strconv.Itoa(filter.Size))
-	q.Set(querySort, sortExecutions)
-	q.Set(queryFreeText, freeTextAll)
+	q.Set(querySort, cmp.Or(filter.Sort, sortExecutions))
+	q.Set(queryFreeText, cmp.Or(filter.FreeText, freeTextAll))
 	q.Set(queryClassifier, classifierAgent)
 	q.Set(queryTopLevelOnly, valueTrue)
 	if clauses != "" {
@@ -306,7 +342,8 @@ func (c *restClient) SearchExecutions(ctx context.Context, filter ExecutionFilte
 
 // executionQueryClauses renders filter as a Conductor search expression, e.g.
 // "workflowType='triage' AND startTime>1786120404085". Returns "" for an empty
-// filter, and an error if a value contains a quote.
+// filter, and an error if a value contains a quote. A raw Query is appended as is,
+// parenthesized when other clauses precede it.
 func executionQueryClauses(filter ExecutionFilter) (string, error) {
 	var clauses []string
 	if filter.AgentName != "" {
@@ -328,6 +365,13 @@ func executionQueryClauses(filter ExecutionFilter) (string, error) {
 	if filter.StartTimeTo > 0 {
 		clauses = append(clauses, fmt.Sprintf("startTime<%d", filter.StartTimeTo))
 	}
+	if filter.Query != "" {
+		if len(clauses) > 0 {
+			clauses = append(clauses, "("+filter.Query+")")
+		} else {
+			clauses = append(clauses, filter.Query)
+		}
+	}
 	return strings.Join(clauses, " AND "), nil
 }
 
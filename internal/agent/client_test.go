@@ -17,6 +17,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -79,15 +80,18 @@ func TestRunSendsFrameworkEnvelope(t *testing.T) {
 	var raw map[string]json.RawMessage
 	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
 		_ = json.NewDecoder(r.Body).Decode(&raw)
-		_, _ = w.Write([]byte(`{"executionId":"e2","agentName":"x"}`))
+		_, _ = w.Write([]byte(`{"executionId":"e2","agentName":"x","requiredWorkers":["lookup"]}`))
 	})
-	_, err := c.Run(context.Background(), RunRequest{
+	exec, err := c.Run(context.Background(), RunRequest{
 		Framework:  "openai",
 		Definition: json.RawMessage(`{"name":"x"}`),
 	})
 	if err != nil {
 		t.Fatalf("Run: %v", err)
 	}
+	if len(exec.RequiredWorkers) != 1 || exec.RequiredWorkers[0] != "lookup" {
+		t.Errorf("RequiredWorkers = %v, want [lookup]", exec.RequiredWorkers)
+	}
 	if _, ok := raw["framework"]; !ok {
 		t.Errorf("expected framework envelope, got keys %v", keysOf(raw))
 	}
@@ -197,6 +201,22 @@ func TestSearchExecutionsSendsStartTimeAsStructuredQuery(t *testing.T) {
 	}
 }
 
+func TestSearchExecutionsPassesSortAndFreeText(t *testing.T) {
+	var got url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		_, _ = w.Write([]byte(`{"totalHits":0,"results":[]}`))
+	})
+	if _, err := c.SearchExecutions(context.Background(), ExecutionFilter{
+		Sort: "endTime:ASC", FreeText: "refund", Start: 100, Size: 50,
+	}); err != nil {
+		t.Fatalf("SearchExecutions: %v", err)
+	}
+	if got.Get(querySort) != "endTime:ASC" || got.Get(queryFreeText) != "refund" || got.Get(queryStart) != "100" {
+		t.Errorf("params = %v, want the filter's sort, free text and start", got)
+	}
+}
+
 func TestExecutionQueryClauses(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -208,6 +228,8 @@ func TestExecutionQueryClauses(t *testing.T) {
 		{"window", ExecutionFilter{StartTimeFrom: 5, StartTimeTo: 9}, "startTime>5 AND startTime<9"},
 		{"name and time", ExecutionFilter{AgentName: "triage", StartTimeFrom: 5}, "workflowType='triage' AND startTime>5"},
 		{"status", ExecutionFilter{Status: "FAILED"}, "status='FAILED'"},
+		{"raw query", ExecutionFilter{Query: "status IN (FAILED,TIMED_OUT)"}, "status IN (FAILED,TIMED_OUT)"},
+		{"name and raw query", ExecutionFilter{AgentName: "triage", Query: "a=1 OR b=2"}, "workflowType='triage' AND (a=1 OR b=2)"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -437,3 +459,21 @@ func keysOf(m map[string]json.RawMessage) []string {
 	}
 	return ks
 }
+
+func TestStreamRetryable(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network", errors.New("connection reset by peer"), true},
+		{"throttled", &transport.APIError{Status: http.StatusTooManyRequests}, true},
+		{"server error", &transport.APIError{Status: http.StatusBadGateway}, true},
+		{"unknown execution", &transport.APIError{Status: http.StatusNotFound}, false},
+		{"no agents API", fmt.Errorf("connect: %w", errUnsupportedAPI), false},
+	} {
+		if got := streamRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: streamRetryable = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
@@ -18,7 +18,9 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // EventType enumerates the streamed agent event kinds. Defining them as named
@@ -52,15 +54,26 @@ const (
 	fieldID         = "id:"
 	fieldEvent      = "event:"
 	fieldData       = "data:"
+	fieldRetry      = "retry:"
 	fieldComment    = ":"
 )
 
 // SSEEvent is one decoded Server-Sent Event. Data stays raw so the transport layer
 // never needs to know each event's payload schema — the presentation layer decodes it.
+//
+// Retry carries the reconnection delay from a "retry:" field in the same record. A
+// record holding only that field still arrives, as an event with no type or data,
+// so the reconnect logic sees it; the service does not forward such events to sinks.
 type SSEEvent struct {
-	ID   string
-	Type EventType
-	Data json.RawMessage
+	ID    string
+	Type  EventType
+	Data  json.RawMessage
+	Retry time.Duration
+}
+
+// retryOnly reports whether the event exists only to carry a reconnection delay.
+func (e SSEEvent) retryOnly() bool {
+	return e.Retry > 0 && e.Type == "" && len(e.Data) == 0
 }
 
 // ResolvedType returns the event's type, preferring the SSE event field and falling
@@ -103,6 +116,18 @@ type EventPayload struct {
 	Timestamp     int64          `json:"timestamp"`
 }
 
+// PendingToolName names the tool a waiting event is blocked on. The server's
+// pendingTool is free-form, so the usual name keys are tried in order; "" when none
+// is present.
+func (p EventPayload) PendingToolName() string {
+	for _, key := range []string{"name", "toolName", "taskReferenceName"} {
+		if s, ok := p.PendingTool[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
 // RawValue is a payload field the server types as a free-form object — tool
 // arguments, tool results, the final output. Keeping it raw lets the payload stay
 // typed without pinning down schemas the server does not fix.
@@ -145,6 +170,20 @@ func (v RawValue) String() string {
 	return string(encoded)
 }
 
+// Normalized returns the payload with its envelope filled in from the SSE framing:
+// the resolved type, and the SSE id when the data carries no numeric one. It is the
+// one shape machine-readable output emits, whichever way the server framed the event.
+func (e SSEEvent) Normalized() EventPayload {
+	p := e.Payload()
+	p.Type = e.ResolvedType()
+	if p.ID == 0 {
+		if id, err := strconv.ParseInt(e.ID, 10, 64); err == nil {
+			p.ID = id
+		}
+	}
+	return p
+}
+
 // Payload decodes the event data into the typed payload. A malformed or empty body
 // yields the zero payload rather than an error: a renderer shows what arrived, it
 // does not police the wire format.
@@ -156,8 +195,9 @@ func (e SSEEvent) Payload() EventPayload {
 
 // parseSSE reads a text/event-stream body and emits one SSEEvent per record onto
 // out, following WHATWG SSE framing: a blank line ends a record, ":" lines are
-// comments (heartbeats), and multi-line data is joined with newlines. It returns the
-// scanner error (or nil) when the stream ends; the caller owns closing out.
+// comments (heartbeats), multi-line data is joined with newlines and "retry:" sets
+// the record's reconnection delay. It returns the scanner error (or nil) when the
+// stream ends; the caller owns closing out.
 //
 // Every send selects on ctx, so a consumer that walks away mid-stream — the stream
 // ends on a terminal event, or the user hits Ctrl-C — cannot strand this goroutine
@@ -168,22 +208,23 @@ func parseSSE(ctx context.Context, r io.Reader, out chan<- SSEEvent) error {
 
 	var id, event string
 	var dataLines []string
+	var retry time.Duration
 
 	// flush reports whether the record was delivered; false means ctx is done.
 	flush := func() bool {
-		if len(dataLines) == 0 && event == "" {
+		if len(dataLines) == 0 && event == "" && retry == 0 {
 			return true
 		}
+		var data json.RawMessage
+		if len(dataLines) > 0 {
+			data = json.RawMessage(strings.Join(dataLines, "\n"))
+		}
 		select {
-		case out <- SSEEvent{
-			ID:   id,
-			Type: EventType(event),
-			Data: json.RawMessage(strings.Join(dataLines, "\n")),
-		}:
+		case out <- SSEEvent{ID: id, Type: EventType(event), Data: data, Retry: retry}:
 		case <-ctx.Done():
 			return false
 		}
-		id, event, dataLines = "", "", dataLines[:0]
+		id, event, dataLines, retry = "", "", dataLines[:0], 0
 		return true
 	}
 
@@ -202,6 +243,11 @@ func parseSSE(ctx context.Context, r io.Reader, out chan<- SSEEvent) error {
 			event = sseFieldValue(line, fieldEvent)
 		case strings.HasPrefix(line, fieldData):
 			dataLines = append(dataLines, sseFieldValue(line, fieldData))
+		case strings.HasPrefix(line, fieldRetry):
+			// Per the spec, a value that is not all ASCII digits is ignored.
+			if ms, err := strconv.ParseUint(sseFieldValue(line, fieldRetry), 10, 32); err == nil && ms > 0 {
+				retry = time.Duration(ms) * time.Millisecond
+			}
 		}
 	}
 	if !flush() {
@@ -59,6 +59,22 @@ func TestParseSSEFramingAndComments(t *testing.T) {
 	}
 }
 
+func TestParseSSERetryField(t *testing.T) {
+	body := "retry: 2500\n\n" +
+		"retry: soon\nevent: message\ndata: {}\n\n"
+
+	got := collectSSE(t, body)
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(got), got)
+	}
+	if !got[0].retryOnly() || got[0].Retry != 2500*time.Millisecond {
+		t.Errorf("event 0 = %+v, want a retry-only record of 2.5s", got[0])
+	}
+	if got[1].Retry != 0 || got[1].Type != EventMessage {
+		t.Errorf("event 1 = %+v, want the message with the malformed retry ignored", got[1])
+	}
+}
+
 func TestResolvedTypeFallsBackToDataType(t *testing.T) {
 	e := SSEEvent{Data: []byte(`{"type":"thinking"}`)}
 	if e.ResolvedType() != EventThinking {
@@ -128,6 +144,18 @@ func TestPayloadDecodesServerFieldNames(t *testing.T) {
 	}
 }
 
+func TestNormalizedFillsEnvelopeFromFraming(t *testing.T) {
+	p := SSEEvent{ID: "17", Type: EventMessage, Data: []byte(`{"content":"hi"}`)}.Normalized()
+	if p.ID != 17 || p.Type != EventMessage || p.Content != "hi" {
+		t.Errorf("Normalized() = %+v, want id 17 and type message from the framing", p)
+	}
+
+	p = SSEEvent{ID: "abc", Data: []byte(`{"id":5,"type":"handoff","target":"billing"}`)}.Normalized()
+	if p.ID != 5 || p.Type != EventHandoff {
+		t.Errorf("Normalized() = %+v, want the payload's own id and type kept", p)
+	}
+}
+
 func TestPayloadOfMalformedDataIsZero(t *testing.T) {
 	if p := (SSEEvent{Data: []byte("not json")}).Payload(); !reflect.DeepEqual(p, EventPayload{}) {
 		t.Errorf("payload = %+v, want zero", p)
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+// DeployAction is what deploying a definition would do to the stored agent.
+type DeployAction string
+
+const (
+	DeployCreate    DeployAction = "create"
+	DeployUpdate    DeployAction = "update"
+	DeployUnchanged DeployAction = "unchanged"
+)
+
+// DeployPlan compares a definition about to be deployed with the agent's latest
+// stored version. Changes is empty unless Action is DeployUpdate.
+type DeployPlan struct {
+	AgentName string            `json:"agentName"`
+	Action    DeployAction      `json:"action"`
+	Version   int               `json:"currentVersion,omitempty"`
+	Changes   []jsondiff.Change `json:"changes,omitempty"`
+}
+
+// PlanDeploy reports whether deploying def as name would create the agent, change
+// it or leave it as it is, with the structural differences from the stored
+// definition. def must be in the stored form, as `agent get` prints it.
+func (s *service) PlanDeploy(ctx context.Context, name string, def json.RawMessage) (DeployPlan, error) {
+	plan := DeployPlan{AgentName: name, Action: DeployCreate}
+	current, err := s.client.Get(ctx, name, nil)
+	if IsNotFound(err) {
+		return plan, nil
+	}
+	if err != nil {
+		return DeployPlan{}, fmt.Errorf("get %s: %w", name, err)
+	}
+	changes, err := DiffDefinitions(current, def)
+	if err != nil {
+		return DeployPlan{}, fmt.Errorf("compare %s: %w", name, err)
+	}
+	plan.Version = newAgentVersion(current).Version
+	plan.Action = DeployUnchanged
+	if len(changes) > 0 {
+		plan.Action, plan.Changes = DeployUpdate, changes
+	}
+	return plan, nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/transport"
+)
+
+func TestPlanDeploy(t *testing.T) {
+	stored := json.RawMessage(`{"name":"triage","version":3,"model":"openai/gpt-4o","updateTime":1}`)
+	tests := []struct {
+		name    string
+		fc      *fakeClient
+		def     string
+		action  DeployAction
+		changes int
+	}{
+		{"new agent", &fakeClient{getErr: &transport.APIError{Status: http.StatusNotFound}}, `{"name":"triage"}`, DeployCreate, 0},
+		{"same definition", &fakeClient{getDef: stored}, `{"name":"triage","model":"openai/gpt-4o"}`, DeployUnchanged, 0},
+		{"changed model", &fakeClient{getDef: stored}, `{"name":"triage","model":"openai/gpt-4o-mini"}`, DeployUpdate, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := NewService(tt.fc).PlanDeploy(context.Background(), "triage", json.RawMessage(tt.def))
+			if err != nil {
+				t.Fatalf("PlanDeploy: %v", err)
+			}
+			if plan.Action != tt.action || len(plan.Changes) != tt.changes {
+				t.Errorf("plan = %+v, want %s with %d change(s)", plan, tt.action, tt.changes)
+			}
+		})
+	}
+
+	failing := &fakeClient{getErr: &transport.APIError{Status: http.StatusInternalServerError}}
+	if _, err := NewService(failing).PlanDeploy(context.Background(), "triage", json.RawMessage(`{}`)); err == nil {
+		t.Error("PlanDeploy hid a server error")
+	}
+}
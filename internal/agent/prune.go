@@ -0,0 +1,154 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// How many executions the first search page holds while planning a prune, and how
+// many IDs each agent's line of the plan shows.
+const (
+	prunePageSize  = 100
+	pruneSampleIDs = 3
+	millisPerDay   = int64(24 * time.Hour / time.Millisecond)
+)
+
+// PruneOtherAgents names the line of a PrunePlan counting the executions of agents
+// the plan did not search one by one.
+const PruneOtherAgents = "(other agents)"
+
+const (
+	statusCompleted  = "COMPLETED"
+	statusFailed     = "FAILED"
+	statusTerminated = "TERMINATED"
+	statusTimedOut   = "TIMED_OUT"
+)
+
+// terminalStatuses are the statuses of executions prune removes; running and
+// paused executions are never touched.
+var terminalStatuses = []string{statusCompleted, statusFailed, statusTerminated, statusTimedOut}
+
+// PrunePlan is what a prune would remove, grouped by agent, most executions first.
+type PrunePlan struct {
+	Cutoff time.Time          `json:"cutoff"`
+	Total  int                `json:"total"`
+	Agents []PruneAgentCounts `json:"agents"`
+}
+
+// PruneAgentCounts is one agent's share of a PrunePlan, with a few of its
+// execution IDs as a sample.
+type PruneAgentCounts struct {
+	AgentName string   `json:"agentName"`
+	Count     int      `json:"count"`
+	SampleIDs []string `json:"sampleIds"`
+}
+
+// pruneFilter is the execution search matching what req prunes as of now:
+// terminal executions started before the age cutoff. The server prunes by its own
+// clock and timestamps, so the plan is a close estimate rather than a guarantee.
+func pruneFilter(req PruneRequest, now time.Time) (ExecutionFilter, error) {
+	if req.OlderThanDays < 0 {
+		return ExecutionFilter{}, fmt.Errorf("age must not be negative, got %d day(s)", req.OlderThanDays)
+	}
+	filter := ExecutionFilter{
+		AgentName:   req.AgentName,
+		StartTimeTo: now.UnixMilli() - int64(req.OlderThanDays)*millisPerDay,
+		Size:        prunePageSize,
+	}
+	if req.Status == "" {
+		filter.Query = fmt.Sprintf("status IN (%s)", strings.Join(terminalStatuses, ","))
+		return filter, nil
+	}
+	filter.Status = strings.ToUpper(req.Status)
+	if !slices.Contains(terminalStatuses, filter.Status) {
+		return ExecutionFilter{}, fmt.Errorf("status %q is not terminal; prune removes %s executions",
+			req.Status, strings.Join(terminalStatuses, ", "))
+	}
+	return filter, nil
+}
+
+// PlanPrune counts what req would prune without paging through it: one search
+// gives the total and a first page, which is the whole plan when it holds every
+// match. Otherwise each agent on that page is counted by a search of its own,
+// and executions of agents first seen beyond it are counted together under
+// PruneOtherAgents.
+func (s *service) PlanPrune(ctx context.Context, req PruneRequest, now time.Time) (PrunePlan, error) {
+	filter, err := pruneFilter(req, now)
+	if err != nil {
+		return PrunePlan{}, err
+	}
+	first, err := s.client.SearchExecutions(ctx, filter)
+	if err != nil {
+		return PrunePlan{}, err
+	}
+	plan := PrunePlan{Cutoff: time.UnixMilli(filter.StartTimeTo), Total: int(first.TotalHits)}
+
+	byAgent := map[string]*PruneAgentCounts{}
+	var names []string
+	for _, e := range first.Results {
+		c, ok := byAgent[e.AgentName]
+		if !ok {
+			c = &PruneAgentCounts{AgentName: e.AgentName}
+			byAgent[e.AgentName] = c
+			names = append(names, e.AgentName)
+		}
+		c.Count++
+		if len(c.SampleIDs) < pruneSampleIDs {
+			c.SampleIDs = append(c.SampleIDs, e.ExecutionID)
+		}
+	}
+	counted := len(first.Results)
+	switch {
+	case int64(counted) >= first.TotalHits:
+		// The first page holds every match.
+	case req.AgentName != "" && len(names) > 0:
+		// Every match is the one agent's.
+		byAgent[names[0]].Count, counted = plan.Total, plan.Total
+	default:
+		counted = 0
+		for _, name := range names {
+			f := filter
+			f.AgentName, f.Size = name, pruneSampleIDs
+			page, err := s.client.SearchExecutions(ctx, f)
+			if err != nil {
+				return PrunePlan{}, err
+			}
+			c := byAgent[name]
+			c.Count, c.SampleIDs = int(page.TotalHits), nil
+			for _, e := range page.Results[:min(len(page.Results), pruneSampleIDs)] {
+				c.SampleIDs = append(c.SampleIDs, e.ExecutionID)
+			}
+			counted += c.Count
+		}
+	}
+
+	for _, name := range names {
+		plan.Agents = append(plan.Agents, *byAgent[name])
+	}
+	slices.SortFunc(plan.Agents, func(a, b PruneAgentCounts) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.AgentName, b.AgentName)
+	})
+	if rest := plan.Total - counted; rest > 0 {
+		plan.Agents = append(plan.Agents, PruneAgentCounts{AgentName: PruneOtherAgents, Count: rest, SampleIDs: []string{}})
+	}
+	return plan, nil
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlanPruneGroupsByAgent(t *testing.T) {
+	var results []ExecutionSummary
+	for i, name := range []string{"triage", "billing", "triage", "triage", "triage", "billing"} {
+		results = append(results, ExecutionSummary{ExecutionID: string(rune('a' + i)), AgentName: name})
+	}
+	fc := &fakeClient{pages: []ExecutionPage{{TotalHits: int64(len(results)), Results: results}}}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	plan, err := NewService(fc).PlanPrune(context.Background(), PruneRequest{OlderThanDays: 30}, now)
+	if err != nil {
+		t.Fatalf("PlanPrune: %v", err)
+	}
+	want := PrunePlan{
+		Cutoff: now.AddDate(0, 0, -30),
+		Total:  6,
+		Agents: []PruneAgentCounts{
+			{AgentName: "triage", Count: 4, SampleIDs: []string{"a", "c", "d"}},
+			{AgentName: "billing", Count: 2, SampleIDs: []string{"b", "f"}},
+		},
+	}
+	if !plan.Cutoff.Equal(want.Cutoff) || plan.Total != want.Total || !reflect.DeepEqual(plan.Agents, want.Agents) {
+		t.Errorf("plan = %+v, want %+v", plan, want)
+	}
+	if got := fc.lastSearch; got.StartTimeTo != want.Cutoff.UnixMilli() || got.Query != "status IN (COMPLETED,FAILED,TERMINATED,TIMED_OUT)" {
+		t.Errorf("search filter = %+v, want terminal executions before the cutoff", got)
+	}
+}
+
+// A plan larger than one page is counted from search totals, not by paging.
+func TestPlanPruneCountsFromTotals(t *testing.T) {
+	ids := func(prefix string, n int) []ExecutionSummary {
+		var out []ExecutionSummary
+		for i := range n {
+			out = append(out, ExecutionSummary{ExecutionID: fmt.Sprintf("%s%d", prefix, i), AgentName: prefix})
+		}
+		return out
+	}
+	fc := &fakeClient{search: func(f ExecutionFilter) ExecutionPage {
+		switch f.AgentName {
+		case "":
+			return ExecutionPage{TotalHits: 25000, Results: append(ids("triage", 2), ids("billing", 1)...)}
+		case "triage":
+			return ExecutionPage{TotalHits: 20000, Results: ids("triage", f.Size)}
+		default:
+			return ExecutionPage{TotalHits: 4000, Results: ids("billing", f.Size)}
+		}
+	}}
+
+	plan, err := NewService(fc).PlanPrune(context.Background(), PruneRequest{OlderThanDays: 30}, time.Now())
+	if err != nil {
+		t.Fatalf("PlanPrune: %v", err)
+	}
+	want := []PruneAgentCounts{
+		{AgentName: "triage", Count: 20000, SampleIDs: []string{"triage0", "triage1", "triage2"}},
+		{AgentName: "billing", Count: 4000, SampleIDs: []string{"billing0", "billing1", "billing2"}},
+		{AgentName: PruneOtherAgents, Count: 1000, SampleIDs: []string{}},
+	}
+	if plan.Total != 25000 || !reflect.DeepEqual(plan.Agents, want) {
+		t.Errorf("plan = %+v, want 25000 in %+v", plan, want)
+	}
+	if len(fc.searchStarts) != 3 {
+		t.Errorf("made %d searches, want one overall and one per agent", len(fc.searchStarts))
+	}
+}
+
+func TestPlanPruneFilters(t *testing.T) {
+	fc := &fakeClient{}
+	svc := NewService(fc)
+	if _, err := svc.PlanPrune(context.Background(), PruneRequest{AgentName: "triage", Status: "failed"}, time.Now()); err != nil {
+		t.Fatalf("PlanPrune: %v", err)
+	}
+	if got := fc.lastSearch; got.AgentName != "triage" || got.Status != "FAILED" || got.Query != "" {
+		t.Errorf("search filter = %+v, want agent triage with status FAILED", got)
+	}
+	if _, err := svc.PlanPrune(context.Background(), PruneRequest{Status: "RUNNING"}, time.Now()); err == nil {
+		t.Error("PlanPrune accepted a non-terminal status")
+	}
+	if _, err := svc.Prune(context.Background(), PruneRequest{OlderThanDays: 7, AgentName: "triage"}); err == nil {
+		t.Error("Prune accepted an agent filter the server cannot apply")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 )
 
 // frameworkSkill is the framework marker inferred for skill-backed agent definitions.
@@ -32,6 +33,23 @@ type EventSink interface {
 	OnEvent(SSEEvent) error
 }
 
+// MultiSink fans each event out to every sink in order. The first error stops the
+// fan-out and is returned, ending the stream as any sink error does.
+func MultiSink(sinks ...EventSink) EventSink {
+	return multiSink(sinks)
+}
+
+type multiSink []EventSink
+
+func (m multiSink) OnEvent(e SSEEvent) error {
+	for _, s := range m {
+		if err := s.OnEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Service is the agent use-case layer. It depends only on Client and is free of
 // presentation and transport concerns.
 type Service interface {
@@ -44,19 +62,73 @@ type Service interface {
 	Delete(ctx context.Context, name string, version *int) error
 	Compile(ctx context.Context, def json.RawMessage) (json.RawMessage, error)
 	SearchExecutions(ctx context.Context, filter ExecutionFilter) (ExecutionPage, error)
+	EachExecution(ctx context.Context, filter ExecutionFilter, visit func(ExecutionSummary) error) error
 	GetExecution(ctx context.Context, id string) (json.RawMessage, error)
 	Status(ctx context.Context, id string) (json.RawMessage, error)
 	Respond(ctx context.Context, id string, resp HumanResponse) error
 	Prune(ctx context.Context, req PruneRequest) (PruneResult, error)
+	PlanPrune(ctx context.Context, req PruneRequest, now time.Time) (PrunePlan, error)
+	Versions(ctx context.Context, name string) ([]AgentVersion, error)
+	Rollback(ctx context.Context, name string, version int) (DeployResult, error)
+	Publish(ctx context.Context, def json.RawMessage) (DeployResult, error)
+	PlanDeploy(ctx context.Context, name string, def json.RawMessage) (DeployPlan, error)
+}
+
+// ReconnectPolicy bounds how StreamExecution reopens a dropped stream. MaxAttempts
+// is the number of consecutive reconnects allowed without receiving an event; zero
+// disables reconnecting. The delay doubles per attempt from InitialDelay (or the
+// server's "retry:" value) up to MaxDelay.
+type ReconnectPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// DefaultReconnectPolicy rides out a couple of minutes of lost connectivity.
+var DefaultReconnectPolicy = ReconnectPolicy{
+	MaxAttempts:  8,
+	InitialDelay: time.Second,
+	MaxDelay:     30 * time.Second,
+}
+
+// delay is the wait before reconnect number attempt (0-based).
+func (p ReconnectPolicy) delay(attempt int, serverRetry time.Duration) time.Duration {
+	d, ceiling := p.InitialDelay, p.MaxDelay
+	if serverRetry > 0 {
+		d, ceiling = serverRetry, max(ceiling, serverRetry)
+	}
+	for i := 0; i < attempt && d < ceiling; i++ {
+		d *= 2
+	}
+	return min(d, ceiling)
 }
 
+// errStreamClosed is the reconnect failure when the server kept closing the stream
+// without an error and without the execution finishing.
+var errStreamClosed = errors.New("stream closed before the execution finished")
+
 // NewService returns a Service backed by the given Client.
 func NewService(c Client) Service {
-	return &service{client: c}
+	return &service{client: c, reconnect: DefaultReconnectPolicy, wait: SleepContext}
 }
 
 type service struct {
-	client Client
+	client    Client
+	reconnect ReconnectPolicy
+	// wait pauses between reconnects; tests swap it to run without real delays.
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+// SleepContext waits for d or until ctx is done, returning ctx.Err() in that case.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (s *service) CheckSupported(ctx context.Context) error {
@@ -94,24 +166,98 @@ func (s *service) Deploy(ctx context.Context, framework string, rawConfig json.R
 // sends heartbeats, so waiting for the body to end hangs forever. Ending it is a
 // cancellation like any other, which is also what releases the client's producer
 // goroutine from a send this loop is no longer reading.
+//
+// A connection that drops before the terminal event is reopened from the last event
+// id seen, after a backoff that starts at the server's "retry:" delay when it sent
+// one. The budget counts consecutive reconnects that delivered nothing, so a long run
+// over a flaky link survives any number of drops as long as each one makes progress.
 func (s *service) StreamExecution(ctx context.Context, executionID, lastEventID string, sink EventSink) error {
+	cur := streamCursor{lastEventID: lastEventID}
+	failures := 0
+	for {
+		received := cur.received
+		done, err := s.streamOnce(ctx, executionID, &cur, sink)
+		if done {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+		if cur.received > received {
+			failures = 0
+		}
+		if s.reconnect.MaxAttempts == 0 {
+			// Without reconnects, a clean close ends the stream as it always has.
+			return err
+		}
+		if failures >= s.reconnect.MaxAttempts {
+			if err == nil {
+				err = errStreamClosed
+			}
+			if failures == 0 {
+				return err
+			}
+			return fmt.Errorf("stream lost after %d reconnect attempt(s): %w", failures, err)
+		}
+		delay := s.reconnect.delay(failures, cur.retry)
+		failures++
+		if s.wait(ctx, delay) != nil {
+			return nil
+		}
+	}
+}
+
+// streamCursor is what survives a reconnect: where to resume and how long to wait.
+type streamCursor struct {
+	lastEventID string
+	retry       time.Duration
+	received    int
+}
+
+// streamOnce runs one connection. done reports that the stream is over — a terminal
+// event, a sink error or a cancellation — and err is then the result to return;
+// otherwise err is why the connection ended (nil for a close) and a reconnect may
+// pick up where it left off.
+func (s *service) streamOnce(ctx context.Context, executionID string, cur *streamCursor, sink EventSink) (done bool, err error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	events, errc := s.client.Stream(ctx, executionID, lastEventID)
+	events, errc := s.client.Stream(ctx, executionID, cur.lastEventID)
+	terminal := false
 	for evt := range events {
+		if evt.Retry > 0 {
+			cur.retry = evt.Retry
+		}
+		if evt.ID != "" {
+			cur.lastEventID = evt.ID
+		}
+		if evt.retryOnly() {
+			continue
+		}
+		cur.received++
 		if err := sink.OnEvent(evt); err != nil {
-			return err
+			cancel()
+			<-errc
+			return true, err
 		}
 		if evt.ResolvedType().IsTerminal() {
+			terminal = true
 			cancel()
 			break
 		}
 	}
-	if err := <-errc; err != nil && !errors.Is(err, context.Canceled) {
-		return err
+	err = <-errc
+	if terminal {
+		return true, nil
 	}
-	return nil
+	if errors.Is(err, context.Canceled) {
+		return ctx.Err() != nil, nil
+	}
+	return false, err
 }
 
 func (s *service) List(ctx context.Context) ([]AgentSummary, error) {
@@ -134,6 +280,36 @@ func (s *service) SearchExecutions(ctx context.Context, filter ExecutionFilter)
 	return s.client.SearchExecutions(ctx, filter)
 }
 
+// EachExecution pages through every execution matching filter, filter.Size at a
+// time from filter.Start, and calls visit for each in order. Executions that start
+// while it pages shift later pages; one seen twice that way is visited once. An
+// error from visit stops the walk and is returned.
+func (s *service) EachExecution(ctx context.Context, filter ExecutionFilter, visit func(ExecutionSummary) error) error {
+	if filter.Size <= 0 {
+		return fmt.Errorf("page size must be positive, got %d", filter.Size)
+	}
+	seen := map[string]bool{}
+	for {
+		page, err := s.client.SearchExecutions(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, e := range page.Results {
+			if seen[e.ExecutionID] {
+				continue
+			}
+			seen[e.ExecutionID] = true
+			if err := visit(e); err != nil {
+				return err
+			}
+		}
+		filter.Start += filter.Size
+		if int64(filter.Start) >= page.TotalHits {
+			return nil
+		}
+	}
+}
+
 func (s *service) GetExecution(ctx context.Context, id string) (json.RawMessage, error) {
 	return s.client.GetExecution(ctx, id)
 }
@@ -147,9 +323,62 @@ func (s *service) Respond(ctx context.Context, id string, resp HumanResponse) er
 }
 
 func (s *service) Prune(ctx context.Context, req PruneRequest) (PruneResult, error) {
+	if req.AgentName != "" || req.Status != "" {
+		return PruneResult{}, errors.New("prune cannot be narrowed by agent or status; the server removes every agent's terminal executions")
+	}
 	return s.client.Prune(ctx, req)
 }
 
+// Versions lists an agent's stored versions, newest first. The server has no
+// version listing, so it reads the latest and then fetches each earlier version by
+// number; numbers whose version was deleted are skipped.
+func (s *service) Versions(ctx context.Context, name string) ([]AgentVersion, error) {
+	latest, err := s.client.Get(ctx, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	head := newAgentVersion(latest)
+	head.Latest = true
+	versions := []AgentVersion{head}
+	for v := head.Version - 1; v >= 1; v-- {
+		def, err := s.client.Get(ctx, name, &v)
+		if IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get version %d: %w", v, err)
+		}
+		av := newAgentVersion(def)
+		if av.Version == 0 {
+			av.Version = v
+		}
+		versions = append(versions, av)
+	}
+	return versions, nil
+}
+
+// Rollback re-deploys a stored version as the new latest. The old definition is
+// deployed as-is apart from its server-managed fields, through the envelope of the
+// framework it declares.
+func (s *service) Rollback(ctx context.Context, name string, version int) (DeployResult, error) {
+	def, err := s.client.Get(ctx, name, &version)
+	if err != nil {
+		return DeployResult{}, fmt.Errorf("get version %d: %w", version, err)
+	}
+	return s.Publish(ctx, def)
+}
+
+// Publish deploys a stored definition, such as one fetched from another server, as
+// the agent's new latest version. Server-managed keys are dropped for the server
+// to assign afresh.
+func (s *service) Publish(ctx context.Context, def json.RawMessage) (DeployResult, error) {
+	def, err := publishableDefinition(def)
+	if err != nil {
+		return DeployResult{}, err
+	}
+	return s.client.Deploy(ctx, detectFramework(def), def)
+}
+
 // detectFramework returns the stored framework marker on an agent definition, or ""
 // for a native agent. Framework agents (skill/openai/…) start via a different envelope.
 func detectFramework(def json.RawMessage) string {
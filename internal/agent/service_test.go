@@ -17,14 +17,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/transport"
 )
 
 // fakeClient records calls and returns canned values; it implements Client so the
 // service can be tested in isolation.
 type fakeClient struct {
 	getDef        json.RawMessage
+	getErr        error
 	getCalled     bool
 	lastRun       RunRequest
 	lastDeployFwk string
@@ -39,6 +45,16 @@ type fakeClient struct {
 	// was not stranded on a send.
 	streamDone        chan struct{}
 	lastStreamEventID string
+	// versions, when set, answers Get calls for a specific version; a missing
+	// number is a 404.
+	versions map[int]json.RawMessage
+	// pages answers SearchExecutions by page number (Start/Size); searchStarts
+	// records the Start of each call and lastSearch the latest filter.
+	pages        []ExecutionPage
+	searchStarts []int
+	lastSearch   ExecutionFilter
+	// search, when set, answers SearchExecutions instead of pages.
+	search func(ExecutionFilter) ExecutionPage
 }
 
 func (f *fakeClient) CheckSupported(ctx context.Context) error { return nil }
@@ -86,7 +102,14 @@ func (f *fakeClient) Stream(ctx context.Context, id, lastEventID string) (<-chan
 
 func (f *fakeClient) Get(ctx context.Context, name string, version *int) (json.RawMessage, error) {
 	f.getCalled = true
-	return f.getDef, nil
+	if version != nil && f.versions != nil {
+		def, ok := f.versions[*version]
+		if !ok {
+			return nil, &transport.APIError{Status: http.StatusNotFound, Message: "not found"}
+		}
+		return def, nil
+	}
+	return f.getDef, f.getErr
 }
 
 func (f *fakeClient) List(ctx context.Context) ([]AgentSummary, error)      { return nil, nil }
@@ -94,7 +117,15 @@ func (f *fakeClient) Delete(ctx context.Context, name string, v *int) error { re
 func (f *fakeClient) Compile(ctx context.Context, d json.RawMessage) (json.RawMessage, error) {
 	return nil, nil
 }
-func (f *fakeClient) SearchExecutions(ctx context.Context, _ ExecutionFilter) (ExecutionPage, error) {
+func (f *fakeClient) SearchExecutions(ctx context.Context, filter ExecutionFilter) (ExecutionPage, error) {
+	f.searchStarts = append(f.searchStarts, filter.Start)
+	f.lastSearch = filter
+	if f.search != nil {
+		return f.search(filter), nil
+	}
+	if filter.Size > 0 && filter.Start/filter.Size < len(f.pages) {
+		return f.pages[filter.Start/filter.Size], nil
+	}
 	return ExecutionPage{}, nil
 }
 func (f *fakeClient) GetExecution(ctx context.Context, id string) (json.RawMessage, error) {
@@ -232,7 +263,182 @@ func TestStreamExecutionPassesLastEventID(t *testing.T) {
 
 func TestStreamExecutionReturnsRealError(t *testing.T) {
 	fc := &fakeClient{streamErr: errors.New("boom")}
-	if err := NewService(fc).StreamExecution(context.Background(), "exec-1", "", &recordingSink{}); err == nil {
+	if err := newTestService(fc, 2, nil).StreamExecution(context.Background(), "exec-1", "", &recordingSink{}); err == nil {
 		t.Fatal("expected the terminal stream error to surface")
 	}
 }
+
+// newTestService returns a service whose reconnects record their delay instead of
+// sleeping.
+func newTestService(c Client, attempts int, delays *[]time.Duration) *service {
+	p := DefaultReconnectPolicy
+	p.MaxAttempts = attempts
+	return &service{client: c, reconnect: p, wait: func(_ context.Context, d time.Duration) error {
+		if delays != nil {
+			*delays = append(*delays, d)
+		}
+		return nil
+	}}
+}
+
+type scriptedStream struct {
+	events []SSEEvent
+	err    error
+}
+
+// reconnectClient plays one scripted connection per Stream call, repeating the last
+// one once the script runs out, and records the Last-Event-ID of each call.
+type reconnectClient struct {
+	fakeClient
+	script       []scriptedStream
+	lastEventIDs []string
+}
+
+func (c *reconnectClient) Stream(ctx context.Context, id, lastEventID string) (<-chan SSEEvent, <-chan error) {
+	step := c.script[min(len(c.lastEventIDs), len(c.script)-1)]
+	c.lastEventIDs = append(c.lastEventIDs, lastEventID)
+	c.streamEvents, c.streamErr = step.events, step.err
+	return c.fakeClient.Stream(ctx, id, lastEventID)
+}
+
+func TestStreamExecutionReconnectsFromLastEventID(t *testing.T) {
+	rc := &reconnectClient{script: []scriptedStream{
+		{events: []SSEEvent{{ID: "1", Type: EventMessage}, {Retry: 5 * time.Second}}, err: io.ErrUnexpectedEOF},
+		{events: []SSEEvent{{ID: "2", Type: EventDone}}},
+	}}
+	var delays []time.Duration
+	sink := &recordingSink{}
+	if err := newTestService(rc, 3, &delays).StreamExecution(context.Background(), "exec-1", "", sink); err != nil {
+		t.Fatalf("StreamExecution: %v", err)
+	}
+	if len(sink.events) != 2 || sink.events[1].Type != EventDone {
+		t.Errorf("sink saw %+v, want the message and done without the retry record", sink.events)
+	}
+	if !reflect.DeepEqual(rc.lastEventIDs, []string{"", "1"}) {
+		t.Errorf("Last-Event-IDs = %q, want a resume from 1", rc.lastEventIDs)
+	}
+	if !reflect.DeepEqual(delays, []time.Duration{5 * time.Second}) {
+		t.Errorf("delays = %v, want the server's retry value", delays)
+	}
+}
+
+func TestStreamExecutionReconnectBudgetCountsOnlyFruitlessAttempts(t *testing.T) {
+	drop := errors.New("connection reset")
+	rc := &reconnectClient{script: []scriptedStream{
+		{events: []SSEEvent{{ID: "1", Type: EventMessage}}, err: drop},
+		{events: []SSEEvent{{ID: "2", Type: EventMessage}}},
+		{err: drop},
+		{events: []SSEEvent{{ID: "3", Type: EventDone}}},
+	}}
+	if err := newTestService(rc, 2, nil).StreamExecution(context.Background(), "exec-1", "", &recordingSink{}); err != nil {
+		t.Fatalf("StreamExecution: %v; each drop after progress should get a fresh budget", err)
+	}
+
+	rc = &reconnectClient{script: []scriptedStream{{err: drop}}}
+	var delays []time.Duration
+	err := newTestService(rc, 3, &delays).StreamExecution(context.Background(), "exec-1", "", &recordingSink{})
+	if !errors.Is(err, drop) {
+		t.Fatalf("StreamExecution = %v, want the drop once the budget is spent", err)
+	}
+	if len(rc.lastEventIDs) != 4 || !reflect.DeepEqual(delays, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}) {
+		t.Errorf("calls=%d delays=%v, want 1 try + 3 reconnects backing off from 1s", len(rc.lastEventIDs), delays)
+	}
+}
+
+func TestStreamExecutionWithoutReconnectsEndsOnCleanClose(t *testing.T) {
+	rc := &reconnectClient{script: []scriptedStream{{events: []SSEEvent{{ID: "1", Type: EventMessage}}}}}
+	sink := &recordingSink{}
+	if err := newTestService(rc, 0, nil).StreamExecution(context.Background(), "exec-1", "", sink); err != nil {
+		t.Fatalf("StreamExecution = %v, want nil for a clean close with reconnecting disabled", err)
+	}
+	if len(rc.lastEventIDs) != 1 || len(sink.events) != 1 {
+		t.Errorf("calls=%d events=%d, want one connection and its event", len(rc.lastEventIDs), len(sink.events))
+	}
+}
+
+func TestStreamExecutionDoesNotRetryPermanentErrors(t *testing.T) {
+	notFound := errors.New("execution not found")
+	rc := &reconnectClient{script: []scriptedStream{{err: &permanentError{err: notFound}}}}
+	err := newTestService(rc, 3, nil).StreamExecution(context.Background(), "exec-1", "", &recordingSink{})
+	if err != notFound || len(rc.lastEventIDs) != 1 {
+		t.Errorf("err=%v calls=%d, want the unwrapped error after one call", err, len(rc.lastEventIDs))
+	}
+}
+
+func TestReconnectDelay(t *testing.T) {
+	p := ReconnectPolicy{InitialDelay: time.Second, MaxDelay: 10 * time.Second}
+	for _, tt := range []struct {
+		attempt int
+		server  time.Duration
+		want    time.Duration
+	}{
+		{0, 0, time.Second},
+		{3, 0, 8 * time.Second},
+		{4, 0, 10 * time.Second},
+		{1, 3 * time.Second, 6 * time.Second},
+		{0, 20 * time.Second, 20 * time.Second},
+	} {
+		if got := p.delay(tt.attempt, tt.server); got != tt.want {
+			t.Errorf("delay(%d, %s) = %s, want %s", tt.attempt, tt.server, got, tt.want)
+		}
+	}
+}
+
+type failingSink struct{ err error }
+
+func (f failingSink) OnEvent(SSEEvent) error { return f.err }
+
+func TestMultiSinkFansOutInOrderAndStopsOnError(t *testing.T) {
+	first, last := &recordingSink{}, &recordingSink{}
+	if err := MultiSink(first, last).OnEvent(SSEEvent{Type: EventMessage}); err != nil {
+		t.Fatalf("OnEvent: %v", err)
+	}
+	if len(first.events) != 1 || len(last.events) != 1 {
+		t.Fatalf("sinks saw %d and %d events, want 1 each", len(first.events), len(last.events))
+	}
+
+	boom := errors.New("boom")
+	skipped := &recordingSink{}
+	if err := MultiSink(failingSink{boom}, skipped).OnEvent(SSEEvent{Type: EventMessage}); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if len(skipped.events) != 0 {
+		t.Error("a sink after the failing one still saw the event")
+	}
+}
+
+func TestEachExecutionWalksEveryPageOnce(t *testing.T) {
+	page := func(ids ...string) ExecutionPage {
+		p := ExecutionPage{TotalHits: 5}
+		for _, id := range ids {
+			p.Results = append(p.Results, ExecutionSummary{ExecutionID: id})
+		}
+		return p
+	}
+	// A new execution arrived between the first and second page, shifting e2 onto
+	// the second page too.
+	fc := &fakeClient{pages: []ExecutionPage{page("e1", "e2"), page("e2", "e3"), page("e4")}}
+	var got []string
+	err := NewService(fc).EachExecution(context.Background(), ExecutionFilter{Size: 2}, func(e ExecutionSummary) error {
+		got = append(got, e.ExecutionID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachExecution: %v", err)
+	}
+	if want := []string{"e1", "e2", "e3", "e4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visited %v, want %v", got, want)
+	}
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(fc.searchStarts, want) {
+		t.Errorf("page starts = %v, want %v", fc.searchStarts, want)
+	}
+
+	stop := errors.New("stop")
+	fc = &fakeClient{pages: []ExecutionPage{page("e1", "e2"), page("e3", "e4")}}
+	if err := NewService(fc).EachExecution(context.Background(), ExecutionFilter{Size: 2}, func(ExecutionSummary) error { return stop }); err != stop {
+		t.Errorf("err = %v, want the visitor's error", err)
+	}
+	if len(fc.searchStarts) != 1 {
+		t.Errorf("fetched %d pages after the visitor stopped, want 1", len(fc.searchStarts))
+	}
+}
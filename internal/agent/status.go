@@ -0,0 +1,304 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TaskKind groups an execution's tasks by the part they play in an agent run.
+type TaskKind string
+
+const (
+	TaskLLM      TaskKind = "llm"      // a model call: one turn
+	TaskTool     TaskKind = "tool"     // a tool the model called
+	TaskHuman    TaskKind = "human"    // a human-in-the-loop task
+	TaskSubAgent TaskKind = "subagent" // a sub-agent, run as its own execution
+	TaskControl  TaskKind = "control"  // workflow plumbing: loops, switches, joins
+)
+
+// Conductor task types the kinds are read from; any other type is a tool.
+var taskKinds = map[string]TaskKind{
+	"LLM_CHAT_COMPLETE":  TaskLLM,
+	"LLM_TEXT_COMPLETE":  TaskLLM,
+	"HUMAN":              TaskHuman,
+	"WAIT":               TaskHuman,
+	"SUB_WORKFLOW":       TaskSubAgent,
+	"DO_WHILE":           TaskControl,
+	"SWITCH":             TaskControl,
+	"DECISION":           TaskControl,
+	"FORK_JOIN":          TaskControl,
+	"FORK_JOIN_DYNAMIC":  TaskControl,
+	"JOIN":               TaskControl,
+	"SET_VARIABLE":       TaskControl,
+	"INLINE":             TaskControl,
+	"JSON_JQ_TRANSFORM":  TaskControl,
+	"TERMINATE":          TaskControl,
+	"EXCLUSIVE_JOIN":     TaskControl,
+	"START_WORKFLOW":     TaskControl,
+	"DYNAMIC":            TaskControl,
+	"GET_WORKFLOW_INPUT": TaskControl,
+}
+
+// Task statuses that mean a task has not finished.
+var openTaskStatuses = map[string]bool{"SCHEDULED": true, "IN_PROGRESS": true}
+
+// TokenUsage counts model tokens; zero values mean the server did not report them.
+type TokenUsage struct {
+	Prompt     int64 `json:"promptTokens"`
+	Completion int64 `json:"completionTokens"`
+	Total      int64 `json:"totalTokens"`
+}
+
+func (u TokenUsage) add(o TokenUsage) TokenUsage {
+	return TokenUsage{Prompt: u.Prompt + o.Prompt, Completion: u.Completion + o.Completion, Total: u.Total + o.Total}
+}
+
+// IsZero reports whether no usage was reported.
+func (u TokenUsage) IsZero() bool { return u == TokenUsage{} }
+
+// ExecutionStatus is an execution's status detail, decoded for display. Fields the
+// server did not send stay zero.
+type ExecutionStatus struct {
+	ExecutionID string
+	AgentName   string
+	Status      string
+	Start, End  time.Time
+	Prompt      string
+	Output      RawValue
+	Reason      string
+	Usage       TokenUsage
+	Tasks       []StatusTask
+}
+
+// StatusTask is one task of an execution.
+type StatusTask struct {
+	Name           string
+	Type           string
+	Kind           TaskKind
+	Status         string
+	Start, End     time.Time
+	SubExecutionID string
+	Output         RawValue
+	Usage          TokenUsage
+}
+
+// Open reports whether the task has not finished.
+func (t StatusTask) Open() bool { return openTaskStatuses[t.Status] }
+
+// Duration is how long the task ran, up to now when it has not finished; zero when
+// it never started.
+func (t StatusTask) Duration(now time.Time) time.Duration {
+	return span(t.Start, t.End, now)
+}
+
+// Duration is how long the execution has run, up to now when it has not finished.
+func (s ExecutionStatus) Duration(now time.Time) time.Duration {
+	return span(s.Start, s.End, now)
+}
+
+func span(start, end, now time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+	if end.IsZero() {
+		end = now
+	}
+	return max(end.Sub(start), 0)
+}
+
+// Turn is one model call and the tools called on its answer.
+type Turn struct {
+	LLM   StatusTask
+	Tools []StatusTask
+}
+
+// Turns groups the tool and human tasks after each model call under it. Tasks
+// before the first model call open a turn without one.
+func (s ExecutionStatus) Turns() []Turn {
+	var turns []Turn
+	for _, t := range s.Tasks {
+		switch t.Kind {
+		case TaskLLM:
+			turns = append(turns, Turn{LLM: t})
+		case TaskTool, TaskHuman, TaskSubAgent:
+			if len(turns) == 0 {
+				turns = append(turns, Turn{})
+			}
+			turns[len(turns)-1].Tools = append(turns[len(turns)-1].Tools, t)
+		}
+	}
+	return turns
+}
+
+// Pending returns the human tasks still waiting for an answer.
+func (s ExecutionStatus) Pending() []StatusTask {
+	var out []StatusTask
+	for _, t := range s.Tasks {
+		if t.Kind == TaskHuman && t.Open() {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// statusWire is the status detail as the server sends it. It accepts the names
+// the agent endpoints use and the workflow names they are derived from.
+type statusWire struct {
+	ExecutionID           string                  `json:"executionId"`
+	WorkflowID            string                  `json:"workflowId"`
+	AgentName             string                  `json:"agentName"`
+	WorkflowName          string                  `json:"workflowName"`
+	WorkflowType          string                  `json:"workflowType"`
+	Status                string                  `json:"status"`
+	StartTime             json.RawMessage         `json:"startTime"`
+	EndTime               json.RawMessage         `json:"endTime"`
+	Input                 struct{ Prompt string } `json:"input"`
+	Prompt                string                  `json:"prompt"`
+	Output                RawValue                `json:"output"`
+	ReasonForIncompletion string                  `json:"reasonForIncompletion"`
+	TokenUsage            *usageWire              `json:"tokenUsage"`
+	Tasks                 []taskWire              `json:"tasks"`
+}
+
+type taskWire struct {
+	TaskType          string          `json:"taskType"`
+	ReferenceTaskName string          `json:"referenceTaskName"`
+	TaskDefName       string          `json:"taskDefName"`
+	Status            string          `json:"status"`
+	StartTime         json.RawMessage `json:"startTime"`
+	EndTime           json.RawMessage `json:"endTime"`
+	SubWorkflowID     string          `json:"subWorkflowId"`
+	OutputData        json.RawMessage `json:"outputData"`
+}
+
+// usageWire reads token counts under the names the server and LLM tasks use.
+type usageWire struct {
+	PromptTokens     int64 `json:"promptTokens"`
+	CompletionTokens int64 `json:"completionTokens"`
+	TotalTokens      int64 `json:"totalTokens"`
+	TokenUsed        int64 `json:"tokenUsed"`
+}
+
+func (w usageWire) usage() TokenUsage {
+	u := TokenUsage{Prompt: w.PromptTokens, Completion: w.CompletionTokens, Total: w.TotalTokens}
+	if u.Total == 0 {
+		u.Total = w.TokenUsed
+	}
+	if u.Total == 0 {
+		u.Total = u.Prompt + u.Completion
+	}
+	return u
+}
+
+// ParseStatus decodes a status detail. Tasks come back in start order; token usage
+// is the execution's own figure or, failing that, the sum over its model calls.
+func ParseStatus(raw json.RawMessage) (ExecutionStatus, error) {
+	var w statusWire
+	if err := json.Unmarshal(raw, &w); err != nil {
+		return ExecutionStatus{}, fmt.Errorf("decode status: %w", err)
+	}
+	s := ExecutionStatus{
+		ExecutionID: firstNonEmpty(w.ExecutionID, w.WorkflowID),
+		AgentName:   firstNonEmpty(w.AgentName, w.WorkflowName, w.WorkflowType),
+		Status:      w.Status,
+		Start:       parseTime(w.StartTime),
+		End:         parseTime(w.EndTime),
+		Prompt:      firstNonEmpty(w.Prompt, w.Input.Prompt),
+		Output:      w.Output,
+		Reason:      w.ReasonForIncompletion,
+	}
+	var taskUsage TokenUsage
+	for _, tw := range w.Tasks {
+		t := StatusTask{
+			Name:           firstNonEmpty(tw.ReferenceTaskName, tw.TaskDefName, tw.TaskType),
+			Type:           tw.TaskType,
+			Kind:           taskKind(tw.TaskType),
+			Status:         tw.Status,
+			Start:          parseTime(tw.StartTime),
+			End:            parseTime(tw.EndTime),
+			SubExecutionID: tw.SubWorkflowID,
+		}
+		if len(tw.OutputData) > 0 {
+			var out struct {
+				Result RawValue `json:"result"`
+				usageWire
+			}
+			if json.Unmarshal(tw.OutputData, &out) == nil {
+				t.Output = out.Result
+				t.Usage = out.usage()
+			}
+		}
+		taskUsage = taskUsage.add(t.Usage)
+		s.Tasks = append(s.Tasks, t)
+	}
+	sort.SliceStable(s.Tasks, func(i, j int) bool {
+		a, b := s.Tasks[i].Start, s.Tasks[j].Start
+		return !a.IsZero() && (b.IsZero() || a.Before(b))
+	})
+	if w.TokenUsage != nil {
+		s.Usage = w.TokenUsage.usage()
+	} else {
+		s.Usage = taskUsage
+	}
+	return s, nil
+}
+
+func taskKind(taskType string) TaskKind {
+	if k, ok := taskKinds[taskType]; ok {
+		return k
+	}
+	return TaskTool
+}
+
+// parseTime reads a timestamp sent as epoch milliseconds (a number or a numeric
+// string) or RFC 3339; zero and anything unreadable are the zero time.
+func parseTime(raw json.RawMessage) time.Time {
+	if len(raw) == 0 {
+		return time.Time{}
+	}
+	var ms int64
+	if json.Unmarshal(raw, &ms) == nil {
+		return epochMillis(ms)
+	}
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		return time.Time{}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return epochMillis(n)
+	}
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+func epochMillis(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
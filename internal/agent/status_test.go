@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const statusDetail = `{
+  "workflowId": "e1", "workflowName": "triage", "status": "RUNNING",
+  "startTime": 1000, "input": {"prompt": "where is my order"},
+  "tasks": [
+    {"taskType": "SIMPLE", "referenceTaskName": "lookup_order", "status": "COMPLETED", "startTime": 2500, "endTime": 3000},
+    {"taskType": "LLM_CHAT_COMPLETE", "referenceTaskName": "call_llm", "status": "COMPLETED", "startTime": 1000, "endTime": 2400,
+     "outputData": {"promptTokens": 100, "completionTokens": 20}},
+    {"taskType": "DO_WHILE", "referenceTaskName": "loop", "status": "IN_PROGRESS", "startTime": 1000},
+    {"taskType": "HUMAN", "referenceTaskName": "approve_refund", "status": "IN_PROGRESS", "startTime": 3100},
+    {"taskType": "LLM_CHAT_COMPLETE", "referenceTaskName": "call_llm_2", "status": "SCHEDULED",
+     "outputData": {"tokenUsed": 7}}
+  ]
+}`
+
+func TestParseStatus(t *testing.T) {
+	st, err := ParseStatus(json.RawMessage(statusDetail))
+	if err != nil {
+		t.Fatalf("ParseStatus: %v", err)
+	}
+	if st.ExecutionID != "e1" || st.AgentName != "triage" || st.Prompt != "where is my order" || !st.Start.Equal(time.UnixMilli(1000)) {
+		t.Errorf("header = %+v", st)
+	}
+	if st.Usage != (TokenUsage{Prompt: 100, Completion: 20, Total: 127}) {
+		t.Errorf("usage = %+v, want the sum over model calls", st.Usage)
+	}
+	if st.Tasks[0].Name != "call_llm" || st.Tasks[len(st.Tasks)-1].Name != "call_llm_2" {
+		t.Errorf("tasks not in start order, unstarted last: %+v", st.Tasks)
+	}
+
+	turns := st.Turns()
+	if len(turns) != 2 || len(turns[0].Tools) != 2 || turns[0].Tools[0].Name != "lookup_order" || turns[1].LLM.Name != "call_llm_2" {
+		t.Errorf("turns = %+v", turns)
+	}
+	if p := st.Pending(); len(p) != 1 || p[0].Name != "approve_refund" {
+		t.Errorf("pending = %+v", p)
+	}
+	if d := st.Tasks[0].Duration(time.UnixMilli(9000)); d != 1400*time.Millisecond {
+		t.Errorf("duration = %s", d)
+	}
+
+	withUsage, _ := ParseStatus(json.RawMessage(`{"tokenUsage": {"totalTokens": 5}, "startTime": "2026-01-02T03:04:05Z"}`))
+	if withUsage.Usage.Total != 5 || withUsage.Start.IsZero() {
+		t.Errorf("execution usage / RFC 3339 time not read: %+v", withUsage)
+	}
+}
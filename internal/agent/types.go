@@ -32,11 +32,14 @@ type RunRequest struct {
 	SessionID  string
 }
 
-// Execution identifies a started or running agent execution.
+// Execution identifies a started or running agent execution. RequiredWorkers, as
+// on DeployResult, lists the tool task types a framework agent needs served; the
+// server reports it only when starting one.
 type Execution struct {
-	ID        string
-	AgentName string
-	Status    string
+	ID              string
+	AgentName       string
+	Status          string
+	RequiredWorkers []string
 }
 
 // DeployResult reports the outcome of publishing (deploying) an agent definition
@@ -57,12 +60,18 @@ type AgentSummary struct {
 }
 
 // ExecutionFilter narrows an execution search. Start/Size paginate.
-// StartTimeFrom/StartTimeTo are epoch milliseconds; zero means unbounded.
+// StartTimeFrom/StartTimeTo are epoch milliseconds; zero means unbounded. Query is
+// a Conductor search expression ANDed with the other filters; FreeText searches
+// execution contents ("" matches everything). Sort is "field:ASC" or "field:DESC";
+// "" sorts newest first.
 type ExecutionFilter struct {
 	AgentName     string
 	Status        string
 	StartTimeFrom int64
 	StartTimeTo   int64
+	Query         string
+	FreeText      string
+	Sort          string
 	Start         int
 	Size          int
 }
@@ -85,9 +94,13 @@ type ExecutionSummary struct {
 }
 
 // PruneRequest selects terminal executions to delete (or archive) by age.
+// AgentName and Status narrow PlanPrune only: the prune endpoint removes every
+// agent's terminal executions, so Prune rejects a request that sets them.
 type PruneRequest struct {
 	OlderThanDays int
 	Archive       bool
+	AgentName     string
+	Status        string
 }
 
 // PruneResult reports how many execution records were removed.
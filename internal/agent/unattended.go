@@ -0,0 +1,108 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultConcurrency is how many executions an unattended run starts at once when
+// the caller does not say.
+const DefaultConcurrency = 4
+
+// RunEach calls run for items 0..n-1, at most concurrency at a time
+// (DefaultConcurrency when it is not positive), and returns the results in item
+// order. done, when set, gets each result as it finishes, in completion order and
+// never concurrently.
+func RunEach[R any](n, concurrency int, run func(i int) R, done func(R)) []R {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	results := make([]R, n)
+	sem := make(chan struct{}, concurrency)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			res := run(i)
+			results[i] = res
+			if done != nil {
+				mu.Lock()
+				done(res)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// ErrNeedsHuman ends a stream that is waiting for human input an AutoApprover
+// will not give.
+var ErrNeedsHuman = errors.New("waiting for human input")
+
+// responder is the slice of Service an AutoApprover answers with.
+type responder interface {
+	Respond(ctx context.Context, id string, resp HumanResponse) error
+}
+
+// AutoApprover is an EventSink for an execution nobody is watching. It approves
+// the human-input requests for the tools it was given and ends the stream with
+// ErrNeedsHuman on any other request, or when an approval fails; Blocked then
+// says why.
+type AutoApprover struct {
+	ctx         context.Context
+	svc         responder
+	executionID string
+	approve     map[string]bool
+	reason      string
+	hint        string
+
+	Blocked string
+}
+
+// NewAutoApprover returns an AutoApprover for an execution that approves tools,
+// recording reason on each response. hint follows the description of a request it
+// leaves unanswered, to say how to answer it.
+func NewAutoApprover(ctx context.Context, svc responder, executionID string, tools []string, reason, hint string) *AutoApprover {
+	a := &AutoApprover{ctx: ctx, svc: svc, executionID: executionID, approve: map[string]bool{}, reason: reason, hint: hint}
+	for _, t := range tools {
+		a.approve[t] = true
+	}
+	return a
+}
+
+func (a *AutoApprover) OnEvent(e SSEEvent) error {
+	if e.ResolvedType() != EventWaiting {
+		return nil
+	}
+	tool := e.Payload().PendingToolName()
+	if !a.approve[tool] {
+		a.Blocked = fmt.Sprintf("waiting for human input on %q; %s", tool, a.hint)
+		return ErrNeedsHuman
+	}
+	if err := a.svc.Respond(a.ctx, a.executionID, HumanResponse{Approved: true, Reason: a.reason}); err != nil {
+		a.Blocked = fmt.Sprintf("approve %s: %v", tool, err)
+		return ErrNeedsHuman
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunEachKeepsItemOrder(t *testing.T) {
+	var done []string
+	got := RunEach(5, 2, func(i int) string { return fmt.Sprint(i) }, func(s string) { done = append(done, s) })
+	if !reflect.DeepEqual(got, []string{"0", "1", "2", "3", "4"}) || len(done) != 5 {
+		t.Errorf("RunEach = %q with %d reported, want every item in order", got, len(done))
+	}
+}
+
+type recordingResponder struct{ ids []string }
+
+func (r *recordingResponder) Respond(_ context.Context, id string, _ HumanResponse) error {
+	r.ids = append(r.ids, id)
+	return nil
+}
+
+func TestAutoApproverAnswersOnlyItsTools(t *testing.T) {
+	svc := &recordingResponder{}
+	a := NewAutoApprover(context.Background(), svc, "e1", []string{"lookup"}, "approved by test", "answer it yourself")
+	waiting := func(tool string) SSEEvent {
+		return SSEEvent{Type: EventWaiting, Data: []byte(`{"pendingTool":{"name":"` + tool + `"}}`)}
+	}
+	if err := a.OnEvent(waiting("lookup")); err != nil || len(svc.ids) != 1 {
+		t.Fatalf("allowlisted tool: err=%v responses=%v", err, svc.ids)
+	}
+	if err := a.OnEvent(waiting("refund")); !errors.Is(err, ErrNeedsHuman) || !strings.Contains(a.Blocked, `"refund"; answer it yourself`) {
+		t.Errorf("other tool: err=%v blocked=%q", err, a.Blocked)
+	}
+	if len(svc.ids) != 1 {
+		t.Errorf("responses = %v, want only the allowlisted tool answered", svc.ids)
+	}
+}
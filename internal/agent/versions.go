@@ -0,0 +1,178 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+// Definition keys the versioning commands read. The rest of a definition stays
+// opaque.
+const (
+	keyVersion      = "version"
+	keyModel        = "model"
+	keyInstructions = "instructions"
+	keyTools        = "tools"
+	keyGuardrails   = "guardrails"
+)
+
+// ServerManagedKeys are set by the server on every save of an agent: its version
+// and the keys it stamps on any definition. They differ between any two versions,
+// so a diff ignores them and Publish drops them for the server to assign afresh.
+var ServerManagedKeys = append([]string{keyVersion}, jsondiff.ServerManagedKeys...)
+
+// AgentVersion is one stored version of an agent definition.
+type AgentVersion struct {
+	Version    int             `json:"version"`
+	Latest     bool            `json:"latest"`
+	Model      string          `json:"model,omitempty"`
+	Tools      int             `json:"tools"`
+	Definition json.RawMessage `json:"definition"`
+}
+
+func newAgentVersion(def json.RawMessage) AgentVersion {
+	var probe struct {
+		Version int               `json:"version"`
+		Model   string            `json:"model"`
+		Tools   []json.RawMessage `json:"tools"`
+	}
+	_ = json.Unmarshal(def, &probe)
+	return AgentVersion{Version: probe.Version, Model: probe.Model, Tools: len(probe.Tools), Definition: def}
+}
+
+// publishableDefinition is def with its server-managed keys removed, ready to be
+// deployed as a new latest version.
+func publishableDefinition(def json.RawMessage) (json.RawMessage, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(def, &m); err != nil {
+		return nil, fmt.Errorf("agent definition is not a JSON object: %w", err)
+	}
+	for _, k := range ServerManagedKeys {
+		delete(m, k)
+	}
+	return json.Marshal(m)
+}
+
+// DiffDefinitions compares two agent definitions structurally: the model and
+// instructions as values, tools and guardrails entry by entry keyed on their name,
+// then any other top-level key. Server-managed keys are ignored. A change's Path is
+// a top-level key, or "tools[name]" / "guardrails[name]" for a list entry. Changes
+// come back with model, instructions, tools and guardrails first, the rest by key.
+func DiffDefinitions(from, to json.RawMessage) ([]jsondiff.Change, error) {
+	var a, b map[string]json.RawMessage
+	if err := json.Unmarshal(from, &a); err != nil {
+		return nil, fmt.Errorf("old definition is not a JSON object: %w", err)
+	}
+	if err := json.Unmarshal(to, &b); err != nil {
+		return nil, fmt.Errorf("new definition is not a JSON object: %w", err)
+	}
+	skip := map[string]bool{}
+	for _, k := range ServerManagedKeys {
+		skip[k] = true
+	}
+
+	var changes []jsondiff.Change
+	for _, k := range []string{keyModel, keyInstructions} {
+		changes = append(changes, diffValue(k, a[k], b[k])...)
+		skip[k] = true
+	}
+	for _, k := range []string{keyTools, keyGuardrails} {
+		changes = append(changes, diffNamedList(k, a[k], b[k])...)
+		skip[k] = true
+	}
+
+	var rest []string
+	for k := range a {
+		rest = append(rest, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	for _, k := range rest {
+		if !skip[k] {
+			changes = append(changes, diffValue(k, a[k], b[k])...)
+		}
+	}
+	return changes, nil
+}
+
+func diffValue(path string, a, b json.RawMessage) []jsondiff.Change {
+	c, ok := jsondiff.Compare(a, b)
+	if !ok {
+		return nil
+	}
+	c.Path = path
+	return []jsondiff.Change{c}
+}
+
+// diffNamedList compares two lists whose entries are names or objects with a
+// "name", by name; a list that does not have that shape is compared as a value.
+func diffNamedList(path string, a, b json.RawMessage) []jsondiff.Change {
+	as, okA := namedEntries(a)
+	bs, okB := namedEntries(b)
+	if !okA || !okB {
+		return diffValue(path, a, b)
+	}
+	names := map[string]bool{}
+	for n := range as {
+		names[n] = true
+	}
+	for n := range bs {
+		names[n] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for n := range names {
+		sorted = append(sorted, n)
+	}
+	sort.Strings(sorted)
+
+	var changes []jsondiff.Change
+	for _, n := range sorted {
+		changes = append(changes, diffValue(fmt.Sprintf("%s[%s]", path, n), as[n], bs[n])...)
+	}
+	return changes
+}
+
+func namedEntries(list json.RawMessage) (map[string]json.RawMessage, bool) {
+	out := map[string]json.RawMessage{}
+	if jsondiff.IsAbsent(list) {
+		return out, true
+	}
+	var entries []json.RawMessage
+	if json.Unmarshal(list, &entries) != nil {
+		return nil, false
+	}
+	for i, e := range entries {
+		var name string
+		if json.Unmarshal(e, &name) != nil {
+			var obj struct {
+				Name string `json:"name"`
+			}
+			if json.Unmarshal(e, &obj) != nil || obj.Name == "" {
+				name = fmt.Sprintf("#%d", i)
+			} else {
+				name = obj.Name
+			}
+		}
+		out[name] = e
+	}
+	return out, true
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+func TestVersionsListsNewestFirstAndSkipsDeleted(t *testing.T) {
+	fc := &fakeClient{
+		getDef: json.RawMessage(`{"name":"x","version":4,"model":"m4","tools":["a","b"]}`),
+		versions: map[int]json.RawMessage{
+			3: json.RawMessage(`{"name":"x","version":3,"model":"m3"}`),
+			1: json.RawMessage(`{"name":"x","model":"m1"}`),
+		},
+	}
+	got, err := NewService(fc).Versions(context.Background(), "x")
+	if err != nil {
+		t.Fatalf("Versions: %v", err)
+	}
+	var summary [][3]any
+	for _, v := range got {
+		summary = append(summary, [3]any{v.Version, v.Model, v.Latest})
+	}
+	want := [][3]any{{4, "m4", true}, {3, "m3", false}, {1, "m1", false}}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("Versions = %v, want %v", summary, want)
+	}
+	if got[0].Tools != 2 {
+		t.Errorf("latest tools = %d, want 2", got[0].Tools)
+	}
+}
+
+func TestRollbackDeploysOldDefinitionWithoutServerFields(t *testing.T) {
+	fc := &fakeClient{versions: map[int]json.RawMessage{
+		2: json.RawMessage(`{"name":"x","version":2,"updateTime":17,"_framework":"openai","model":"m2"}`),
+	}}
+	if _, err := NewService(fc).Rollback(context.Background(), "x", 2); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if fc.lastDeployFwk != "openai" {
+		t.Errorf("framework = %q, want openai", fc.lastDeployFwk)
+	}
+	if string(fc.lastDeployCfg) != `{"_framework":"openai","model":"m2","name":"x"}` {
+		t.Errorf("deployed %s, want the definition without version or timestamps", fc.lastDeployCfg)
+	}
+
+	if _, err := NewService(fc).Rollback(context.Background(), "x", 9); err == nil {
+		t.Error("Rollback to a missing version succeeded, want an error")
+	}
+}
+
+func TestDiffDefinitions(t *testing.T) {
+	from := json.RawMessage(`{
+		"name": "x", "version": 1, "model": "m1", "instructions": "be brief",
+		"tools": ["search", {"name": "lookup", "timeout": 5}],
+		"guardrails": [{"name": "pii", "mode": "block"}],
+		"maxTurns": 10, "updateTime": 1, "ownerApp": "a"
+	}`)
+	to := json.RawMessage(`{
+		"name": "x", "version": 2, "model": "m2", "instructions": "be brief",
+		"tools": [{"name": "lookup", "timeout": 10}, "escalate"],
+		"guardrails": [{"mode": "block", "name": "pii"}],
+		"description": "new", "updateTime": 2, "ownerApp": "b"
+	}`)
+	got, err := DiffDefinitions(from, to)
+	if err != nil {
+		t.Fatalf("DiffDefinitions: %v", err)
+	}
+	want := []jsondiff.Change{
+		{Path: "model", Kind: jsondiff.Changed, Old: "m1", New: "m2"},
+		{Path: "tools[escalate]", Kind: jsondiff.Added, New: "escalate"},
+		{Path: "tools[lookup]", Kind: jsondiff.Changed, Old: `{"name":"lookup","timeout":5}`, New: `{"name":"lookup","timeout":10}`},
+		{Path: "tools[search]", Kind: jsondiff.Removed, Old: "search"},
+		{Path: "description", Kind: jsondiff.Added, New: "new"},
+		{Path: "maxTurns", Kind: jsondiff.Removed, Old: "10"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffDefinitions =\n%+v\nwant\n%+v", got, want)
+	}
+}
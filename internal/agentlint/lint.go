@@ -0,0 +1,303 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package agentlint validates agent config files offline: against the bundled JSON
+// Schema, then for what a schema cannot express — model identifiers, duplicate
+// names, tool task definitions and strategy-specific fields. Every diagnostic
+// carries the line and column it refers to.
+package agentlint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity grades a diagnostic. Errors fail validation; warnings do not.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is one problem found in a config. Path names the offending field in
+// dotted form (tools[1].name); Line and Column are 1-based.
+type Diagnostic struct {
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	s := fmt.Sprintf("%d:%d: %s: %s", d.Line, d.Column, d.Severity, d.Message)
+	if d.Path != "" {
+		s += " (" + d.Path + ")"
+	}
+	return s
+}
+
+func diagAt(n *yaml.Node, sev Severity, path, msg string) Diagnostic {
+	return Diagnostic{Line: n.Line, Column: n.Column, Severity: sev, Path: path, Message: msg}
+}
+
+// Provider is an LLM provider model identifiers may name, as "<Prefix>/<model>".
+// Configured says whether its credentials are set in this environment.
+type Provider struct {
+	Name       string
+	Prefix     string
+	EnvVars    []string
+	Configured bool
+}
+
+// Options tunes validation. With no Providers, model identifiers are only checked
+// for their provider/model shape.
+type Options struct {
+	Providers []Provider
+}
+
+// Multi-agent strategies and the fields they need beyond a list of agents.
+const (
+	strategyRouter     = "router"
+	strategySequential = "sequential"
+	strategyParallel   = "parallel"
+	toolTypeWorker     = "worker"
+)
+
+// yamlErrorLine pulls the line number out of a YAML syntax error.
+var yamlErrorLine = regexp.MustCompile(`^line (\d+): `)
+
+// frameworkKey marks a framework agent config, whose shape belongs to the framework.
+const frameworkKey = "_framework"
+
+// Validate checks a YAML or JSON agent config and returns its diagnostics ordered
+// by position. A syntax error is the only diagnostic when the file does not parse.
+func Validate(data []byte, opts Options) []Diagnostic {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		d := Diagnostic{Line: 1, Column: 1, Severity: SeverityError, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := yamlErrorLine.FindStringSubmatch(d.Message); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Message = strings.TrimPrefix(d.Message, m[0])
+		}
+		return []Diagnostic{d}
+	}
+	if len(doc.Content) == 0 {
+		return []Diagnostic{{Line: 1, Column: 1, Severity: SeverityError, Message: "config is empty"}}
+	}
+	root := doc.Content[0]
+	if root.Kind == yaml.MappingNode && lookup(root, frameworkKey) != nil {
+		return []Diagnostic{diagAt(root, SeverityWarning, "",
+			"framework agent config: its shape is checked by the server, not offline")}
+	}
+
+	diags := validator{root: bundled}.validate(root, bundled, "")
+	l := &linter{opts: opts}
+	l.agent(root, "", true)
+	diags = append(diags, l.diags...)
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Line != diags[j].Line {
+			return diags[i].Line < diags[j].Line
+		}
+		return diags[i].Column < diags[j].Column
+	})
+	return diags
+}
+
+// HasErrors reports whether any diagnostic is an error.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// linter runs the checks a schema cannot express. It tolerates any shape: the
+// schema has already reported structural problems.
+type linter struct {
+	opts  Options
+	diags []Diagnostic
+}
+
+func (l *linter) report(n *yaml.Node, sev Severity, path, format string, args ...any) {
+	l.diags = append(l.diags, diagAt(n, sev, path, fmt.Sprintf(format, args...)))
+}
+
+func (l *linter) agent(n *yaml.Node, path string, root bool) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	if m := lookup(n, "model"); m != nil {
+		l.model(m, joinPath(path, "model"))
+	}
+
+	agents := lookup(n, "agents")
+	subAgents := 0
+	if agents != nil && agents.Kind == yaml.SequenceNode {
+		subAgents = len(agents.Content)
+		l.uniqueNames(agents, joinPath(path, "agents"), "sub-agent")
+		for i, a := range agents.Content {
+			l.agent(a, fmt.Sprintf("%s[%d]", joinPath(path, "agents"), i), false)
+		}
+	}
+	if root && subAgents == 0 && lookup(n, "model") == nil {
+		l.report(n, SeverityError, path, "missing %q: an agent without sub-agents needs a model", "model")
+	}
+	l.strategy(n, path, subAgents)
+
+	if tools := lookup(n, "tools"); tools != nil && tools.Kind == yaml.SequenceNode {
+		l.uniqueNames(tools, joinPath(path, "tools"), "tool")
+		for i, t := range tools.Content {
+			l.tool(t, fmt.Sprintf("%s[%d]", joinPath(path, "tools"), i))
+		}
+	}
+	if guards := lookup(n, "guardrails"); guards != nil && guards.Kind == yaml.SequenceNode {
+		l.uniqueNames(guards, joinPath(path, "guardrails"), "guardrail")
+		for i, g := range guards.Content {
+			if m := lookup(g, "model"); m != nil {
+				l.model(m, fmt.Sprintf("%s[%d].model", joinPath(path, "guardrails"), i))
+			}
+		}
+	}
+	if r := lookup(n, "router"); r != nil {
+		l.agent(r, joinPath(path, "router"), false)
+	}
+}
+
+// strategy checks the fields a multi-agent strategy depends on.
+func (l *linter) strategy(n *yaml.Node, path string, subAgents int) {
+	s := lookup(n, "strategy")
+	router := lookup(n, "router")
+	if s == nil {
+		if router != nil {
+			l.report(router, SeverityWarning, joinPath(path, "router"), "router is ignored unless strategy is %s", strategyRouter)
+		}
+		return
+	}
+	sp := joinPath(path, "strategy")
+	switch {
+	case subAgents == 0:
+		l.report(s, SeverityError, sp, "strategy %s needs sub-agents under %q", s.Value, "agents")
+	case subAgents == 1 && (s.Value == strategySequential || s.Value == strategyParallel):
+		l.report(s, SeverityWarning, sp, "strategy %s with a single sub-agent has nothing to combine", s.Value)
+	}
+	switch {
+	case s.Value == strategyRouter && router == nil:
+		l.report(s, SeverityError, sp, "strategy %s needs a %q agent or agent name", strategyRouter, "router")
+	case s.Value != strategyRouter && router != nil:
+		l.report(router, SeverityWarning, joinPath(path, "router"), "router is ignored unless strategy is %s", strategyRouter)
+	}
+}
+
+// tool checks that a worker tool carries what its task definition is built from.
+func (l *linter) tool(n *yaml.Node, path string) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	typ := toolTypeWorker
+	if t := lookup(n, "type"); t != nil {
+		typ = t.Value
+	}
+	if a := lookup(n, "agent"); a != nil {
+		l.agent(a, joinPath(path, "agent"), false)
+	}
+	if typ != toolTypeWorker || lookup(n, "taskName") != nil {
+		return
+	}
+	var missing []string
+	for _, k := range []string{"description", "inputSchema"} {
+		if lookup(n, k) == nil {
+			missing = append(missing, strconv.Quote(k))
+		}
+	}
+	if len(missing) > 0 {
+		name := ""
+		if nm := lookup(n, "name"); nm != nil {
+			name = nm.Value + " "
+		}
+		l.report(n, SeverityError, path, "worker tool %shas no task definition: set %s, or taskName to use an existing task",
+			name, strings.Join(missing, " and "))
+	}
+}
+
+// model checks a "<provider>/<model>" identifier against the provider registry.
+func (l *linter) model(n *yaml.Node, path string) {
+	if n.Kind != yaml.ScalarNode || n.Value == "" {
+		return
+	}
+	prefix, rest, ok := strings.Cut(n.Value, "/")
+	if !ok || prefix == "" || rest == "" {
+		l.report(n, SeverityError, path, "model %q must be <provider>/<model>, e.g. openai/gpt-4o", n.Value)
+		return
+	}
+	if len(l.opts.Providers) == 0 {
+		return
+	}
+	for _, p := range l.opts.Providers {
+		if p.Prefix != prefix {
+			continue
+		}
+		if !p.Configured {
+			l.report(n, SeverityWarning, path, "%s is not configured here (%s); the server must have its credentials",
+				p.Name, strings.Join(p.EnvVars, ", "))
+		}
+		return
+	}
+	known := make([]string, len(l.opts.Providers))
+	for i, p := range l.opts.Providers {
+		known[i] = p.Prefix
+	}
+	l.report(n, SeverityError, path, "unknown model provider %q (known: %s)", prefix, strings.Join(known, ", "))
+}
+
+// uniqueNames reports list entries that reuse an earlier entry's name. Entries are
+// names or objects with a "name".
+func (l *linter) uniqueNames(list *yaml.Node, path, what string) {
+	first := map[string]int{}
+	for i, e := range list.Content {
+		nameNode := e
+		if e.Kind == yaml.MappingNode {
+			nameNode = lookup(e, "name")
+		}
+		if nameNode == nil || nameNode.Kind != yaml.ScalarNode || nameNode.Value == "" {
+			continue
+		}
+		if prev, dup := first[nameNode.Value]; dup {
+			l.report(nameNode, SeverityError, fmt.Sprintf("%s[%d]", path, i),
+				"duplicate %s name %q (first at %s[%d])", what, nameNode.Value, path, prev)
+			continue
+		}
+		first[nameNode.Value] = i
+	}
+}
+
+// lookup returns the value of key in a mapping node, or nil.
+func lookup(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agentlint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testProviders = []Provider{
+	{Name: "OpenAI", Prefix: "openai", EnvVars: []string{"OPENAI_API_KEY"}, Configured: true},
+	{Name: "Anthropic", Prefix: "anthropic", EnvVars: []string{"ANTHROPIC_API_KEY"}},
+}
+
+func messages(diags []Diagnostic) []string {
+	out := make([]string, len(diags))
+	for i, d := range diags {
+		out[i] = d.String()
+	}
+	return out
+}
+
+func TestValidateCleanConfig(t *testing.T) {
+	cfg := `name: support
+model: openai/gpt-4o
+instructions: Help customers.
+maxTurns: 10
+tools:
+  - name: lookup_order
+    description: Find an order
+    inputSchema: {type: object}
+  - name: refund
+    type: http
+    url: https://example.com/refund
+  - name: legacy
+    taskName: legacy_task
+guardrails:
+  - {name: no_pii, type: regex, pattern: "\\d{3}-\\d{2}-\\d{4}", onFail: raise}
+`
+	if diags := Validate([]byte(cfg), Options{Providers: testProviders}); len(diags) != 0 {
+		t.Errorf("Validate = %v, want no diagnostics", messages(diags))
+	}
+}
+
+func TestValidateReportsPositions(t *testing.T) {
+	cfg := `name: support
+modle: openai/gpt-4o
+model: gemini-pro
+maxTurns: 0
+strategy: router
+agents:
+  - name: billing
+    model: anthropic/claude-3-5-sonnet-20241022
+  - name: billing
+    model: mistral/large
+tools:
+  - name: lookup
+    description: Find an order
+    inputSchema: {type: object}
+  - name: lookup
+    type: http
+  - name: scrape
+`
+	got := messages(Validate([]byte(cfg), Options{Providers: testProviders}))
+	want := []string{
+		`2:1: error: unknown key "modle"; did you mean "model"? (modle)`,
+		`3:8: error: model "gemini-pro" must be <provider>/<model>, e.g. openai/gpt-4o (model)`,
+		`4:11: error: must be at least 1 (maxTurns)`,
+		`5:11: error: strategy router needs a "router" agent or agent name (strategy)`,
+		`8:12: warning: Anthropic is not configured here (ANTHROPIC_API_KEY); the server must have its credentials (agents[0].model)`,
+		`9:11: error: duplicate sub-agent name "billing" (first at agents[0]) (agents[1])`,
+		`10:12: error: unknown model provider "mistral" (known: openai, anthropic) (agents[1].model)`,
+		`15:5: error: missing required key "url" (tools[1])`,
+		`15:11: error: duplicate tool name "lookup" (first at tools[0]) (tools[1])`,
+		`17:5: error: worker tool scrape has no task definition: set "description" and "inputSchema", or taskName to use an existing task (tools[2])`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateJSONAndSyntaxErrors(t *testing.T) {
+	json := "{\n  \"name\": \"x\",\n  \"model\": \"openai/gpt-4o\",\n  \"tools\": [\"search\", 7]\n}"
+	got := messages(Validate([]byte(json), Options{}))
+	want := []string{`4:23: error: expected string or object, got integer (tools[1])`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON config = %v, want %v", got, want)
+	}
+
+	got = messages(Validate([]byte("name: x\ntools:\n  - a\n - b\n"), Options{}))
+	if want := []string{"3:1: error: did not find expected key"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("syntax error = %v, want %v", got, want)
+	}
+
+	if diags := Validate([]byte("_framework: openai\nanything: goes\n"), Options{}); HasErrors(diags) {
+		t.Errorf("framework config = %v, want no errors", messages(diags))
+	}
+}
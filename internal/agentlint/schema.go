@@ -0,0 +1,366 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package agentlint
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaJSON is the bundled JSON Schema for agent configs, for editors and CI
+// tools that validate on their own.
+//
+//go:embed schema.json
+var SchemaJSON []byte
+
+// refPrefix is the only kind of $ref the bundled schema uses.
+const refPrefix = "#/$defs/"
+
+// schema is the subset of JSON Schema the bundled schema is written in: $ref,
+// anyOf, allOf, if/then, type, enum, const, required, properties,
+// additionalProperties: false, items, minLength, pattern, minimum and maximum.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Defs                 map[string]*schema `json:"$defs"`
+	AnyOf                []*schema          `json:"anyOf"`
+	AllOf                []*schema          `json:"allOf"`
+	If                   *schema            `json:"if"`
+	Then                 *schema            `json:"then"`
+	Type                 typeList           `json:"type"`
+	Enum                 []any              `json:"enum"`
+	Const                any                `json:"const"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	MinLength            *int               `json:"minLength"`
+	Pattern              string             `json:"pattern"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+
+	pattern *regexp.Regexp
+}
+
+// typeList reads "type" as a single name or a list of names.
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var one string
+	if json.Unmarshal(data, &one) == nil {
+		*t = typeList{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// bundled is the parsed bundled schema. It is part of the binary, so failing to
+// parse it is a build defect.
+var bundled = mustParseSchema(SchemaJSON)
+
+func mustParseSchema(data []byte) *schema {
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		panic(fmt.Sprintf("agentlint: bundled schema: %v", err))
+	}
+	var compile func(*schema)
+	compile = func(s *schema) {
+		if s == nil {
+			return
+		}
+		if s.Pattern != "" {
+			s.pattern = regexp.MustCompile(s.Pattern)
+		}
+		for _, d := range s.Defs {
+			compile(d)
+		}
+		for _, p := range s.Properties {
+			compile(p)
+		}
+		for _, group := range [][]*schema{s.AnyOf, s.AllOf, {s.If, s.Then, s.Items}} {
+			for _, sub := range group {
+				compile(sub)
+			}
+		}
+	}
+	compile(&s)
+	return &s
+}
+
+// validator checks YAML nodes against a schema, resolving $refs against root.
+type validator struct {
+	root *schema
+}
+
+func (v validator) resolve(s *schema) *schema {
+	for s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, refPrefix)
+		if !ok || v.root.Defs[name] == nil {
+			panic(fmt.Sprintf("agentlint: unresolvable $ref %q", s.Ref))
+		}
+		s = v.root.Defs[name]
+	}
+	return s
+}
+
+// validate returns one diagnostic per violation under n; path names n for messages.
+func (v validator) validate(n *yaml.Node, s *schema, path string) []Diagnostic {
+	s = v.resolve(s)
+	var out []Diagnostic
+	report := func(at *yaml.Node, format string, args ...any) {
+		out = append(out, diagAt(at, SeverityError, path, fmt.Sprintf(format, args...)))
+	}
+
+	if len(s.AnyOf) > 0 {
+		out = append(out, v.anyOf(n, s.AnyOf, path)...)
+	}
+	for _, sub := range s.AllOf {
+		out = append(out, v.validate(n, sub, path)...)
+	}
+	if s.If != nil && s.Then != nil && len(v.validate(n, s.If, path)) == 0 {
+		out = append(out, v.validate(n, s.Then, path)...)
+	}
+
+	kind := kindOf(n)
+	if len(s.Type) > 0 && !typeMatches(s.Type, kind) {
+		report(n, "expected %s, got %s", strings.Join(s.Type, " or "), kind)
+		return out
+	}
+	if len(s.Enum) > 0 && !enumContains(s.Enum, n) {
+		report(n, "%s is not one of %s", n.Value, enumList(s.Enum))
+	}
+	if s.Const != nil && !scalarEquals(n, s.Const) {
+		report(n, "must be %v", s.Const)
+	}
+
+	switch kind {
+	case kindString:
+		if s.MinLength != nil && len(n.Value) < *s.MinLength {
+			report(n, "must not be empty")
+		}
+		if s.pattern != nil && !s.pattern.MatchString(n.Value) {
+			report(n, "%q does not match %s", n.Value, s.Pattern)
+		}
+	case kindInteger, kindNumber:
+		f, _ := strconv.ParseFloat(n.Value, 64)
+		if s.Minimum != nil && f < *s.Minimum {
+			report(n, "must be at least %s", formatNumber(*s.Minimum))
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			report(n, "must be at most %s", formatNumber(*s.Maximum))
+		}
+	case kindObject:
+		out = append(out, v.object(n, s, path)...)
+	case kindArray:
+		if s.Items != nil {
+			for i, item := range n.Content {
+				out = append(out, v.validate(item, s.Items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return out
+}
+
+func (v validator) object(n *yaml.Node, s *schema, path string) []Diagnostic {
+	var out []Diagnostic
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := n.Content[i], n.Content[i+1]
+		child := joinPath(path, key.Value)
+		if seen[key.Value] {
+			out = append(out, diagAt(key, SeverityError, child, fmt.Sprintf("duplicate key %q", key.Value)))
+			continue
+		}
+		seen[key.Value] = true
+		if prop, ok := s.Properties[key.Value]; ok {
+			out = append(out, v.validate(val, prop, child)...)
+		} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			msg := fmt.Sprintf("unknown key %q", key.Value)
+			if guess := closest(key.Value, s.Properties); guess != "" {
+				msg += fmt.Sprintf("; did you mean %q?", guess)
+			}
+			out = append(out, diagAt(key, SeverityError, child, msg))
+		}
+	}
+	for _, r := range s.Required {
+		if !seen[r] {
+			out = append(out, diagAt(n, SeverityError, path, fmt.Sprintf("missing required key %q", r)))
+		}
+	}
+	return out
+}
+
+// anyOf passes when one branch does. Otherwise it reports the errors of the branch
+// whose type fits the value, which names the real problem, or a type mismatch.
+func (v validator) anyOf(n *yaml.Node, branches []*schema, path string) []Diagnostic {
+	var fitting []Diagnostic
+	var types []string
+	fits := false
+	for _, b := range branches {
+		errs := v.validate(n, b, path)
+		if len(errs) == 0 {
+			return nil
+		}
+		t := v.resolve(b).Type
+		types = append(types, t...)
+		if !fits && typeMatches(t, kindOf(n)) {
+			fitting, fits = errs, true
+		}
+	}
+	if fits {
+		return fitting
+	}
+	return []Diagnostic{diagAt(n, SeverityError, path, fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), kindOf(n)))}
+}
+
+// JSON types of YAML nodes.
+const (
+	kindString  = "string"
+	kindInteger = "integer"
+	kindNumber  = "number"
+	kindBoolean = "boolean"
+	kindNull    = "null"
+	kindObject  = "object"
+	kindArray   = "array"
+)
+
+func kindOf(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return kindObject
+	case yaml.SequenceNode:
+		return kindArray
+	case yaml.AliasNode:
+		return kindOf(n.Alias)
+	}
+	switch n.ShortTag() {
+	case "!!int":
+		return kindInteger
+	case "!!float":
+		return kindNumber
+	case "!!bool":
+		return kindBoolean
+	case "!!null":
+		return kindNull
+	}
+	return kindString
+}
+
+func typeMatches(types []string, kind string) bool {
+	for _, t := range types {
+		if t == kind || (t == kindNumber && kind == kindInteger) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumContains(enum []any, n *yaml.Node) bool {
+	for _, e := range enum {
+		if scalarEquals(n, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func scalarEquals(n *yaml.Node, want any) bool {
+	if n.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch w := want.(type) {
+	case string:
+		return kindOf(n) == kindString && n.Value == w
+	case float64:
+		f, err := strconv.ParseFloat(n.Value, 64)
+		return err == nil && f == w
+	case bool:
+		return kindOf(n) == kindBoolean && n.Value == strconv.FormatBool(w)
+	}
+	return false
+}
+
+func enumList(enum []any) string {
+	parts := make([]string, len(enum))
+	for i, e := range enum {
+		parts[i] = fmt.Sprint(e)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatNumber(f float64) string {
+	if f == math.Trunc(f) {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// maxSuggestDistance bounds how far a misspelt key may be from a suggestion.
+const maxSuggestDistance = 2
+
+// closest returns the property name nearest to key, if any is close enough to be
+// a likely typo.
+func closest(key string, props map[string]*schema) string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	best, bestDist := "", maxSuggestDistance+1
+	for _, name := range names {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(name)); d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
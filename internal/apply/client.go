@@ -0,0 +1,193 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/conductor-oss/conductor-cli/internal/transport"
+)
+
+// Endpoints listing each kind; a resource's path appends its name, or for
+// webhooks and gateway configs its id.
+var kindPaths = map[Kind]string{
+	KindTaskDef:           "/metadata/taskdefs",
+	KindWorkflow:          "/metadata/workflow",
+	KindEventHandler:      "/event",
+	KindSchedule:          "/scheduler/schedules",
+	KindWebhook:           "/metadata/webhook",
+	KindGatewayAuthConfig: "/gateway/config/auth",
+	KindGatewayService:    "/gateway/config/service",
+}
+
+// client is the Server over the Conductor metadata, event, scheduler, webhook
+// and gateway endpoints. It sends definitions as they are, so keys the SDK models
+// lack, tags among them, reach the server.
+type client struct {
+	t transport.Config
+}
+
+// NewClient returns a Server talking to the server t points at.
+func NewClient(t transport.Config) Server {
+	return client{t: t}
+}
+
+func (c client) Get(ctx context.Context, kind Kind, name string, version int) (json.RawMessage, bool, error) {
+	switch kind {
+	case KindWebhook:
+		// Webhooks are addressed by an id the server assigns, so find one by name.
+		return c.find(ctx, kind, name)
+	case KindEventHandler:
+		// Event handlers can only be read by the event they handle, so list them
+		// all and find one by name.
+		return c.find(ctx, kind, name)
+	}
+	path := resourcePath(kind, name)
+	if kind == KindWorkflow {
+		path += "?" + url.Values{keyVersion: {strconv.Itoa(version)}}.Encode()
+	}
+	def, err := c.get(ctx, path)
+	if err != nil || nameOf(kind, def) == "" {
+		// Some servers answer an unknown name with an empty body instead of a 404.
+		return nil, false, err
+	}
+	return def, true, nil
+}
+
+func (c client) find(ctx context.Context, kind Kind, name string) (json.RawMessage, bool, error) {
+	defs, err := c.List(ctx, kind)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, def := range defs {
+		if nameOf(kind, def) == name {
+			return def, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// List returns every resource of a kind; a server without the kind's endpoint,
+// such as OSS Conductor for webhooks, has none.
+func (c client) List(ctx context.Context, kind Kind) ([]json.RawMessage, error) {
+	raw, err := c.get(ctx, kindPaths[kind])
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	var defs []json.RawMessage
+	if err := json.Unmarshal(raw, &defs); err != nil {
+		return nil, fmt.Errorf("decode %s list: %w", kind, err)
+	}
+	return defs, nil
+}
+
+func (c client) Put(ctx context.Context, kind Kind, def, current json.RawMessage) error {
+	base := kindPaths[kind]
+	switch kind {
+	case KindTaskDef:
+		if current == nil {
+			return c.t.DoJSON(ctx, http.MethodPost, base, []json.RawMessage{def}, nil)
+		}
+		return c.t.DoJSON(ctx, http.MethodPut, base, def, nil)
+	case KindWorkflow:
+		return c.t.DoJSON(ctx, http.MethodPut, base, []json.RawMessage{def}, nil)
+	case KindEventHandler:
+		if current == nil {
+			return c.t.DoJSON(ctx, http.MethodPost, base, def, nil)
+		}
+		return c.t.DoJSON(ctx, http.MethodPut, base, def, nil)
+	case KindSchedule:
+		return c.t.DoJSON(ctx, http.MethodPost, base, def, nil)
+	default:
+		if current == nil {
+			return c.t.DoJSON(ctx, http.MethodPost, base, def, nil)
+		}
+		id := idOf(current)
+		if kind == KindWebhook {
+			// The file names the webhook; the server knows it by id.
+			var err error
+			if def, err = withID(def, id); err != nil {
+				return err
+			}
+		}
+		return c.t.DoJSON(ctx, http.MethodPut, base+"/"+url.PathEscape(id), def, nil)
+	}
+}
+
+func (c client) Delete(ctx context.Context, kind Kind, def json.RawMessage) error {
+	path := resourcePath(kind, nameOf(kind, def))
+	switch kind {
+	case KindWorkflow:
+		path += "/" + strconv.Itoa(versionOf(kind, def))
+	case KindWebhook:
+		path = resourcePath(kind, idOf(def))
+	}
+	return c.t.DoJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// get returns the body of a GET, or nil when the server has nothing there: a
+// 404, or an empty or null body.
+func (c client) get(ctx context.Context, path string) (json.RawMessage, error) {
+	resp, err := c.t.Do(ctx, http.MethodGet, path, nil, nil)
+	var apiErr *transport.APIError
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	return raw, nil
+}
+
+func resourcePath(kind Kind, name string) string {
+	return kindPaths[kind] + "/" + url.PathEscape(name)
+}
+
+func idOf(def json.RawMessage) string {
+	var probe struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(def, &probe)
+	return probe.ID
+}
+
+func withID(def json.RawMessage, id string) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(def, &fields); err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(id)
+	if err != nil {
+		return nil, err
+	}
+	fields[keyID] = raw
+	return json.Marshal(fields)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/transport"
+)
+
+func TestClientTreatsEmptyAndMissingAsNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scheduler/schedules/gone":
+			// OSS Conductor answers an unknown schedule with an empty 200.
+		case "/metadata/workflow/flow":
+			if r.URL.Query().Get("version") != "2" {
+				t.Errorf("version = %q", r.URL.Query().Get("version"))
+			}
+			_, _ = io.WriteString(w, `{"name":"flow","version":2,"tasks":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(transport.Config{BaseURL: srv.URL})
+
+	for _, kind := range []Kind{KindSchedule, KindTaskDef} {
+		if _, found, err := c.Get(context.Background(), kind, "gone", 0); found || err != nil {
+			t.Errorf("Get %s = %t, %v; want not found", kind, found, err)
+		}
+	}
+	if def, found, err := c.Get(context.Background(), KindWorkflow, "flow", 2); !found || err != nil || versionOf(KindWorkflow, def) != 2 {
+		t.Errorf("Get workflow = %s, %t, %v", def, found, err)
+	}
+}
+
+func TestClientUpdatesWebhookByID(t *testing.T) {
+	var putPath string
+	var putBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = io.WriteString(w, `[{"id":"wh-1","name":"stripe","sourcePlatform":"Stripe"}]`)
+		case http.MethodPut:
+			putPath = r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&putBody)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(transport.Config{BaseURL: srv.URL})
+
+	current, found, err := c.Get(context.Background(), KindWebhook, "stripe", 0)
+	if !found || err != nil {
+		t.Fatalf("Get = %t, %v", found, err)
+	}
+	def := json.RawMessage(`{"name":"stripe","sourcePlatform":"Stripe","verifier":"SIGNATURE_BASED"}`)
+	if err := c.Put(context.Background(), KindWebhook, def, current); err != nil {
+		t.Fatal(err)
+	}
+	if putPath != "/metadata/webhook/wh-1" || putBody["id"] != "wh-1" {
+		t.Errorf("PUT %s %v", putPath, putBody)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// tagTypeMetadata is the type the server gives user-defined tags.
+const tagTypeMetadata = "METADATA"
+
+// labelledKinds can carry a label. Event handlers and gateway configs have no
+// tags, so apply never prunes them.
+var labelledKinds = map[Kind]bool{KindTaskDef: true, KindWorkflow: true, KindSchedule: true, KindWebhook: true}
+
+// Label marks the resources one set of files manages, stored on each as a tag.
+// Prune deletes labelled resources the files no longer declare, so two sets of
+// files sharing a server use different labels.
+type Label struct {
+	Key   string
+	Value string
+}
+
+// ParseLabel reads a label written key=value.
+func ParseLabel(s string) (Label, error) {
+	key, value, ok := strings.Cut(s, "=")
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if !ok || key == "" || value == "" {
+		return Label{}, fmt.Errorf("label %q must be written key=value", s)
+	}
+	return Label{Key: key, Value: value}, nil
+}
+
+func (l Label) String() string {
+	return l.Key + "=" + l.Value
+}
+
+type tag struct {
+	Key   string `json:"key"`
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+// withLabel returns def with l among its tags, leaving its other tags alone. The
+// tag is written as current, the stored resource, has it when it does, so an
+// unchanged resource does not differ in how the server recorded its label.
+func withLabel(def json.RawMessage, l Label, current json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(def, &fields); err != nil {
+		return nil, err
+	}
+	tags, err := tagsOf(fields)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		if t.Key == l.Key && t.Value == l.Value {
+			return def, nil
+		}
+	}
+	raw, err := json.Marshal(append(tags, labelTag(l, current)))
+	if err != nil {
+		return nil, err
+	}
+	fields[keyTags] = raw
+	return json.Marshal(fields)
+}
+
+func labelTag(l Label, current json.RawMessage) tag {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(current, &fields) == nil {
+		tags, _ := tagsOf(fields)
+		for _, t := range tags {
+			if t.Key == l.Key && t.Value == l.Value {
+				return t
+			}
+		}
+	}
+	return tag{Key: l.Key, Type: tagTypeMetadata, Value: l.Value}
+}
+
+// hasLabel reports whether def carries l among its tags.
+func hasLabel(def json.RawMessage, l Label) bool {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(def, &fields) != nil {
+		return false
+	}
+	tags, _ := tagsOf(fields)
+	for _, t := range tags {
+		if t.Key == l.Key && t.Value == l.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func tagsOf(fields map[string]json.RawMessage) ([]tag, error) {
+	var tags []tag
+	if raw, ok := fields[keyTags]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &tags); err != nil {
+			return nil, fmt.Errorf("tags must be a list of {key, value} objects: %w", err)
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,267 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+	"github.com/conductor-oss/conductor-cli/internal/workflowdef"
+)
+
+// Server reads and writes resources on a Conductor server. Get reports found
+// false, with no error, for a resource the server does not have; Put creates def
+// when current is nil and otherwise replaces current with it; Delete removes a
+// resource as List returned it.
+type Server interface {
+	Get(ctx context.Context, kind Kind, name string, version int) (def json.RawMessage, found bool, err error)
+	List(ctx context.Context, kind Kind) ([]json.RawMessage, error)
+	Put(ctx context.Context, kind Kind, def, current json.RawMessage) error
+	Delete(ctx context.Context, kind Kind, def json.RawMessage) error
+}
+
+// Action is what applying does with one resource.
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionUnchanged Action = "unchanged"
+	ActionDelete    Action = "delete"
+)
+
+// Step is one resource in a plan. Changes lists how the file differs from the
+// server for an update. A field the file leaves out is reported only when the
+// server holds something other than the default it fills in for it.
+type Step struct {
+	Kind    Kind              `json:"kind"`
+	Name    string            `json:"name"`
+	Version int               `json:"version,omitempty"`
+	Source  string            `json:"source,omitempty"`
+	Action  Action            `json:"action"`
+	Changes []jsondiff.Change `json:"changes,omitempty"`
+
+	definition json.RawMessage
+	current    json.RawMessage
+}
+
+// Options controls a plan. Label, when set, is written onto every resource that
+// can carry one; Prune, which needs a Label, also plans deleting the labelled
+// resources the files no longer declare. Workflows are pruned by name, so the
+// older versions of a workflow the files still declare are kept.
+type Options struct {
+	Label *Label
+	Prune bool
+}
+
+// Plan compares resources, in dependency order, with the server: each is to be
+// created, updated or left unchanged. With Prune the deletions follow, in reverse
+// dependency order so nothing is deleted while something still references it.
+func Plan(ctx context.Context, srv Server, resources []Resource, opts Options) ([]Step, error) {
+	if opts.Prune && opts.Label == nil {
+		return nil, errors.New("pruning needs a label to tell which resources these files manage")
+	}
+	resources, err := Order(resources)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]Step, 0, len(resources))
+	declared := map[Kind]map[string]bool{}
+	for _, r := range resources {
+		step, err := planResource(ctx, srv, r, opts.Label)
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s): %w", r, r.Source, err)
+		}
+		steps = append(steps, step)
+		if declared[r.Kind] == nil {
+			declared[r.Kind] = map[string]bool{}
+		}
+		declared[r.Kind][r.Name] = true
+	}
+	if !opts.Prune {
+		return steps, nil
+	}
+	for _, kind := range slices.Backward(Kinds) {
+		if !labelledKinds[kind] {
+			continue
+		}
+		deletions, err := planPrune(ctx, srv, kind, *opts.Label, declared[kind])
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, deletions...)
+	}
+	return steps, nil
+}
+
+func planResource(ctx context.Context, srv Server, r Resource, label *Label) (Step, error) {
+	step := Step{Kind: r.Kind, Name: r.Name, Version: r.Version, Source: r.Source, definition: r.Definition}
+	current, found, err := srv.Get(ctx, r.Kind, r.Name, r.Version)
+	if err != nil {
+		return step, err
+	}
+	if label != nil && labelledKinds[r.Kind] {
+		if step.definition, err = withLabel(r.Definition, *label, current); err != nil {
+			return step, err
+		}
+	}
+	if !found {
+		step.Action = ActionCreate
+		return step, nil
+	}
+	step.current = current
+	changes, err := diff(r.Kind, current, step.definition)
+	if err != nil {
+		return step, err
+	}
+	step.Changes = changes
+	if len(changes) == 0 {
+		step.Action = ActionUnchanged
+	} else {
+		step.Action = ActionUpdate
+	}
+	return step, nil
+}
+
+// serverDefaults are the values the server fills in for fields a definition
+// leaves out, by kind; taskDefaults are those of a workflow's tasks. A nil value
+// is one the server assigns, such as the owner, whatever it is.
+var (
+	serverDefaults = map[Kind]map[string]json.RawMessage{
+		KindWorkflow: {
+			"schemaVersion":                 json.RawMessage(`2`),
+			"restartable":                   json.RawMessage(`true`),
+			"workflowStatusListenerEnabled": json.RawMessage(`false`),
+			"timeoutPolicy":                 json.RawMessage(`"ALERT_ONLY"`),
+			"timeoutSeconds":                json.RawMessage(`0`),
+			"inputParameters":               json.RawMessage(`[]`),
+			"outputParameters":              json.RawMessage(`{}`),
+			"variables":                     json.RawMessage(`{}`),
+			"inputTemplate":                 json.RawMessage(`{}`),
+			"ownerEmail":                    nil,
+		},
+		KindTaskDef: {
+			"retryCount":                  json.RawMessage(`3`),
+			"retryLogic":                  json.RawMessage(`"FIXED"`),
+			"retryDelaySeconds":           json.RawMessage(`60`),
+			"timeoutPolicy":               json.RawMessage(`"TIME_OUT_WF"`),
+			"responseTimeoutSeconds":      json.RawMessage(`3600`),
+			"totalTimeoutSeconds":         json.RawMessage(`0`),
+			"inputKeys":                   json.RawMessage(`[]`),
+			"outputKeys":                  json.RawMessage(`[]`),
+			"inputTemplate":               json.RawMessage(`{}`),
+			"rateLimitPerFrequency":       json.RawMessage(`0`),
+			"rateLimitFrequencyInSeconds": json.RawMessage(`1`),
+			"backoffScaleFactor":          json.RawMessage(`1`),
+			"ownerEmail":                  nil,
+		},
+		KindSchedule: {
+			"paused":                      json.RawMessage(`false`),
+			"runCatchupScheduleInstances": json.RawMessage(`false`),
+			"zoneId":                      json.RawMessage(`"UTC"`),
+		},
+	}
+	taskDefaults = map[string]json.RawMessage{
+		"optional":        json.RawMessage(`false`),
+		"asyncComplete":   json.RawMessage(`false`),
+		"permissive":      json.RawMessage(`false`),
+		"startDelay":      json.RawMessage(`0`),
+		"inputParameters": json.RawMessage(`{}`),
+		"joinOn":          json.RawMessage(`[]`),
+		"taskDefinition":  nil,
+	}
+)
+
+// diff compares a stored resource with its file. A field the file leaves out is
+// dropped when the server holds just the default it would fill in again.
+func diff(kind Kind, current, def json.RawMessage) ([]jsondiff.Change, error) {
+	var (
+		changes []jsondiff.Change
+		err     error
+	)
+	if kind == KindWorkflow {
+		changes, err = workflowdef.Diff(current, def)
+	} else {
+		changes, err = workflowdef.DiffFields(current, def)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(changes, func(c jsondiff.Change) bool {
+		if c.Kind != jsondiff.Removed || c.Path == "" {
+			return false
+		}
+		defaults := serverDefaults[kind]
+		if c.Task != "" {
+			defaults = taskDefaults
+		}
+		value, ok := defaults[c.Path]
+		return ok && (value == nil || jsondiff.Display(value) == c.Old)
+	}), nil
+}
+
+func planPrune(ctx context.Context, srv Server, kind Kind, label Label, declared map[string]bool) ([]Step, error) {
+	defs, err := srv.List(ctx, kind)
+	if err != nil {
+		return nil, fmt.Errorf("list %ss: %w", kind, err)
+	}
+	var steps []Step
+	for _, def := range defs {
+		name := nameOf(kind, def)
+		if name == "" || declared[name] || !hasLabel(def, label) {
+			continue
+		}
+		steps = append(steps, Step{Kind: kind, Name: name, Version: versionOf(kind, def), Action: ActionDelete, current: def})
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		if steps[i].Name != steps[j].Name {
+			return steps[i].Name < steps[j].Name
+		}
+		return steps[i].Version < steps[j].Version
+	})
+	return steps, nil
+}
+
+// Apply carries out a plan in order, skipping unchanged resources. It stops at
+// the first failure and reports how many steps were done before it.
+func Apply(ctx context.Context, srv Server, steps []Step) (done int, err error) {
+	for i, s := range steps {
+		switch s.Action {
+		case ActionCreate, ActionUpdate:
+			err = srv.Put(ctx, s.Kind, s.definition, s.current)
+		case ActionDelete:
+			err = srv.Delete(ctx, s.Kind, s.current)
+		}
+		if err != nil {
+			return i, fmt.Errorf("%s %s: %w", s.Action, Resource{Kind: s.Kind, Name: s.Name, Version: s.Version}, err)
+		}
+	}
+	return len(steps), nil
+}
+
+// Summary counts a plan's steps by action, e.g. "1 to create, 2 to update,
+// 3 unchanged, 1 to delete".
+func Summary(steps []Step) string {
+	counts := map[Action]int{}
+	for _, s := range steps {
+		counts[s.Action]++
+	}
+	return fmt.Sprintf("%d to create, %d to update, %d unchanged, %d to delete",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionUnchanged], counts[ActionDelete])
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+// fakeServer stores definitions by kind and name and records each write.
+type fakeServer struct {
+	defs   map[Kind]map[string]json.RawMessage
+	writes []string
+}
+
+func (f *fakeServer) Get(_ context.Context, kind Kind, name string, _ int) (json.RawMessage, bool, error) {
+	def, ok := f.defs[kind][name]
+	return def, ok, nil
+}
+
+func (f *fakeServer) List(_ context.Context, kind Kind) ([]json.RawMessage, error) {
+	var defs []json.RawMessage
+	for _, def := range f.defs[kind] {
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+func (f *fakeServer) Put(_ context.Context, kind Kind, def, current json.RawMessage) error {
+	f.writes = append(f.writes, fmt.Sprintf("put %s %s (existed: %t)", kind, nameOf(kind, def), current != nil))
+	return nil
+}
+
+func (f *fakeServer) Delete(_ context.Context, kind Kind, def json.RawMessage) error {
+	f.writes = append(f.writes, fmt.Sprintf("delete %s %s", kind, nameOf(kind, def)))
+	return nil
+}
+
+func TestPlanAndApply(t *testing.T) {
+	label := Label{Key: "app", Value: "orders"}
+	srv := &fakeServer{defs: map[Kind]map[string]json.RawMessage{
+		KindTaskDef: {
+			// The server filled in a default the file leaves out.
+			"charge": json.RawMessage(`{"name":"charge","retryCount":3,"timeoutPolicy":"TIME_OUT_WF","tags":[{"key":"app","value":"orders"}]}`),
+			"legacy": json.RawMessage(`{"name":"legacy","retryCount":1,"tags":[{"key":"app","value":"orders"}]}`),
+			"shared": json.RawMessage(`{"name":"shared","retryCount":1}`),
+		},
+		KindSchedule: {
+			"nightly": json.RawMessage(`{"name":"nightly","cronExpression":"0 0 * * * ?","tags":[{"key":"app","value":"orders","type":"METADATA"}]}`),
+		},
+	}}
+	resources := []Resource{
+		{Kind: KindSchedule, Name: "nightly", Source: "s.yaml",
+			Definition: json.RawMessage(`{"name":"nightly","cronExpression":"0 30 * * * ?"}`)},
+		{Kind: KindWorkflow, Name: "flow", Version: 1, Source: "w.json",
+			Definition: json.RawMessage(`{"name":"flow","tasks":[]}`)},
+		{Kind: KindTaskDef, Name: "charge", Source: "t.json",
+			Definition: json.RawMessage(`{"name":"charge","retryCount":3}`)},
+	}
+
+	steps, err := Plan(context.Background(), srv, resources, Options{Label: &label, Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range steps {
+		got = append(got, fmt.Sprintf("%s %s %s", s.Action, s.Kind, s.Name))
+	}
+	want := []string{
+		"unchanged taskDef charge",
+		"create workflow flow",
+		"update schedule nightly",
+		"delete taskDef legacy",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("plan = %q, want %q", got, want)
+	}
+	wantChange := []jsondiff.Change{{Path: "cronExpression", Kind: jsondiff.Changed, Old: "0 0 * * * ?", New: "0 30 * * * ?"}}
+	if !reflect.DeepEqual(steps[2].Changes, wantChange) {
+		t.Errorf("schedule changes = %+v, want %+v", steps[2].Changes, wantChange)
+	}
+	if s := Summary(steps); s != "1 to create, 1 to update, 1 unchanged, 1 to delete" {
+		t.Errorf("summary = %q", s)
+	}
+
+	done, err := Apply(context.Background(), srv, steps)
+	if err != nil || done != len(steps) {
+		t.Fatalf("Apply = %d, %v", done, err)
+	}
+	wantWrites := []string{"put workflow flow (existed: false)", "put schedule nightly (existed: true)", "delete taskDef legacy"}
+	if !reflect.DeepEqual(srv.writes, wantWrites) {
+		t.Errorf("writes = %q, want %q", srv.writes, wantWrites)
+	}
+}
+
+func TestPlanPruneNeedsLabel(t *testing.T) {
+	_, err := Plan(context.Background(), &fakeServer{}, nil, Options{Prune: true})
+	if err == nil || !strings.Contains(err.Error(), "needs a label") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestPlanReportsRemovedFields(t *testing.T) {
+	srv := &fakeServer{defs: map[Kind]map[string]json.RawMessage{
+		KindWorkflow: {"flow": json.RawMessage(`{"name":"flow","version":1,"schemaVersion":2,"ownerEmail":"a@b.c","timeoutSeconds":60,
+			"tasks":[{"name":"fetch","taskReferenceName":"fetch","type":"HTTP","optional":false,"inputParameters":{"url":"http://a","debug":true}}]}`)},
+	}}
+	tests := []struct {
+		name string
+		def  string
+		want []jsondiff.Change
+	}{
+		{
+			name: "defaults left out",
+			def:  `{"name":"flow","version":1,"timeoutSeconds":60,"tasks":[{"name":"fetch","taskReferenceName":"fetch","type":"HTTP","inputParameters":{"url":"http://a","debug":true}}]}`,
+		},
+		{
+			name: "task input removed",
+			def:  `{"name":"flow","version":1,"timeoutSeconds":60,"tasks":[{"name":"fetch","taskReferenceName":"fetch","type":"HTTP","inputParameters":{"url":"http://a"}}]}`,
+			want: []jsondiff.Change{{Task: "fetch", Path: "inputParameters.debug", Kind: jsondiff.Removed, Old: "true"}},
+		},
+		{
+			name: "non-default value removed",
+			def:  `{"name":"flow","version":1,"tasks":[{"name":"fetch","taskReferenceName":"fetch","type":"HTTP","inputParameters":{"url":"http://a","debug":true}}]}`,
+			want: []jsondiff.Change{{Path: "timeoutSeconds", Kind: jsondiff.Removed, Old: "60"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources := []Resource{{Kind: KindWorkflow, Name: "flow", Version: 1, Definition: json.RawMessage(tt.def)}}
+			steps, err := Plan(context.Background(), srv, resources, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantAction := ActionUpdate
+			if tt.want == nil {
+				wantAction = ActionUnchanged
+			}
+			if steps[0].Action != wantAction || !slices.Equal(steps[0].Changes, tt.want) {
+				t.Errorf("step = %s %+v, want %s %+v", steps[0].Action, steps[0].Changes, wantAction, tt.want)
+			}
+		})
+	}
+}
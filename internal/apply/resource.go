@@ -0,0 +1,250 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package apply makes a Conductor server match a set of declarative resource
+// files: task definitions, workflows, event handlers, schedules, webhooks and API
+// gateway services and auth configs. Each document's kind is detected from its
+// keys; Plan compares the documents with the server and Apply upserts them in
+// dependency order, pruning labelled resources the files no longer declare. File
+// access stays in the cmd layer, which passes each file's bytes to Parse.
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the type of a resource.
+type Kind string
+
+const (
+	KindTaskDef           Kind = "taskDef"
+	KindWorkflow          Kind = "workflow"
+	KindEventHandler      Kind = "eventHandler"
+	KindSchedule          Kind = "schedule"
+	KindWebhook           Kind = "webhook"
+	KindGatewayAuthConfig Kind = "gatewayAuthConfig"
+	KindGatewayService    Kind = "gatewayService"
+)
+
+// Kinds lists every kind in dependency order: a workflow's tasks are defined
+// before it, the workflows event handlers, schedules, webhooks and gateway routes
+// start exist before them, and a gateway service's auth config before the service.
+var Kinds = []Kind{KindTaskDef, KindWorkflow, KindEventHandler, KindSchedule, KindWebhook, KindGatewayAuthConfig, KindGatewayService}
+
+// Keys that identify a resource.
+const (
+	keyName    = "name"
+	keyID      = "id"
+	keyVersion = "version"
+	keyTags    = "tags"
+)
+
+// defaultWorkflowVersion is the version the server gives a workflow definition
+// that does not set one.
+const defaultWorkflowVersion = 1
+
+// Keys whose presence marks a document's kind. A gateway service is the only kind
+// with both an id and a path.
+var (
+	workflowKeys     = []string{"tasks"}
+	eventHandlerKeys = []string{"event", "actions"}
+	scheduleKeys     = []string{"cronExpression"}
+	webhookKeys      = []string{"sourcePlatform", "verifier", "receiverWorkflowNamesToVersions", "workflowsToStart"}
+	gatewayAuthKeys  = []string{"authenticationType"}
+	gatewayKeys      = []string{keyID, "path"}
+	taskDefKeys      = []string{"retryCount", "retryLogic", "retryDelaySeconds", "timeoutSeconds", "timeoutPolicy",
+		"responseTimeoutSeconds", "pollTimeoutSeconds", "concurrentExecLimit", "ownerEmail", "inputKeys",
+		"outputKeys", "rateLimitPerFrequency", "rateLimitFrequencyInSeconds"}
+)
+
+// Resource is one document of a resource file. Version is set for workflows only.
+type Resource struct {
+	Kind       Kind
+	Name       string
+	Version    int
+	Source     string
+	Definition json.RawMessage
+}
+
+func (r Resource) String() string {
+	if r.Kind == KindWorkflow {
+		return fmt.Sprintf("%s %q v%d", r.Kind, r.Name, r.Version)
+	}
+	return fmt.Sprintf("%s %q", r.Kind, r.Name)
+}
+
+// Parse reads the resources in one file. The file is YAML or JSON and may hold
+// several YAML documents, each a resource or a list of resources, so an exported
+// list of task definitions applies as it is. source names the file in errors.
+func Parse(source string, data []byte) ([]Resource, error) {
+	var out []Resource
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc any
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		docs, ok := doc.([]any)
+		if !ok {
+			docs = []any{doc}
+		}
+		for _, d := range docs {
+			if d == nil {
+				continue
+			}
+			r, err := newResource(source, d)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// ResourceOf describes a definition of a known kind, as the server returned it.
+func ResourceOf(kind Kind, def json.RawMessage) Resource {
+	return Resource{Kind: kind, Name: nameOf(kind, def), Version: versionOf(kind, def), Definition: def}
+}
+
+func newResource(source string, doc any) (Resource, error) {
+	if _, ok := doc.(map[string]any); !ok {
+		return Resource{}, fmt.Errorf("%s: a resource must be an object, not %T", source, doc)
+	}
+	def, err := json.Marshal(doc)
+	if err != nil {
+		return Resource{}, fmt.Errorf("%s: %w", source, err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(def, &fields); err != nil {
+		return Resource{}, fmt.Errorf("%s: %w", source, err)
+	}
+	kind, err := Detect(fields)
+	if err != nil {
+		return Resource{}, fmt.Errorf("%s: %w", source, err)
+	}
+	r := Resource{Kind: kind, Name: nameOf(kind, def), Version: versionOf(kind, def), Source: source, Definition: def}
+	if r.Name == "" {
+		return Resource{}, fmt.Errorf("%s: %s has no %s", source, kind, nameKey(kind))
+	}
+	return r, nil
+}
+
+// Detect tells a resource's kind from its keys: tasks make a workflow, an event
+// and actions an event handler, a cron expression a schedule, a source platform,
+// verifier or workflow map a webhook, an authentication type a gateway auth
+// config, an id and a path a gateway service, and a name with task settings such
+// as retryCount or timeoutSeconds a task definition.
+func Detect(fields map[string]json.RawMessage) (Kind, error) {
+	switch {
+	case hasAny(fields, workflowKeys):
+		return KindWorkflow, nil
+	case hasAll(fields, eventHandlerKeys):
+		return KindEventHandler, nil
+	case hasAny(fields, scheduleKeys):
+		return KindSchedule, nil
+	case hasAny(fields, webhookKeys):
+		return KindWebhook, nil
+	case hasAny(fields, gatewayAuthKeys):
+		return KindGatewayAuthConfig, nil
+	case hasAll(fields, gatewayKeys):
+		return KindGatewayService, nil
+	case hasAll(fields, []string{keyName}) && hasAny(fields, taskDefKeys):
+		return KindTaskDef, nil
+	}
+	return "", errors.New("cannot tell the resource's kind: expected a workflow (tasks), event handler (event and actions), " +
+		"schedule (cronExpression), webhook (sourcePlatform), gateway auth config (authenticationType), " +
+		"gateway service (id and path) or task definition (name and retryCount, timeoutSeconds, …)")
+}
+
+func hasAny(fields map[string]json.RawMessage, keys []string) bool {
+	for _, k := range keys {
+		if _, ok := fields[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAll(fields map[string]json.RawMessage, keys []string) bool {
+	for _, k := range keys {
+		if _, ok := fields[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Order sorts resources into dependency order, keeping file order within a kind,
+// and rejects a resource declared twice.
+func Order(resources []Resource) ([]Resource, error) {
+	rank := map[Kind]int{}
+	for i, k := range Kinds {
+		rank[k] = i
+	}
+	out := append([]Resource(nil), resources...)
+	sort.SliceStable(out, func(i, j int) bool { return rank[out[i].Kind] < rank[out[j].Kind] })
+
+	seen := map[string]string{}
+	for _, r := range out {
+		key := r.String()
+		if prev, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%s is declared in both %s and %s", key, prev, r.Source)
+		}
+		seen[key] = r.Source
+	}
+	return out, nil
+}
+
+func nameKey(kind Kind) string {
+	if kind == KindGatewayService || kind == KindGatewayAuthConfig {
+		return keyID
+	}
+	return keyName
+}
+
+// nameOf is the key a resource is addressed by: a gateway service's or auth
+// config's id, else its name.
+func nameOf(kind Kind, def json.RawMessage) string {
+	var fields map[string]json.RawMessage
+	var name string
+	if json.Unmarshal(def, &fields) == nil {
+		_ = json.Unmarshal(fields[nameKey(kind)], &name)
+	}
+	return strings.TrimSpace(name)
+}
+
+func versionOf(kind Kind, def json.RawMessage) int {
+	if kind != KindWorkflow {
+		return 0
+	}
+	var probe struct {
+		Version int `json:"version"`
+	}
+	if json.Unmarshal(def, &probe) != nil || probe.Version == 0 {
+		return defaultWorkflowVersion
+	}
+	return probe.Version
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apply
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseDetectsEachKind(t *testing.T) {
+	data := `
+name: order_flow
+version: 2
+tasks: []
+---
+- name: charge
+  retryCount: 3
+- name: refund
+  timeoutSeconds: 60
+---
+name: nightly
+cronExpression: "0 0 * * * ?"
+startWorkflowRequest: {name: order_flow}
+---
+name: stripe
+sourcePlatform: Stripe
+---
+{"id": "orders", "path": "/orders"}
+---
+{"id": "keys", "authenticationType": "API_KEY"}
+---
+name: on_payment
+event: "kafka:payments"
+actions: []
+`
+	resources, err := Parse("resources.yaml", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`workflow "order_flow" v2`, `taskDef "charge"`, `taskDef "refund"`,
+		`schedule "nightly"`, `webhook "stripe"`, `gatewayService "orders"`, `gatewayAuthConfig "keys"`,
+		`eventHandler "on_payment"`}
+	if len(resources) != len(want) {
+		t.Fatalf("got %d resources, want %d", len(resources), len(want))
+	}
+	for i, r := range resources {
+		if r.String() != want[i] || r.Source != "resources.yaml" {
+			t.Errorf("resource %d = %s from %s, want %s", i, r, r.Source, want[i])
+		}
+	}
+}
+
+func TestParseRejectsUnknownAndUnnamed(t *testing.T) {
+	tests := []struct{ doc, wantErr string }{
+		{`{"name": "x", "color": "blue"}`, "cannot tell the resource's kind"},
+		{`{"tasks": []}`, "workflow has no name"},
+		{`[1, 2]`, "must be an object"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse("f.json", []byte(tt.doc)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Parse(%s) err = %v, want it to mention %q", tt.doc, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOrderSortsByDependencyAndRejectsDuplicates(t *testing.T) {
+	resources := []Resource{
+		{Kind: KindSchedule, Name: "nightly", Source: "a"},
+		{Kind: KindWorkflow, Name: "flow", Version: 1, Source: "a"},
+		{Kind: KindTaskDef, Name: "charge", Source: "b"},
+		{Kind: KindWorkflow, Name: "flow", Version: 2, Source: "b"},
+	}
+	ordered, err := Order(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range ordered {
+		got = append(got, r.String())
+	}
+	if want := `taskDef "charge",workflow "flow" v1,workflow "flow" v2,schedule "nightly"`; strings.Join(got, ",") != want {
+		t.Errorf("order = %s, want %s", strings.Join(got, ","), want)
+	}
+
+	_, err = Order(append(resources, Resource{Kind: KindTaskDef, Name: "charge", Source: "c"}))
+	if err == nil || !strings.Contains(err.Error(), `taskDef "charge" is declared in both b and c`) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestWithLabelKeepsOtherTags(t *testing.T) {
+	l, err := ParseLabel("app=billing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := withLabel(json.RawMessage(`{"name":"x","tags":[{"key":"team","value":"pay"}]}`), l, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasLabel(def, l) || !hasLabel(def, Label{Key: "team", Value: "pay"}) {
+		t.Errorf("labelled definition = %s", def)
+	}
+	if again, _ := withLabel(def, l, nil); string(again) != string(def) {
+		t.Errorf("labelling twice changed the definition: %s", again)
+	}
+	if _, err := ParseLabel("app"); err == nil {
+		t.Error("a label without a value was accepted")
+	}
+}
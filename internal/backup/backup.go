@@ -0,0 +1,216 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package backup snapshots a server's metadata into a directory of JSON files and
+// restores it: every version of every workflow definition, task definitions,
+// event handlers, schedules, webhooks and gateway configs, listed in a manifest
+// with the names of the server's secrets, whose values are never exported. It
+// reads and writes the server through apply.Server; the cmd layer owns the files.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/apply"
+)
+
+// FormatVersion is written to every manifest; Load rejects snapshots from a newer
+// format.
+const FormatVersion = 1
+
+// ManifestFile is the snapshot's table of contents, at its root.
+const ManifestFile = "manifest.json"
+
+// SectionSecrets selects the secret names, which have no files of their own.
+const SectionSecrets = "secrets"
+
+// dirs are the snapshot directories each kind is stored under. Their first
+// element is the section --only selects them by.
+var dirs = map[apply.Kind]string{
+	apply.KindTaskDef:           "tasks",
+	apply.KindWorkflow:          "workflows",
+	apply.KindEventHandler:      "events",
+	apply.KindSchedule:          "schedules",
+	apply.KindWebhook:           "webhooks",
+	apply.KindGatewayAuthConfig: "gateway/auth",
+	apply.KindGatewayService:    "gateway/services",
+}
+
+// Sections lists what a snapshot can hold, in restore order.
+var Sections = []string{"tasks", "workflows", "events", "schedules", "webhooks", "gateway", SectionSecrets}
+
+// Server is where a snapshot is taken from or restored to.
+type Server interface {
+	apply.Server
+	SecretNames(ctx context.Context) ([]string, error)
+}
+
+// Snapshot is a server's metadata: its resources in dependency order and the
+// names of its secrets.
+type Snapshot struct {
+	Source    string
+	CreatedAt time.Time
+	Resources []apply.Resource
+	Secrets   []string
+}
+
+// Manifest lists a snapshot's files, in dependency order.
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	Source        string    `json:"source,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Entries       []Entry   `json:"entries"`
+	Secrets       []string  `json:"secrets,omitempty"`
+}
+
+// Entry locates one resource in a snapshot.
+type Entry struct {
+	Kind    apply.Kind `json:"kind"`
+	Name    string     `json:"name"`
+	Version int        `json:"version,omitempty"`
+	Path    string     `json:"path"`
+}
+
+// SectionOf is the section a kind belongs to.
+func SectionOf(kind apply.Kind) string {
+	section, _, _ := strings.Cut(dirs[kind], "/")
+	return section
+}
+
+// ParseSections checks the sections --only names; none means all of them.
+func ParseSections(names []string) (map[string]bool, error) {
+	selected := map[string]bool{}
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if !slices.Contains(Sections, n) {
+			return nil, fmt.Errorf("unknown section %q; expected one of %s", n, strings.Join(Sections, ", "))
+		}
+		selected[n] = true
+	}
+	if len(selected) == 0 {
+		for _, s := range Sections {
+			selected[s] = true
+		}
+	}
+	return selected, nil
+}
+
+// Take reads the selected sections from src: every resource of their kinds,
+// sorted by name and version, and with secrets the secret names.
+func Take(ctx context.Context, src Server, sections map[string]bool) (Snapshot, error) {
+	var s Snapshot
+	for _, kind := range apply.Kinds {
+		if !sections[SectionOf(kind)] {
+			continue
+		}
+		defs, err := src.List(ctx, kind)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("list %ss: %w", kind, err)
+		}
+		resources := make([]apply.Resource, 0, len(defs))
+		for _, def := range defs {
+			if r := apply.ResourceOf(kind, def); r.Name != "" {
+				resources = append(resources, r)
+			}
+		}
+		sort.SliceStable(resources, func(i, j int) bool {
+			if resources[i].Name != resources[j].Name {
+				return resources[i].Name < resources[j].Name
+			}
+			return resources[i].Version < resources[j].Version
+		})
+		s.Resources = append(s.Resources, resources...)
+	}
+	if sections[SectionSecrets] {
+		names, err := src.SecretNames(ctx)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("list secrets: %w", err)
+		}
+		s.Secrets = slices.Sorted(slices.Values(names))
+	}
+	return s, nil
+}
+
+// entryPath is where a resource is stored: its kind's directory, then its name
+// escaped for use as a file name, with a workflow's version.
+func entryPath(r apply.Resource) string {
+	name := url.PathEscape(r.Name)
+	if r.Kind == apply.KindWorkflow {
+		name = fmt.Sprintf("%s.v%d", name, r.Version)
+	}
+	return path.Join(dirs[r.Kind], name+".json")
+}
+
+// Files renders s as the files of a snapshot directory, keyed by slash-separated
+// path: the manifest and one indented JSON file per resource.
+func (s Snapshot) Files() (map[string][]byte, error) {
+	m := Manifest{FormatVersion: FormatVersion, Source: s.Source, CreatedAt: s.CreatedAt.UTC(), Entries: []Entry{}, Secrets: s.Secrets}
+	files := map[string][]byte{}
+	for _, r := range s.Resources {
+		p := entryPath(r)
+		if _, dup := files[p]; dup {
+			return nil, fmt.Errorf("%s and another resource would both be stored as %s", r, p)
+		}
+		data, err := json.MarshalIndent(r.Definition, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r, err)
+		}
+		files[p] = append(data, '\n')
+		m.Entries = append(m.Entries, Entry{Kind: r.Kind, Name: r.Name, Version: r.Version, Path: p})
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files[ManifestFile] = append(manifest, '\n')
+	return files, nil
+}
+
+// Load reads a snapshot written from Files, its resources in manifest order.
+func Load(fsys fs.FS) (Snapshot, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("not a snapshot: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Snapshot{}, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	if m.FormatVersion > FormatVersion {
+		return Snapshot{}, fmt.Errorf("snapshot format %d is newer than this CLI supports (%d); upgrade the CLI", m.FormatVersion, FormatVersion)
+	}
+	s := Snapshot{Source: m.Source, CreatedAt: m.CreatedAt, Secrets: m.Secrets}
+	for _, e := range m.Entries {
+		if _, ok := dirs[e.Kind]; !ok {
+			return Snapshot{}, fmt.Errorf("%s: unknown kind %q", ManifestFile, e.Kind)
+		}
+		def, err := fs.ReadFile(fsys, e.Path)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("%s %q: %w", e.Kind, e.Name, err)
+		}
+		if !json.Valid(def) {
+			return Snapshot{}, fmt.Errorf("%s is not valid JSON", e.Path)
+		}
+		s.Resources = append(s.Resources, apply.Resource{Kind: e.Kind, Name: e.Name, Version: e.Version, Source: e.Path, Definition: def})
+	}
+	return s, nil
+}
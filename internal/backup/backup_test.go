@@ -0,0 +1,126 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/apply"
+)
+
+// fakeServer holds definitions by kind and secret names, and records writes.
+type fakeServer struct {
+	defs    map[apply.Kind][]json.RawMessage
+	secrets []string
+	writes  []string
+}
+
+func (f *fakeServer) Get(_ context.Context, kind apply.Kind, name string, version int) (json.RawMessage, bool, error) {
+	for _, def := range f.defs[kind] {
+		if r := apply.ResourceOf(kind, def); r.Name == name && r.Version == version {
+			return def, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (f *fakeServer) List(_ context.Context, kind apply.Kind) ([]json.RawMessage, error) {
+	return f.defs[kind], nil
+}
+
+func (f *fakeServer) Put(_ context.Context, kind apply.Kind, def, current json.RawMessage) error {
+	f.writes = append(f.writes, fmt.Sprintf("%s %s (existed: %t)", kind, apply.ResourceOf(kind, def).Name, current != nil))
+	return nil
+}
+
+func (f *fakeServer) Delete(context.Context, apply.Kind, json.RawMessage) error { return nil }
+
+func (f *fakeServer) SecretNames(context.Context) ([]string, error) { return f.secrets, nil }
+
+func TestTakeWritesAndLoadsEveryVersion(t *testing.T) {
+	src := &fakeServer{
+		defs: map[apply.Kind][]json.RawMessage{
+			apply.KindWorkflow: {
+				json.RawMessage(`{"name":"flow","version":2,"tasks":[]}`),
+				json.RawMessage(`{"name":"flow","version":1,"tasks":[]}`),
+			},
+			apply.KindTaskDef:        {json.RawMessage(`{"name":"a/b","retryCount":1}`)},
+			apply.KindGatewayService: {json.RawMessage(`{"id":"orders","path":"/orders"}`)},
+		},
+		secrets: []string{"stripe_key", "db_password"},
+	}
+	sections, err := ParseSections(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Take(context.Background(), src, sections)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.CreatedAt = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	files, err := s.Files()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{}
+	for p, data := range files {
+		fsys[p] = &fstest.MapFile{Data: data}
+	}
+	for _, p := range []string{ManifestFile, "tasks/a%2Fb.json", "workflows/flow.v1.json", "workflows/flow.v2.json", "gateway/services/orders.json"} {
+		if _, ok := fsys[p]; !ok {
+			t.Errorf("snapshot has no %s", p)
+		}
+	}
+
+	loaded, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range loaded.Resources {
+		got = append(got, r.String())
+	}
+	want := []string{`taskDef "a/b"`, `workflow "flow" v1`, `workflow "flow" v2`, `gatewayService "orders"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resources = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(loaded.Secrets, []string{"db_password", "stripe_key"}) || !loaded.CreatedAt.Equal(s.CreatedAt) {
+		t.Errorf("secrets = %q, created %s", loaded.Secrets, loaded.CreatedAt)
+	}
+}
+
+func TestLoadRejectsNewerFormat(t *testing.T) {
+	fsys := fstest.MapFS{ManifestFile: {Data: []byte(`{"formatVersion": 99, "entries": []}`)}}
+	if _, err := Load(fsys); err == nil || !strings.Contains(err.Error(), "upgrade the CLI") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestParseSections(t *testing.T) {
+	got, err := ParseSections([]string{"Workflows", "gateway"})
+	if err != nil || !reflect.DeepEqual(got, map[string]bool{"workflows": true, "gateway": true}) {
+		t.Errorf("ParseSections = %v, %v", got, err)
+	}
+	if _, err := ParseSections([]string{"agents"}); err == nil || !strings.Contains(err.Error(), "unknown section") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/conductor-oss/conductor-cli/internal/apply"
+	"github.com/conductor-oss/conductor-cli/internal/bundle"
+)
+
+// Conflict says what restoring does with a resource the target already has
+// with a different definition.
+type Conflict string
+
+const (
+	ConflictSkip      Conflict = "skip"
+	ConflictOverwrite Conflict = "overwrite"
+)
+
+// ParseConflict reads a --conflict value.
+func ParseConflict(s string) (Conflict, error) {
+	switch c := Conflict(strings.ToLower(s)); c {
+	case ConflictSkip, ConflictOverwrite:
+		return c, nil
+	}
+	return "", fmt.Errorf("--conflict must be %s or %s, not %q", ConflictSkip, ConflictOverwrite, s)
+}
+
+// keyWebhookID is a webhook's id, which the server holding it assigns, so it
+// differs between servers holding the same webhook. Keys every server stamps are
+// jsondiff.ServerManagedKeys, which bundle.DifferingKeys ignores already.
+const keyWebhookID = "id"
+
+// RestoreOptions selects the sections to restore, what to do on a conflict, and
+// whether to only report what would happen.
+type RestoreOptions struct {
+	Sections map[string]bool
+	Conflict Conflict
+	DryRun   bool
+}
+
+// Restore recreates a snapshot's resources on dst in dependency order. Resources
+// dst has unchanged are left alone; different ones are conflicts, or overwritten
+// with opts.Conflict set to overwrite. It stops at the first failed write. With secrets selected
+// it also returns the snapshot's secret names dst lacks: their values were never
+// exported, so they have to be set again by hand.
+func Restore(ctx context.Context, dst Server, s Snapshot, opts RestoreOptions) (results []bundle.ImportResult, missingSecrets []string, err error) {
+	resources, err := apply.Order(s.Resources)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range resources {
+		if !opts.Sections[SectionOf(r.Kind)] {
+			continue
+		}
+		res, err := restoreResource(ctx, dst, r, opts)
+		if err != nil {
+			return results, nil, fmt.Errorf("restore %s: %w", r, err)
+		}
+		results = append(results, res)
+	}
+	if !opts.Sections[SectionSecrets] || len(s.Secrets) == 0 {
+		return results, nil, nil
+	}
+	existing, err := dst.SecretNames(ctx)
+	if err != nil {
+		return results, nil, fmt.Errorf("list secrets: %w", err)
+	}
+	for _, name := range s.Secrets {
+		if !slices.Contains(existing, name) {
+			missingSecrets = append(missingSecrets, name)
+		}
+	}
+	return results, missingSecrets, nil
+}
+
+func restoreResource(ctx context.Context, dst Server, r apply.Resource, opts RestoreOptions) (bundle.ImportResult, error) {
+	res := bundle.ImportResult{Kind: string(r.Kind), Name: r.Name, Version: r.Version}
+	current, found, err := dst.Get(ctx, r.Kind, r.Name, r.Version)
+	if err != nil {
+		return res, err
+	}
+	if found {
+		var ignore []string
+		if r.Kind == apply.KindWebhook {
+			ignore = append(ignore, keyWebhookID)
+		}
+		res.Differences, err = bundle.DifferingKeys(current, r.Definition, ignore...)
+		if err != nil {
+			return res, err
+		}
+	}
+	switch {
+	case !found:
+		res.Outcome = bundle.OutcomeCreated
+	case len(res.Differences) == 0:
+		res.Outcome = bundle.OutcomeUnchanged
+	case opts.Conflict == ConflictOverwrite:
+		res.Outcome = bundle.OutcomeUpdated
+	default:
+		res.Outcome = bundle.OutcomeConflict
+	}
+	if opts.DryRun || (res.Outcome != bundle.OutcomeCreated && res.Outcome != bundle.OutcomeUpdated) {
+		return res, nil
+	}
+	return res, dst.Put(ctx, r.Kind, r.Definition, current)
+}
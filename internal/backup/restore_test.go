@@ -0,0 +1,82 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/apply"
+	"github.com/conductor-oss/conductor-cli/internal/bundle"
+)
+
+func TestRestore(t *testing.T) {
+	dst := &fakeServer{
+		defs: map[apply.Kind][]json.RawMessage{
+			apply.KindTaskDef: {
+				json.RawMessage(`{"name":"same","retryCount":1,"ownerApp":"other"}`),
+				json.RawMessage(`{"name":"changed","retryCount":1}`),
+			},
+		},
+		secrets: []string{"db_password"},
+	}
+	s := Snapshot{
+		Resources: []apply.Resource{
+			{Kind: apply.KindSchedule, Name: "nightly", Definition: json.RawMessage(`{"name":"nightly","cronExpression":"0 0 * * * ?"}`)},
+			apply.ResourceOf(apply.KindTaskDef, json.RawMessage(`{"name":"same","retryCount":1,"ownerApp":"mine"}`)),
+			apply.ResourceOf(apply.KindTaskDef, json.RawMessage(`{"name":"changed","retryCount":5}`)),
+			apply.ResourceOf(apply.KindWorkflow, json.RawMessage(`{"name":"flow","version":3,"tasks":[]}`)),
+		},
+		Secrets: []string{"db_password", "stripe_key"},
+	}
+	all, _ := ParseSections(nil)
+
+	results, missing, err := Restore(context.Background(), dst, s, RestoreOptions{Sections: all, Conflict: ConflictSkip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bundle.ImportResult{
+		{Kind: string(apply.KindTaskDef), Name: "same", Outcome: bundle.OutcomeUnchanged},
+		{Kind: string(apply.KindTaskDef), Name: "changed", Outcome: bundle.OutcomeConflict, Differences: []string{"retryCount"}},
+		{Kind: string(apply.KindWorkflow), Name: "flow", Version: 3, Outcome: bundle.OutcomeCreated},
+		{Kind: string(apply.KindSchedule), Name: "nightly", Outcome: bundle.OutcomeCreated},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results = %+v\nwant %+v", results, want)
+	}
+	if !reflect.DeepEqual(missing, []string{"stripe_key"}) {
+		t.Errorf("missing secrets = %q", missing)
+	}
+	if want := []string{"workflow flow (existed: false)", "schedule nightly (existed: false)"}; !reflect.DeepEqual(dst.writes, want) {
+		t.Errorf("writes = %q, want %q", dst.writes, want)
+	}
+	if got := bundle.Summary(results); got != "2 created, 1 unchanged, 1 conflict" {
+		t.Errorf("summary = %q", got)
+	}
+
+	dst.writes = nil
+	only, _ := ParseSections([]string{"tasks"})
+	results, _, err = Restore(context.Background(), dst, s, RestoreOptions{Sections: only, Conflict: ConflictOverwrite})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[1].Outcome != bundle.OutcomeUpdated {
+		t.Errorf("results = %+v", results)
+	}
+	if want := []string{"taskDef changed (existed: true)"}; !reflect.DeepEqual(dst.writes, want) {
+		t.Errorf("writes = %q, want %q", dst.writes, want)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package batch runs one agent execution per line of a prompts file and reports
+// how each one ended.
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxLineBytes bounds one line of a prompts file.
+const maxLineBytes = 1 << 20
+
+// placeholder matches {{name}} in a prompt, with optional spaces inside the braces.
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// Item is one line of a prompts file. A line is either a JSON string, the prompt,
+// or an object whose prompt may reference its variables as {{name}}.
+type Item struct {
+	Line      int               `json:"-"`
+	ID        string            `json:"id,omitempty"`
+	Prompt    string            `json:"prompt"`
+	SessionID string            `json:"session,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// Parse reads a prompts file. Blank lines are skipped; every error names its line.
+// Prompts come back with their variables substituted.
+func Parse(r io.Reader) ([]Item, error) {
+	var items []Item
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineBytes)
+	for n := 1; sc.Scan(); n++ {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		item, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		item.Line = n
+		items = append(items, item)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no prompts found")
+	}
+	return items, nil
+}
+
+func parseLine(line []byte) (Item, error) {
+	var item Item
+	if line[0] == '"' {
+		if err := json.Unmarshal(line, &item.Prompt); err != nil {
+			return Item{}, err
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&item); err != nil {
+			return Item{}, err
+		}
+	}
+	prompt, err := expand(item.Prompt, item.Variables)
+	if err != nil {
+		return Item{}, err
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return Item{}, fmt.Errorf("prompt is empty")
+	}
+	item.Prompt = prompt
+	return item, nil
+}
+
+// expand substitutes {{name}} placeholders. A placeholder without a variable is an
+// error rather than a literal, so a typo does not send a half-filled prompt.
+func expand(prompt string, vars map[string]string) (string, error) {
+	var missing []string
+	out := placeholder.ReplaceAllStringFunc(prompt, func(m string) string {
+		name := placeholder.FindStringSubmatch(m)[1]
+		v, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return m
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf("prompt references undefined variable(s): %s", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+func TestParse(t *testing.T) {
+	in := `"plain prompt"
+
+{"id":"t1","prompt":"Summarize {{ ticket }} for {{team}}","session":"s1","variables":{"ticket":"T-9","team":"ops"}}
+`
+	items, err := Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(items) != 2 || items[0].Prompt != "plain prompt" || items[0].Line != 1 {
+		t.Fatalf("items = %+v", items)
+	}
+	if got := items[1]; got.Line != 3 || got.ID != "t1" || got.SessionID != "s1" || got.Prompt != "Summarize T-9 for ops" {
+		t.Errorf("item 2 = %+v", got)
+	}
+
+	for name, bad := range map[string]string{
+		"missing variable": `{"prompt":"hi {{name}}"}`,
+		"unknown field":    `{"prompt":"hi","vars":{}}`,
+		"empty prompt":     `{"prompt":" "}`,
+		"not json":         `hello`,
+		"empty file":       "\n\n",
+	} {
+		_, err := Parse(strings.NewReader("\"ok\"\n" + bad))
+		if bad == "\n\n" {
+			_, err = Parse(strings.NewReader(bad))
+		}
+		if err == nil {
+			t.Errorf("%s: Parse succeeded, want an error", name)
+		} else if bad != "\n\n" && !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("%s: error %q does not name line 2", name, err)
+		}
+	}
+}
+
+// fakeService scripts executions by prompt. A prompt with no script streams
+// nothing until the context ends; "broken" makes the stream fail so the runner
+// polls Status.
+type fakeService struct {
+	mu        sync.Mutex
+	scripts   map[string][]agent.SSEEvent
+	prompts   map[string]string
+	sessions  map[string]string
+	responded []string
+	running   int
+	maxRun    int
+}
+
+func (f *fakeService) Run(ctx context.Context, req agent.RunRequest) (agent.Execution, error) {
+	if req.Prompt == "refused" {
+		return agent.Execution{}, errors.New("400 bad request")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := fmt.Sprintf("e%d", len(f.prompts)+1)
+	f.prompts[id] = req.Prompt
+	f.sessions[id] = req.SessionID
+	return agent.Execution{ID: id}, nil
+}
+
+func (f *fakeService) StreamExecution(ctx context.Context, id, _ string, sink agent.EventSink) error {
+	f.mu.Lock()
+	prompt := f.prompts[id]
+	script, ok := f.scripts[prompt]
+	f.running++
+	f.maxRun = max(f.maxRun, f.running)
+	f.mu.Unlock()
+	defer func() { f.mu.Lock(); f.running--; f.mu.Unlock() }()
+
+	time.Sleep(5 * time.Millisecond)
+	if prompt == "broken" {
+		return errors.New("stream closed before the execution finished")
+	}
+	for _, e := range script {
+		if err := sink.OnEvent(e); err != nil {
+			return err
+		}
+		if e.ResolvedType().IsTerminal() {
+			return nil
+		}
+	}
+	if ok {
+		return nil
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (f *fakeService) Status(ctx context.Context, id string) (json.RawMessage, error) {
+	return json.RawMessage(`{"status":"COMPLETED","output":{"answer":42}}`), nil
+}
+
+func (f *fakeService) Respond(ctx context.Context, id string, r agent.HumanResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responded = append(f.responded, id)
+	return nil
+}
+
+func ev(typ agent.EventType, data string) agent.SSEEvent {
+	return agent.SSEEvent{Type: typ, Data: []byte(data)}
+}
+
+func TestRunnerTracksEachExecution(t *testing.T) {
+	fs := &fakeService{
+		prompts:  map[string]string{},
+		sessions: map[string]string{},
+		scripts: map[string][]agent.SSEEvent{
+			"ok":     {ev(agent.EventThinking, `{"content":"hm"}`), ev(agent.EventDone, `{"output":"fine"}`)},
+			"fails":  {ev(agent.EventError, `{"content":"model unavailable"}`)},
+			"asks":   {ev(agent.EventWaiting, `{"pendingTool":{"name":"delete_user"}}`)},
+			"refund": {ev(agent.EventWaiting, `{"pendingTool":{"name":"refund"}}`), ev(agent.EventDone, `{"output":"refunded"}`)},
+		},
+	}
+	items := []Item{
+		{Line: 1, Prompt: "ok", SessionID: "s1"},
+		{Line: 2, Prompt: "fails"},
+		{Line: 3, Prompt: "asks"},
+		{Line: 4, Prompt: "refund"},
+		{Line: 5, Prompt: "refused"},
+		{Line: 6, Prompt: "broken"},
+		{Line: 7, Prompt: "hangs"},
+	}
+	var order []int
+	r := &Runner{
+		Service:     fs,
+		Concurrency: 2,
+		Timeout:     200 * time.Millisecond,
+		Approve:     []string{"refund"},
+		OnResult:    func(res Result) { order = append(order, res.Line) },
+		wait:        func(context.Context, time.Duration) error { return nil },
+	}
+	results := r.Run(context.Background(), agent.RunRequest{Name: "triage", SessionID: "base"}, items)
+
+	want := []string{StatusCompleted, StatusFailed, StatusWaiting, StatusCompleted, StatusNotStarted, StatusCompleted, StatusTimedOut}
+	for i, res := range results {
+		if res.Line != items[i].Line || res.Status != want[i] {
+			t.Errorf("result %d = %+v, want status %s", i, res, want[i])
+		}
+	}
+	if string(results[0].Output) != `"fine"` || string(results[5].Output) != `{"answer":42}` {
+		t.Errorf("outputs = %s, %s", results[0].Output, results[5].Output)
+	}
+	if results[1].Error != "model unavailable" || !strings.Contains(results[2].Error, "delete_user") {
+		t.Errorf("errors = %q, %q", results[1].Error, results[2].Error)
+	}
+	if got := fs.sessions[results[0].ExecutionID]; got != "s1" {
+		t.Errorf("session = %q, want the item's session over the base one", got)
+	}
+	if got := fs.sessions[results[1].ExecutionID]; got != "base" {
+		t.Errorf("session = %q, want the base session", got)
+	}
+	if len(fs.responded) != 1 || len(order) != len(items) {
+		t.Errorf("responded=%v order=%v", fs.responded, order)
+	}
+	if fs.maxRun > 2 {
+		t.Errorf("%d streams ran at once, want at most 2", fs.maxRun)
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	data, err := json.Marshal(Result{Line: 3, ExecutionID: "e1", Status: StatusCompleted, Output: agent.RawValue(`{"a":1}`), DurationMs: 1500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"line":3,"executionId":"e1","status":"COMPLETED","output":{"a":1},"durationMs":1500}`
+	if string(data) != want {
+		t.Errorf("Result JSON = %s, want %s", data, want)
+	}
+}
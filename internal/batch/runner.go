@@ -0,0 +1,212 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// DefaultPollInterval spaces Status calls for an execution whose stream failed.
+const DefaultPollInterval = 5 * time.Second
+
+// approveReason is recorded on responses the batch sends for approved tools.
+const approveReason = "approved by agent run --batch"
+
+// Result statuses. The terminal ones mirror the server's execution statuses;
+// the rest say why the batch stopped tracking an execution.
+const (
+	StatusCompleted  = "COMPLETED"
+	StatusFailed     = "FAILED"
+	StatusTerminated = "TERMINATED"
+	StatusTimedOut   = "TIMED_OUT"
+	// StatusWaiting: the execution asked for human input the batch does not
+	// approve; it is left pending for 'agent respond'.
+	StatusWaiting = "WAITING"
+	// StatusNotStarted: the server refused to start the execution.
+	StatusNotStarted = "NOT_STARTED"
+	// StatusCancelled: the batch was interrupted before the execution finished.
+	StatusCancelled = "CANCELLED"
+)
+
+// Service is the slice of agent.Service a batch needs.
+type Service interface {
+	Run(ctx context.Context, req agent.RunRequest) (agent.Execution, error)
+	StreamExecution(ctx context.Context, executionID, lastEventID string, sink agent.EventSink) error
+	Status(ctx context.Context, id string) (json.RawMessage, error)
+	Respond(ctx context.Context, id string, resp agent.HumanResponse) error
+}
+
+// Result is how one prompt's execution ended.
+type Result struct {
+	Line        int            `json:"line"`
+	ID          string         `json:"id,omitempty"`
+	ExecutionID string         `json:"executionId,omitempty"`
+	Status      string         `json:"status"`
+	Output      agent.RawValue `json:"output,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	Duration    time.Duration  `json:"-"`
+	DurationMs  int64          `json:"durationMs"`
+}
+
+// OK reports whether the execution completed.
+func (r Result) OK() bool { return r.Status == StatusCompleted }
+
+// Runner starts and tracks the executions of a batch.
+type Runner struct {
+	Service     Service
+	Concurrency int
+	// Timeout bounds each execution from start to finish; zero means no limit.
+	Timeout time.Duration
+	// Approve lists tools whose human-input requests are approved automatically.
+	Approve []string
+	// PollInterval spaces Status calls once streaming fails; zero means
+	// DefaultPollInterval.
+	PollInterval time.Duration
+	// OnResult, when set, is called as each execution finishes, in completion
+	// order and never concurrently.
+	OnResult func(Result)
+	now      func() time.Time
+	wait     func(ctx context.Context, d time.Duration) error
+}
+
+// Run starts one execution per item from base (whose Name or Definition picks the
+// agent), at most Concurrency at a time, and returns the results in item order.
+func (r *Runner) Run(ctx context.Context, base agent.RunRequest, items []Item) []Result {
+	if r.now == nil {
+		r.now = time.Now
+	}
+	if r.wait == nil {
+		r.wait = agent.SleepContext
+	}
+	return agent.RunEach(len(items), r.Concurrency, func(i int) Result {
+		res := r.runItem(ctx, base, items[i])
+		res.DurationMs = res.Duration.Milliseconds()
+		return res
+	}, r.OnResult)
+}
+
+func (r *Runner) runItem(ctx context.Context, base agent.RunRequest, item Item) Result {
+	res := Result{Line: item.Line, ID: item.ID}
+	if ctx.Err() != nil {
+		res.Status = StatusCancelled
+		return res
+	}
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	start := r.now()
+	req := base
+	req.Prompt = item.Prompt
+	if item.SessionID != "" {
+		req.SessionID = item.SessionID
+	}
+	exec, err := r.Service.Run(ctx, req)
+	if err != nil {
+		res.Status, res.Error = StatusNotStarted, err.Error()
+		res.Duration = r.now().Sub(start)
+		return res
+	}
+	res.ExecutionID = exec.ID
+
+	t := &tracker{executionID: exec.ID}
+	approver := agent.NewAutoApprover(ctx, r.Service, exec.ID, r.Approve, approveReason,
+		fmt.Sprintf("answer with 'conductor agent respond %s'", exec.ID))
+	err = r.Service.StreamExecution(ctx, exec.ID, "", agent.MultiSink(t, approver))
+	if approver.Blocked != "" {
+		t.status, t.errText = StatusWaiting, approver.Blocked
+	}
+	if t.status == "" && err != nil && ctx.Err() == nil {
+		// The stream gave up, not the execution: fall back to polling.
+		r.poll(ctx, t)
+	}
+	res.Duration = r.now().Sub(start)
+
+	switch {
+	case t.status != "":
+		res.Status, res.Output, res.Error = t.status, t.output, t.errText
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		res.Status, res.Error = StatusTimedOut, fmt.Sprintf("no result after %s", r.Timeout)
+	case ctx.Err() != nil:
+		res.Status = StatusCancelled
+	case err != nil:
+		res.Status, res.Error = StatusFailed, err.Error()
+	default:
+		res.Status, res.Error = StatusFailed, "stream ended before the execution finished"
+	}
+	return res
+}
+
+// statusDetail is the part of an execution's status the batch reads.
+type statusDetail struct {
+	Status                string         `json:"status"`
+	Output                agent.RawValue `json:"output"`
+	ReasonForIncompletion string         `json:"reasonForIncompletion"`
+}
+
+// poll calls Status until the execution reaches a terminal status or ctx ends.
+func (r *Runner) poll(ctx context.Context, t *tracker) {
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		raw, err := r.Service.Status(ctx, t.executionID)
+		if err == nil {
+			var d statusDetail
+			if json.Unmarshal(raw, &d) == nil && terminalStatus(d.Status) {
+				t.status, t.output, t.errText = d.Status, d.Output, d.ReasonForIncompletion
+				return
+			}
+		}
+		if r.wait(ctx, interval) != nil {
+			return
+		}
+	}
+}
+
+func terminalStatus(s string) bool {
+	switch s {
+	case StatusCompleted, StatusFailed, StatusTerminated, StatusTimedOut:
+		return true
+	}
+	return false
+}
+
+// tracker watches one execution's stream for its outcome.
+type tracker struct {
+	executionID string
+	status      string
+	output      agent.RawValue
+	errText     string
+}
+
+func (t *tracker) OnEvent(e agent.SSEEvent) error {
+	p := e.Payload()
+	switch e.ResolvedType() {
+	case agent.EventDone:
+		t.status, t.output = StatusCompleted, p.Output
+	case agent.EventError:
+		t.status, t.errText = StatusFailed, p.Content
+	}
+	return nil
+}
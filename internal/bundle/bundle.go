@@ -0,0 +1,191 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package bundle moves agents between Conductor servers. A bundle is a gzipped tar
+// of JSON definitions — agents, the task definitions of their tools and the
+// workflows they run as sub-workflows — with a manifest listing them in dependency
+// order. Export and Import work against small interfaces the cmd layer implements
+// over the agent service and the metadata API.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+)
+
+// FormatVersion is written to every manifest; Read rejects bundles from a newer
+// format.
+const FormatVersion = 1
+
+const (
+	manifestFile = "manifest.json"
+	// maxEntryBytes bounds one file read from a bundle.
+	maxEntryBytes = 16 << 20
+)
+
+// Kind is the type of a bundled definition.
+type Kind string
+
+const (
+	KindTaskDef  Kind = "taskDef"
+	KindWorkflow Kind = "workflow"
+	KindAgent    Kind = "agent"
+)
+
+// dirs are the archive directories each kind is stored under.
+var dirs = map[Kind]string{KindTaskDef: "tasks", KindWorkflow: "workflows", KindAgent: "agents"}
+
+// Item is one bundled definition. Version is 0 for task definitions, which are
+// not versioned.
+type Item struct {
+	Kind       Kind
+	Name       string
+	Version    int
+	Definition json.RawMessage
+}
+
+// Bundle is an ordered set of definitions: every item comes after the items it
+// depends on, so importing in order never references something missing.
+type Bundle struct {
+	Source    string
+	CreatedAt time.Time
+	Items     []Item
+}
+
+// Manifest is the bundle's table of contents, stored as manifest.json.
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	Source        string    `json:"source,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Entries       []Entry   `json:"entries"`
+}
+
+// Entry locates one item in the archive.
+type Entry struct {
+	Kind    Kind   `json:"kind"`
+	Name    string `json:"name"`
+	Version int    `json:"version,omitempty"`
+	Path    string `json:"path"`
+}
+
+func entryPath(it Item) string {
+	name := it.Name
+	if it.Version > 0 {
+		name = fmt.Sprintf("%s.v%d", name, it.Version)
+	}
+	return path.Join(dirs[it.Kind], name+".json")
+}
+
+// Write stores b as a gzipped tar: the manifest first, then each definition.
+func Write(w io.Writer, b Bundle) error {
+	m := Manifest{FormatVersion: FormatVersion, Source: b.Source, CreatedAt: b.CreatedAt.UTC()}
+	for _, it := range b.Items {
+		m.Entries = append(m.Entries, Entry{Kind: it.Kind, Name: it.Name, Version: it.Version, Path: entryPath(it)})
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeEntry(tw, manifestFile, manifest, m.CreatedAt); err != nil {
+		return err
+	}
+	for i, it := range b.Items {
+		if err := writeEntry(tw, m.Entries[i].Path, it.Definition, m.CreatedAt); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Read loads a bundle written by Write, returning its items in manifest order.
+func Read(r io.Reader) (Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("not a bundle (expected a .tgz): %w", err)
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Bundle{}, fmt.Errorf("read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxEntryBytes+1))
+		if err != nil {
+			return Bundle{}, fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+		if len(data) > maxEntryBytes {
+			return Bundle{}, fmt.Errorf("%s is larger than %d bytes", hdr.Name, maxEntryBytes)
+		}
+		files[hdr.Name] = data
+	}
+
+	raw, ok := files[manifestFile]
+	if !ok {
+		return Bundle{}, fmt.Errorf("bundle has no %s", manifestFile)
+	}
+	var m Manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return Bundle{}, fmt.Errorf("parse %s: %w", manifestFile, err)
+	}
+	if m.FormatVersion > FormatVersion {
+		return Bundle{}, fmt.Errorf("bundle format %d is newer than this CLI supports (%d); upgrade conductor", m.FormatVersion, FormatVersion)
+	}
+
+	b := Bundle{Source: m.Source, CreatedAt: m.CreatedAt}
+	for _, e := range m.Entries {
+		if _, ok := dirs[e.Kind]; !ok {
+			return Bundle{}, fmt.Errorf("%s: unknown kind %q for %s", manifestFile, e.Kind, e.Name)
+		}
+		def, ok := files[e.Path]
+		if !ok {
+			return Bundle{}, fmt.Errorf("%s lists %s, which the bundle does not contain", manifestFile, e.Path)
+		}
+		if !json.Valid(def) {
+			return Bundle{}, fmt.Errorf("%s is not valid JSON", e.Path)
+		}
+		b.Items = append(b.Items, Item{Kind: e.Kind, Name: e.Name, Version: e.Version, Definition: def})
+	}
+	return b, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	in := Bundle{
+		Source:    "https://dev.example.com/api",
+		CreatedAt: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+		Items: []Item{
+			{Kind: KindTaskDef, Name: "lookup_order", Definition: json.RawMessage(`{"name":"lookup_order"}`)},
+			{Kind: KindWorkflow, Name: "refund_flow", Version: 2, Definition: json.RawMessage(`{"name":"refund_flow","version":2}`)},
+			{Kind: KindAgent, Name: "triage", Version: 3, Definition: json.RawMessage(`{"name":"triage","version":3}`)},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, in); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip =\n%+v\nwant\n%+v", out, in)
+	}
+}
+
+func TestReadRejectsBadBundles(t *testing.T) {
+	if _, err := Read(strings.NewReader("plain text")); err == nil {
+		t.Error("Read accepted a file that is not gzipped")
+	}
+
+	tests := map[string]struct {
+		manifest string
+		want     string
+	}{
+		"newer format": {`{"formatVersion": 99, "entries": []}`, "newer"},
+		"missing file": {`{"formatVersion": 1, "entries": [{"kind": "agent", "name": "a", "path": "agents/a.json"}]}`, "does not contain"},
+		"unknown kind": {`{"formatVersion": 1, "entries": [{"kind": "secret", "name": "a", "path": "a.json"}]}`, "unknown kind"},
+	}
+	for name, tt := range tests {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		if err := writeEntry(tw, manifestFile, []byte(tt.manifest), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		tw.Close()
+		gz.Close()
+		if _, err := Read(&buf); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want it to mention %q", name, err, tt.want)
+		}
+	}
+}
+
+func TestDependenciesOf(t *testing.T) {
+	def := json.RawMessage(`{
+		"name": "support",
+		"router": "classifier",
+		"agents": ["billing", {"name": "inline", "tools": ["inline_tool"]}],
+		"tools": [
+			"lookup_order",
+			{"name": "refund", "taskName": "issue_refund"},
+			{"name": "docs", "type": "http", "url": "http://x"},
+			{"name": "escalate", "type": "agent", "agent": "humans"}
+		]
+	}`)
+	got := dependenciesOf(def)
+	if want := []string{"billing", "classifier", "humans"}; !reflect.DeepEqual(got.agents, want) {
+		t.Errorf("agents = %v, want %v", got.agents, want)
+	}
+	if want := []string{"inline_tool", "lookup_order", "issue_refund"}; !reflect.DeepEqual(got.tasks, want) {
+		t.Errorf("tasks = %v, want %v", got.tasks, want)
+	}
+}
+
+func TestWorkflowDeps(t *testing.T) {
+	def := json.RawMessage(`{"name":"wf","tasks":[
+		{"name":"a","type":"SIMPLE"},
+		{"type":"FORK_JOIN","forkTasks":[[{"name":"child","type":"SUB_WORKFLOW","subWorkflowParam":{"name":"child_wf"}}]]},
+		{"type":"SWITCH","decisionCases":{"x":[{"name":"b","type":"SIMPLE"}]}},
+		{"name":"wait","type":"WAIT"}
+	]}`)
+	subs, tasks := workflowDeps(def)
+	if !reflect.DeepEqual(subs, []string{"child_wf"}) || !reflect.DeepEqual(tasks, []string{"a", "b"}) {
+		t.Errorf("workflowDeps = %v, %v; want [child_wf], [a b]", subs, tasks)
+	}
+}
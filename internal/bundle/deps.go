@@ -0,0 +1,163 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bundle
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Tool types whose calls run as tasks the bundle must carry definitions for. An
+// untyped tool is a worker tool; http, mcp and builtin tools run on the server.
+const (
+	toolTypeWorker = "worker"
+	toolTypeAgent  = "agent"
+)
+
+// Workflow task types that reference other definitions by name.
+const (
+	taskTypeSubWorkflow = "SUB_WORKFLOW"
+	taskTypeSimple      = "SIMPLE"
+)
+
+// agentDeps is what an agent definition references by name: other registered
+// agents (sub-agents, a router, agent tools) and the task types of its worker
+// tools. Inline agents are walked rather than listed.
+type agentDeps struct {
+	agents []string
+	tasks  []string
+}
+
+// agentNode is the part of an agent definition that names dependencies.
+type agentNode struct {
+	Agents []json.RawMessage `json:"agents"`
+	Router json.RawMessage   `json:"router"`
+	Tools  []json.RawMessage `json:"tools"`
+}
+
+type toolNode struct {
+	Name     string          `json:"name"`
+	Type     string          `json:"type"`
+	TaskName string          `json:"taskName"`
+	Agent    json.RawMessage `json:"agent"`
+}
+
+func dependenciesOf(def json.RawMessage) agentDeps {
+	var d agentDeps
+	d.walkAgent(def)
+	return d
+}
+
+func (d *agentDeps) walkAgent(def json.RawMessage) {
+	var n agentNode
+	if json.Unmarshal(def, &n) != nil {
+		return
+	}
+	for _, ref := range n.Agents {
+		d.agentRef(ref)
+	}
+	d.agentRef(n.Router)
+	for _, t := range n.Tools {
+		d.tool(t)
+	}
+}
+
+// agentRef records a reference by name or walks an inline agent.
+func (d *agentDeps) agentRef(ref json.RawMessage) {
+	if len(ref) == 0 {
+		return
+	}
+	var name string
+	if json.Unmarshal(ref, &name) == nil {
+		if name != "" {
+			d.agents = appendUnique(d.agents, name)
+		}
+		return
+	}
+	d.walkAgent(ref)
+}
+
+func (d *agentDeps) tool(raw json.RawMessage) {
+	var name string
+	if json.Unmarshal(raw, &name) == nil {
+		d.tasks = appendUnique(d.tasks, name)
+		return
+	}
+	var t toolNode
+	if json.Unmarshal(raw, &t) != nil {
+		return
+	}
+	switch t.Type {
+	case "", toolTypeWorker:
+		if t.TaskName != "" {
+			d.tasks = appendUnique(d.tasks, t.TaskName)
+		} else if t.Name != "" {
+			d.tasks = appendUnique(d.tasks, t.Name)
+		}
+	case toolTypeAgent:
+		d.agentRef(t.Agent)
+	}
+}
+
+// workflowDeps lists the sub-workflows a workflow definition starts and the task
+// types of its SIMPLE tasks, wherever they are nested (forks, switch cases, loops).
+func workflowDeps(def json.RawMessage) (subWorkflows, tasks []string) {
+	var v any
+	if json.Unmarshal(def, &v) != nil {
+		return nil, nil
+	}
+	var walk func(any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case []any:
+			for _, e := range v {
+				walk(e)
+			}
+		case map[string]any:
+			typ, _ := v["type"].(string)
+			switch typ {
+			case taskTypeSubWorkflow:
+				if p, ok := v["subWorkflowParam"].(map[string]any); ok {
+					if name, _ := p["name"].(string); name != "" {
+						subWorkflows = appendUnique(subWorkflows, name)
+					}
+				}
+			case taskTypeSimple:
+				if name, _ := v["name"].(string); name != "" {
+					tasks = appendUnique(tasks, name)
+				}
+			}
+			// Sorted so the lists, and the bundles built from them, are stable.
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				walk(v[k])
+			}
+		}
+	}
+	walk(v)
+	return subWorkflows, tasks
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, e := range list {
+		if e == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
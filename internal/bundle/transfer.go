@@ -0,0 +1,364 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+// Reader looks definitions up by name; found is false, with no error, when the
+// server does not have one. Workflow version 0 means the latest.
+type Reader interface {
+	Agent(ctx context.Context, name string) (def json.RawMessage, found bool, err error)
+	TaskDef(ctx context.Context, name string) (def json.RawMessage, found bool, err error)
+	Workflow(ctx context.Context, name string, version int) (def json.RawMessage, found bool, err error)
+}
+
+// Source is the server an export reads from.
+type Source interface {
+	Reader
+	// CompileAgent returns the workflow a native agent definition runs as.
+	CompileAgent(ctx context.Context, def json.RawMessage) (json.RawMessage, error)
+}
+
+// Target is the server an import writes to. PutAgent publishes a definition,
+// server-managed keys and all, as the agent's new latest version; PutWorkflow
+// creates or replaces one version.
+type Target interface {
+	Reader
+	CreateTaskDef(ctx context.Context, def json.RawMessage) error
+	UpdateTaskDef(ctx context.Context, def json.RawMessage) error
+	PutWorkflow(ctx context.Context, def json.RawMessage) error
+	PutAgent(ctx context.Context, def json.RawMessage) error
+}
+
+// Export bundles the named agents with what they depend on: referenced agents,
+// the task definitions of their worker tools, and the workflows their compiled
+// form starts as sub-workflows, recursively. Notes say what was left out — tool
+// task types without a task definition, such as system tasks, and framework
+// agents, which the server cannot compile for inspection.
+func Export(ctx context.Context, src Source, names []string) (b Bundle, notes []string, err error) {
+	e := &exporter{src: src, state: map[string]visit{}}
+	for _, name := range names {
+		if err := e.agent(ctx, name); err != nil {
+			return Bundle{}, nil, err
+		}
+	}
+	b.Items = append(append(e.tasks, e.workflows...), e.agents...)
+	return b, e.notes, nil
+}
+
+type visit int
+
+const (
+	visiting visit = iota + 1
+	visited
+)
+
+type exporter struct {
+	src                      Source
+	state                    map[string]visit
+	tasks, workflows, agents []Item
+	notes                    []string
+}
+
+// enter marks key as in progress, reporting false when it was already exported.
+// A key met again while in progress is a reference cycle, which no import order
+// could satisfy.
+func (e *exporter) enter(kind Kind, name string) (bool, error) {
+	key := string(kind) + "/" + name
+	switch e.state[key] {
+	case visited:
+		return false, nil
+	case visiting:
+		return false, fmt.Errorf("%s %q depends on itself through its references", kind, name)
+	}
+	e.state[key] = visiting
+	return true, nil
+}
+
+func (e *exporter) leave(kind Kind, name string) {
+	e.state[string(kind)+"/"+name] = visited
+}
+
+func (e *exporter) agent(ctx context.Context, name string) error {
+	if fresh, err := e.enter(KindAgent, name); !fresh {
+		return err
+	}
+	def, found, err := e.src.Agent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("get agent %q: %w", name, err)
+	}
+	if !found {
+		return fmt.Errorf("agent %q not found", name)
+	}
+
+	deps := dependenciesOf(def)
+	for _, a := range deps.agents {
+		if err := e.agent(ctx, a); err != nil {
+			return err
+		}
+	}
+	tasks := deps.tasks
+	if isFramework(def) {
+		e.notes = append(e.notes, fmt.Sprintf("agent %q is a framework agent; its sub-workflows, if any, are not bundled", name))
+	} else {
+		compiled, err := e.src.CompileAgent(ctx, def)
+		if err != nil {
+			return fmt.Errorf("compile agent %q: %w", name, err)
+		}
+		subs, simple := workflowDeps(compiled)
+		for _, t := range simple {
+			tasks = appendUnique(tasks, t)
+		}
+		for _, w := range subs {
+			if err := e.workflow(ctx, w); err != nil {
+				return err
+			}
+		}
+	}
+	for _, t := range tasks {
+		if err := e.taskDef(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	e.agents = append(e.agents, Item{Kind: KindAgent, Name: name, Version: versionOf(def), Definition: def})
+	e.leave(KindAgent, name)
+	return nil
+}
+
+func (e *exporter) workflow(ctx context.Context, name string) error {
+	// An agent's sub-agents compile to sub-workflows named after them; those travel
+	// as agents, which recreate their workflows on import.
+	if e.state[string(KindAgent)+"/"+name] != 0 {
+		return nil
+	}
+	if fresh, err := e.enter(KindWorkflow, name); !fresh {
+		return err
+	}
+	def, found, err := e.src.Workflow(ctx, name, 0)
+	if err != nil {
+		return fmt.Errorf("get workflow %q: %w", name, err)
+	}
+	if !found {
+		return fmt.Errorf("workflow %q, started as a sub-workflow, not found", name)
+	}
+	subs, tasks := workflowDeps(def)
+	for _, w := range subs {
+		if err := e.workflow(ctx, w); err != nil {
+			return err
+		}
+	}
+	for _, t := range tasks {
+		if err := e.taskDef(ctx, t); err != nil {
+			return err
+		}
+	}
+	e.workflows = append(e.workflows, Item{Kind: KindWorkflow, Name: name, Version: versionOf(def), Definition: def})
+	e.leave(KindWorkflow, name)
+	return nil
+}
+
+func (e *exporter) taskDef(ctx context.Context, name string) error {
+	if fresh, err := e.enter(KindTaskDef, name); !fresh {
+		return err
+	}
+	def, found, err := e.src.TaskDef(ctx, name)
+	if err != nil {
+		return fmt.Errorf("get task definition %q: %w", name, err)
+	}
+	if found {
+		e.tasks = append(e.tasks, Item{Kind: KindTaskDef, Name: name, Definition: def})
+	} else {
+		e.notes = append(e.notes, fmt.Sprintf("task %q has no task definition (a system task, or undefined); not bundled", name))
+	}
+	e.leave(KindTaskDef, name)
+	return nil
+}
+
+func versionOf(def json.RawMessage) int {
+	var probe struct {
+		Version int `json:"version"`
+	}
+	_ = json.Unmarshal(def, &probe)
+	return probe.Version
+}
+
+func isFramework(def json.RawMessage) bool {
+	var probe struct {
+		Framework string `json:"_framework"`
+		SkillMd   string `json:"skillMd"`
+	}
+	return json.Unmarshal(def, &probe) == nil && (probe.Framework != "" || probe.SkillMd != "")
+}
+
+// Outcome is what an import did, or with DryRun would do, with one item. Restoring
+// a backup reports its resources with the same outcomes.
+type Outcome string
+
+const (
+	OutcomeCreated   Outcome = "created"
+	OutcomeUpdated   Outcome = "updated"
+	OutcomeUnchanged Outcome = "unchanged"
+	// OutcomeConflict is an item that exists on the target with a different
+	// definition and was left alone rather than overwritten.
+	OutcomeConflict Outcome = "conflict"
+)
+
+// ImportOptions controls an import. Overwrite replaces conflicting definitions;
+// DryRun compares without writing anything.
+type ImportOptions struct {
+	Overwrite bool
+	DryRun    bool
+}
+
+// ImportResult is the outcome for one item. Kind is the item's kind, or a restored
+// resource's. Differences names the top-level keys that differ from the target's
+// definition, for updates and conflicts.
+type ImportResult struct {
+	Kind        string   `json:"kind"`
+	Name        string   `json:"name"`
+	Version     int      `json:"version,omitempty"`
+	Outcome     Outcome  `json:"outcome"`
+	Differences []string `json:"differences,omitempty"`
+}
+
+// DifferingKeys lists the top-level keys whose values differ between the target's
+// current definition and the one an import or restore would write, in sorted
+// order. jsondiff.ServerManagedKeys and the keys in ignore are left out.
+func DifferingKeys(current, def json.RawMessage, ignore ...string) ([]string, error) {
+	var ma, mb map[string]any
+	if err := json.Unmarshal(current, &ma); err != nil {
+		return nil, fmt.Errorf("target definition is not a JSON object: %w", err)
+	}
+	if err := json.Unmarshal(def, &mb); err != nil {
+		return nil, fmt.Errorf("new definition is not a JSON object: %w", err)
+	}
+	skip := func(k string) bool {
+		return slices.Contains(jsondiff.ServerManagedKeys, k) || slices.Contains(ignore, k)
+	}
+	var diff []string
+	for k, v := range ma {
+		if !skip(k) && !reflect.DeepEqual(v, mb[k]) {
+			diff = append(diff, k)
+		}
+	}
+	for k := range mb {
+		if _, ok := ma[k]; !ok && !skip(k) {
+			diff = append(diff, k)
+		}
+	}
+	sort.Strings(diff)
+	return diff, nil
+}
+
+// Import recreates a bundle's items on dst in bundle order, so every dependency
+// exists before what references it. Each item is compared with the target's
+// current definition first: identical ones are left alone and different ones are
+// conflicts unless opts.Overwrite is set. It stops at the first failed write.
+func Import(ctx context.Context, dst Target, b Bundle, opts ImportOptions) ([]ImportResult, error) {
+	results := make([]ImportResult, 0, len(b.Items))
+	for _, it := range b.Items {
+		res, err := importItem(ctx, dst, it, opts)
+		if err != nil {
+			return results, fmt.Errorf("import %s %q: %w", it.Kind, it.Name, err)
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func importItem(ctx context.Context, dst Target, it Item, opts ImportOptions) (ImportResult, error) {
+	res := ImportResult{Kind: string(it.Kind), Name: it.Name, Version: it.Version}
+	var (
+		current json.RawMessage
+		found   bool
+		err     error
+		ignore  []string
+	)
+	switch it.Kind {
+	case KindTaskDef:
+		current, found, err = dst.TaskDef(ctx, it.Name)
+	case KindWorkflow:
+		current, found, err = dst.Workflow(ctx, it.Name, it.Version)
+	case KindAgent:
+		current, found, err = dst.Agent(ctx, it.Name)
+		ignore = agent.ServerManagedKeys
+	}
+	if err != nil {
+		return res, err
+	}
+
+	switch {
+	case !found:
+		res.Outcome = OutcomeCreated
+	default:
+		diff, err := DifferingKeys(current, it.Definition, ignore...)
+		if err != nil {
+			return res, err
+		}
+		res.Differences = diff
+		switch {
+		case len(diff) == 0:
+			res.Outcome = OutcomeUnchanged
+		case opts.Overwrite:
+			res.Outcome = OutcomeUpdated
+		default:
+			res.Outcome = OutcomeConflict
+		}
+	}
+	if opts.DryRun || (res.Outcome != OutcomeCreated && res.Outcome != OutcomeUpdated) {
+		return res, nil
+	}
+
+	switch it.Kind {
+	case KindTaskDef:
+		if found {
+			return res, dst.UpdateTaskDef(ctx, it.Definition)
+		}
+		return res, dst.CreateTaskDef(ctx, it.Definition)
+	case KindWorkflow:
+		return res, dst.PutWorkflow(ctx, it.Definition)
+	default:
+		return res, dst.PutAgent(ctx, it.Definition)
+	}
+}
+
+// Summary counts results by outcome, e.g. "2 created, 1 unchanged, 1 conflict".
+func Summary(results []ImportResult) string {
+	counts := map[Outcome]int{}
+	for _, r := range results {
+		counts[r.Outcome]++
+	}
+	var parts []string
+	for _, o := range []Outcome{OutcomeCreated, OutcomeUpdated, OutcomeUnchanged, OutcomeConflict} {
+		if counts[o] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[o], o))
+		}
+	}
+	if len(parts) == 0 {
+		return "nothing to do"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fakeServer is an in-memory Source and Target keyed by "kind/name".
+type fakeServer struct {
+	defs     map[string]json.RawMessage
+	compiled map[string]json.RawMessage
+	writes   []string
+}
+
+func (f *fakeServer) get(kind Kind, name string) (json.RawMessage, bool, error) {
+	def, ok := f.defs[string(kind)+"/"+name]
+	return def, ok, nil
+}
+
+func (f *fakeServer) Agent(_ context.Context, name string) (json.RawMessage, bool, error) {
+	return f.get(KindAgent, name)
+}
+
+func (f *fakeServer) TaskDef(_ context.Context, name string) (json.RawMessage, bool, error) {
+	return f.get(KindTaskDef, name)
+}
+
+func (f *fakeServer) Workflow(_ context.Context, name string, _ int) (json.RawMessage, bool, error) {
+	return f.get(KindWorkflow, name)
+}
+
+func (f *fakeServer) CompileAgent(_ context.Context, def json.RawMessage) (json.RawMessage, error) {
+	var probe struct {
+		Name string `json:"name"`
+	}
+	_ = json.Unmarshal(def, &probe)
+	if wf, ok := f.compiled[probe.Name]; ok {
+		return wf, nil
+	}
+	return json.RawMessage(`{"tasks":[]}`), nil
+}
+
+func (f *fakeServer) put(op string, def json.RawMessage) error {
+	var probe struct {
+		Name string `json:"name"`
+	}
+	_ = json.Unmarshal(def, &probe)
+	f.writes = append(f.writes, fmt.Sprintf("%s %s", op, probe.Name))
+	return nil
+}
+
+func (f *fakeServer) CreateTaskDef(_ context.Context, def json.RawMessage) error {
+	return f.put("create-task", def)
+}
+
+func (f *fakeServer) UpdateTaskDef(_ context.Context, def json.RawMessage) error {
+	return f.put("update-task", def)
+}
+
+func (f *fakeServer) PutWorkflow(_ context.Context, def json.RawMessage) error {
+	return f.put("put-workflow", def)
+}
+
+func (f *fakeServer) PutAgent(_ context.Context, def json.RawMessage) error {
+	return f.put("put-agent", def)
+}
+
+func itemNames(items []Item) []string {
+	var out []string
+	for _, it := range items {
+		out = append(out, string(it.Kind)+"/"+it.Name)
+	}
+	return out
+}
+
+func TestExportOrdersDependenciesFirst(t *testing.T) {
+	src := &fakeServer{
+		defs: map[string]json.RawMessage{
+			"agent/support":        json.RawMessage(`{"name":"support","version":4,"agents":["billing"],"tools":["lookup_order","google_search"]}`),
+			"agent/billing":        json.RawMessage(`{"name":"billing","version":2,"tools":["issue_refund"]}`),
+			"taskDef/lookup_order": json.RawMessage(`{"name":"lookup_order"}`),
+			"taskDef/issue_refund": json.RawMessage(`{"name":"issue_refund"}`),
+			"taskDef/audit":        json.RawMessage(`{"name":"audit"}`),
+			"workflow/refund_flow": json.RawMessage(`{"name":"refund_flow","version":1,"tasks":[{"name":"audit","type":"SIMPLE"}]}`),
+		},
+		compiled: map[string]json.RawMessage{
+			"billing": json.RawMessage(`{"tasks":[{"type":"SUB_WORKFLOW","subWorkflowParam":{"name":"refund_flow"}}]}`),
+			"support": json.RawMessage(`{"tasks":[{"type":"SUB_WORKFLOW","subWorkflowParam":{"name":"billing"}}]}`),
+		},
+	}
+	b, notes, err := Export(context.Background(), src, []string{"support"})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	want := []string{
+		"taskDef/audit", "taskDef/issue_refund", "taskDef/lookup_order",
+		"workflow/refund_flow",
+		"agent/billing", "agent/support",
+	}
+	if got := itemNames(b.Items); !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+	if b.Items[5].Version != 4 {
+		t.Errorf("support version = %d, want 4", b.Items[5].Version)
+	}
+	if len(notes) != 1 {
+		t.Errorf("notes = %v, want one for google_search", notes)
+	}
+
+	if _, _, err := Export(context.Background(), src, []string{"missing"}); err == nil {
+		t.Error("Export of an unknown agent succeeded")
+	}
+}
+
+func TestExportRejectsAgentCycles(t *testing.T) {
+	src := &fakeServer{defs: map[string]json.RawMessage{
+		"agent/a": json.RawMessage(`{"name":"a","agents":["b"]}`),
+		"agent/b": json.RawMessage(`{"name":"b","agents":["a"]}`),
+	}}
+	if _, _, err := Export(context.Background(), src, []string{"a"}); err == nil {
+		t.Error("Export accepted agents that reference each other")
+	}
+}
+
+func TestImportOutcomes(t *testing.T) {
+	b := Bundle{Items: []Item{
+		{Kind: KindTaskDef, Name: "new_task", Definition: json.RawMessage(`{"name":"new_task"}`)},
+		{Kind: KindTaskDef, Name: "same_task", Definition: json.RawMessage(`{"name":"same_task","retryCount":3,"createTime":1}`)},
+		{Kind: KindWorkflow, Name: "flow", Version: 1, Definition: json.RawMessage(`{"name":"flow","version":1,"timeoutSeconds":60}`)},
+		{Kind: KindAgent, Name: "triage", Version: 7, Definition: json.RawMessage(`{"name":"triage","version":7,"model":"openai/gpt-4o"}`)},
+	}}
+	dst := func() *fakeServer {
+		return &fakeServer{defs: map[string]json.RawMessage{
+			"taskDef/same_task": json.RawMessage(`{"name":"same_task","retryCount":3,"createTime":2}`),
+			"workflow/flow":     json.RawMessage(`{"name":"flow","version":1,"timeoutSeconds":30}`),
+			"agent/triage":      json.RawMessage(`{"name":"triage","version":2,"model":"openai/gpt-4o"}`),
+		}}
+	}
+
+	target := dst()
+	results, err := Import(context.Background(), target, b, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	var outcomes []Outcome
+	for _, r := range results {
+		outcomes = append(outcomes, r.Outcome)
+	}
+	if want := []Outcome{OutcomeCreated, OutcomeUnchanged, OutcomeConflict, OutcomeUnchanged}; !reflect.DeepEqual(outcomes, want) {
+		t.Errorf("outcomes = %v, want %v", outcomes, want)
+	}
+	if !reflect.DeepEqual(results[2].Differences, []string{"timeoutSeconds"}) {
+		t.Errorf("conflict differences = %v, want [timeoutSeconds]", results[2].Differences)
+	}
+	if want := []string{"create-task new_task"}; !reflect.DeepEqual(target.writes, want) {
+		t.Errorf("writes = %v, want %v", target.writes, want)
+	}
+	if got := Summary(results); got != "1 created, 2 unchanged, 1 conflict" {
+		t.Errorf("Summary = %q", got)
+	}
+
+	target = dst()
+	if _, err := Import(context.Background(), target, b, ImportOptions{Overwrite: true}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if want := []string{"create-task new_task", "put-workflow flow"}; !reflect.DeepEqual(target.writes, want) {
+		t.Errorf("overwrite writes = %v, want %v", target.writes, want)
+	}
+
+	target = dst()
+	if _, err := Import(context.Background(), target, b, ImportOptions{Overwrite: true, DryRun: true}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(target.writes) != 0 {
+		t.Errorf("dry run wrote %v", target.writes)
+	}
+}
+
+func TestDifferingKeysIgnoresServerManagedAndGivenKeys(t *testing.T) {
+	current := json.RawMessage(`{"id":"a1","name":"x","url":"u1","updateTime":1,"ownerApp":"a"}`)
+	def := json.RawMessage(`{"id":"b2","name":"x","url":"u2","updateTime":2,"events":["e"]}`)
+	got, err := DifferingKeys(current, def, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"events", "url"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DifferingKeys = %q, want %q", got, want)
+	}
+}
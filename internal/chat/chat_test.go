@@ -0,0 +1,144 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package chat
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func rawEditor(input string) *Editor {
+	noop := func() (func(), error) { return func() {}, nil }
+	return NewEditor(bufio.NewReader(strings.NewReader(input)), io.Discard, noop)
+}
+
+func TestRawEditing(t *testing.T) {
+	// "helo", left, insert "l", End, "!", Home, Delete-forward, "H", Enter.
+	ed := rawEditor("helo\x1b[Dl\x1b[F!\x01\x1b[3~H\r")
+	got, err := ed.ReadLine("> ")
+	if err != nil || got != "Hello!" {
+		t.Fatalf("ReadLine = %q, %v; want %q", got, err, "Hello!")
+	}
+}
+
+func TestRawHistoryKeepsDraft(t *testing.T) {
+	ed := rawEditor("draft\x1b[A\x1b[A\x1b[B\x1b[B\r")
+	ed.History = []string{"first", "second"}
+	if got, _ := ed.ReadLine("> "); got != "draft" {
+		t.Errorf("up, up, down, down = %q, want the draft back", got)
+	}
+
+	ed = rawEditor("\x1b[A\x1b[A\r")
+	ed.History = []string{"first", "second"}
+	if got, _ := ed.ReadLine("> "); got != "first" {
+		t.Errorf("up, up = %q, want %q", got, "first")
+	}
+}
+
+func TestRawControlKeys(t *testing.T) {
+	if _, err := rawEditor("abc\x03").ReadLine("> "); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Ctrl-C err = %v, want ErrInterrupted", err)
+	}
+	if _, err := rawEditor("\x04").ReadLine("> "); err != io.EOF {
+		t.Errorf("Ctrl-D on empty line err = %v, want io.EOF", err)
+	}
+}
+
+func TestReadMessageMultiline(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"single", "hello\n", "hello"},
+		{"continuation", "one \\\ntwo\\\nthree\n", "one \ntwo\nthree"},
+		{"fence", "\"\"\"\n  indented\n\nlast\n\"\"\"\n", "indented\n\nlast"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ed := NewEditor(bufio.NewReader(strings.NewReader(tt.input)), io.Discard, nil)
+			got, err := ed.ReadMessage("> ", ". ")
+			if err != nil || got != tt.want {
+				t.Fatalf("ReadMessage = %q, %v; want %q", got, err, tt.want)
+			}
+			if !reflect.DeepEqual(ed.History, []string{tt.want}) {
+				t.Errorf("History = %q, want the message", ed.History)
+			}
+		})
+	}
+}
+
+func TestIndexRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "chat-sessions.json")
+	idx, err := LoadIndex(path)
+	if err != nil || len(idx.Sessions) != 0 {
+		t.Fatalf("LoadIndex on a missing file = %+v, %v; want empty", idx, err)
+	}
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	idx.Upsert(Session{ID: "old", Agent: "triage", Updated: now})
+	idx.Upsert(Session{ID: "new", Agent: "triage", Updated: now.Add(time.Hour)})
+	s, _ := idx.Get("old")
+	s.Turns = 3
+	s.Remember("hi")
+	idx.Upsert(s)
+	if err := idx.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadIndex(path)
+	if err != nil {
+		t.Fatalf("LoadIndex: %v", err)
+	}
+	got, ok := loaded.Get("old")
+	if !ok || got.Turns != 3 || !reflect.DeepEqual(got.History, []string{"hi"}) {
+		t.Errorf("Get(old) = %+v, %t; want the updated session", got, ok)
+	}
+	if recent := loaded.Recent(); len(recent) != 2 || recent[0].ID != "new" {
+		t.Errorf("Recent() = %+v, want newest first", recent)
+	}
+}
+
+func TestRememberCapsHistory(t *testing.T) {
+	var s Session
+	for i := 0; i < MaxHistory+5; i++ {
+		s.Remember(strings.Repeat("x", i%3))
+	}
+	if len(s.History) != MaxHistory {
+		t.Errorf("len(History) = %d, want %d", len(s.History), MaxHistory)
+	}
+}
+
+func TestInputReadLineGivesUpWithoutConsuming(t *testing.T) {
+	src, typed := io.Pipe()
+	defer typed.Close()
+	in := NewInput(src)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := in.ReadLine(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReadLine after cancel: err = %v", err)
+	}
+	go func() { _, _ = io.WriteString(typed, "hello\nworld\n") }()
+	for _, want := range []string{"hello\n", "world\n"} {
+		if got, err := in.ReadLine(context.Background()); got != want || err != nil {
+			t.Errorf("ReadLine = %q, %v; want %q", got, err, want)
+		}
+	}
+}
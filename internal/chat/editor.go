@@ -0,0 +1,271 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package chat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MultilineFence opens and closes a block of input sent as one message.
+const MultilineFence = `"""`
+
+// continuation ends a line that carries on to the next one.
+const continuation = `\`
+
+// Control bytes the editor acts on in raw mode.
+const (
+	keyCtrlA     = 0x01
+	keyCtrlC     = 0x03
+	keyCtrlD     = 0x04
+	keyCtrlE     = 0x05
+	keyBackspace = 0x08
+	keyCtrlK     = 0x0b
+	keyCtrlN     = 0x0e
+	keyCtrlP     = 0x10
+	keyCtrlU     = 0x15
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+// ErrInterrupted is returned when Ctrl-C abandons the line being edited.
+var ErrInterrupted = errors.New("interrupted")
+
+// RawMode switches the terminal to raw mode and returns the function restoring it.
+type RawMode func() (restore func(), err error)
+
+// Editor reads prompts with history recall. With a RawMode it edits in place —
+// arrows, Home/End, Ctrl-A/E/K/U, up/down through history; without one (stdin is
+// a pipe) it reads plain lines.
+type Editor struct {
+	in      *bufio.Reader
+	out     io.Writer
+	raw     RawMode
+	History []string
+}
+
+// NewEditor returns an editor reading from in and echoing to out. raw may be nil.
+func NewEditor(in *bufio.Reader, out io.Writer, raw RawMode) *Editor {
+	return &Editor{in: in, out: out, raw: raw}
+}
+
+// ReadMessage reads one message. A line ending in a backslash continues on the
+// next line, and a line holding only """ starts a block that runs to the next such
+// line; both come back joined with newlines. The message is added to the history.
+func (e *Editor) ReadMessage(prompt, cont string) (string, error) {
+	first, err := e.ReadLine(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	switch {
+	case strings.TrimSpace(first) == MultilineFence:
+		for {
+			line, err := e.ReadLine(cont)
+			if err != nil {
+				return "", err
+			}
+			if strings.TrimSpace(line) == MultilineFence {
+				break
+			}
+			lines = append(lines, line)
+		}
+	default:
+		line := first
+		for strings.HasSuffix(line, continuation) {
+			lines = append(lines, strings.TrimSuffix(line, continuation))
+			if line, err = e.ReadLine(cont); err != nil {
+				return "", err
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	msg := strings.TrimSpace(strings.Join(lines, "\n"))
+	if msg != "" && (len(e.History) == 0 || e.History[len(e.History)-1] != msg) {
+		e.History = append(e.History, msg)
+	}
+	return msg, nil
+}
+
+// ReadLine reads a single line after showing prompt. It returns io.EOF on Ctrl-D
+// at an empty line (or end of input) and ErrInterrupted on Ctrl-C.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	if e.raw == nil {
+		return e.readPlain(prompt)
+	}
+	restore, err := e.raw()
+	if err != nil {
+		return e.readPlain(prompt)
+	}
+	defer restore()
+	return e.readRaw(prompt)
+}
+
+func (e *Editor) readPlain(prompt string) (string, error) {
+	fmt.Fprint(e.out, prompt)
+	line, err := e.in.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// lineState is the line being edited in raw mode.
+type lineState struct {
+	buf []rune
+	pos int
+	// hist indexes the history entry shown; len(History) is the draft.
+	hist  int
+	draft []rune
+}
+
+func (e *Editor) readRaw(prompt string) (string, error) {
+	s := &lineState{hist: len(e.History)}
+	e.redraw(prompt, s)
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			if err == io.EOF && len(s.buf) > 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return string(s.buf), nil
+			}
+			return "", err
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(s.buf), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(s.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			s.deleteAt(s.pos)
+		case keyDelete, keyBackspace:
+			if s.pos > 0 {
+				s.pos--
+				s.deleteAt(s.pos)
+			}
+		case keyCtrlA:
+			s.pos = 0
+		case keyCtrlE:
+			s.pos = len(s.buf)
+		case keyCtrlK:
+			s.buf = s.buf[:s.pos]
+		case keyCtrlU:
+			s.buf = append([]rune(nil), s.buf[s.pos:]...)
+			s.pos = 0
+		case keyCtrlP:
+			e.recall(s, -1)
+		case keyCtrlN:
+			e.recall(s, 1)
+		case keyEscape:
+			e.escape(s)
+		default:
+			if r >= ' ' {
+				s.buf = append(s.buf[:s.pos], append([]rune{r}, s.buf[s.pos:]...)...)
+				s.pos++
+			}
+		}
+		e.redraw(prompt, s)
+	}
+}
+
+// escape handles the CSI and SS3 sequences for the arrow, Home, End and Delete
+// keys. Anything else is consumed and ignored.
+func (e *Editor) escape(s *lineState) {
+	intro, _, err := e.in.ReadRune()
+	if err != nil || (intro != '[' && intro != 'O') {
+		return
+	}
+	final, _, err := e.in.ReadRune()
+	if err != nil {
+		return
+	}
+	if final >= '0' && final <= '9' {
+		// ESC [ n ~ — read through the terminating tilde.
+		code := final
+		for final != '~' {
+			if final, _, err = e.in.ReadRune(); err != nil {
+				return
+			}
+		}
+		switch code {
+		case '1', '7':
+			final = 'H'
+		case '4', '8':
+			final = 'F'
+		case '3':
+			if s.pos < len(s.buf) {
+				s.deleteAt(s.pos)
+			}
+			return
+		default:
+			return
+		}
+	}
+	switch final {
+	case 'A':
+		e.recall(s, -1)
+	case 'B':
+		e.recall(s, 1)
+	case 'C':
+		s.pos = min(s.pos+1, len(s.buf))
+	case 'D':
+		s.pos = max(s.pos-1, 0)
+	case 'H':
+		s.pos = 0
+	case 'F':
+		s.pos = len(s.buf)
+	}
+}
+
+// recall moves through the history by delta, keeping the unsent draft so moving
+// past the newest entry brings it back. Multi-line entries are recalled on one line.
+func (e *Editor) recall(s *lineState, delta int) {
+	next := s.hist + delta
+	if next < 0 || next > len(e.History) {
+		return
+	}
+	if s.hist == len(e.History) {
+		s.draft = append([]rune(nil), s.buf...)
+	}
+	s.hist = next
+	if next == len(e.History) {
+		s.buf = append([]rune(nil), s.draft...)
+	} else {
+		s.buf = []rune(strings.ReplaceAll(e.History[next], "\n", " "))
+	}
+	s.pos = len(s.buf)
+}
+
+func (s *lineState) deleteAt(i int) {
+	s.buf = append(s.buf[:i], s.buf[i+1:]...)
+}
+
+// redraw repaints the prompt and line, then puts the cursor back at pos.
+func (e *Editor) redraw(prompt string, s *lineState) {
+	fmt.Fprintf(e.out, "\r\x1b[K%s%s", prompt, string(s.buf))
+	if back := len(s.buf) - s.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
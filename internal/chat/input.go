@@ -0,0 +1,106 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package chat
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// inputChunkSize is how much one read of the underlying input takes at most.
+const inputChunkSize = 4096
+
+// Input is a terminal's input with a single reader: one goroutine reads the source
+// and hands what it gets to whichever read is waiting. A read that gives up when
+// its context ends, as a prompt does on Ctrl-C, so leaves the next input to the
+// next read instead of a read left blocked behind it that would swallow it. The
+// editor and the prompts shown while a turn runs share one Input.
+type Input struct {
+	src *inputSource
+	buf *bufio.Reader
+}
+
+// NewInput starts reading r.
+func NewInput(r io.Reader) *Input {
+	src := &inputSource{chunks: make(chan inputChunk), ctx: context.Background()}
+	go src.pump(r)
+	return &Input{src: src, buf: bufio.NewReader(src)}
+}
+
+// Reader returns the buffered input for the editor. Its reads wait for input.
+func (in *Input) Reader() *bufio.Reader {
+	return in.buf
+}
+
+// ReadLine reads one line, with its line ending, giving up when ctx ends. A last
+// line without a line ending comes back without an error.
+func (in *Input) ReadLine(ctx context.Context) (string, error) {
+	in.src.ctx = ctx
+	defer func() { in.src.ctx = context.Background() }()
+	line, err := in.buf.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return "", err
+	}
+	return line, nil
+}
+
+type inputChunk struct {
+	data []byte
+	err  error
+}
+
+// inputSource is the reader under Input's buffer. Only pump reads the underlying
+// input; Read waits for its chunks or for the current read's context to end.
+type inputSource struct {
+	chunks  chan inputChunk
+	pending []byte
+	err     error
+	ctx     context.Context
+}
+
+func (s *inputSource) pump(r io.Reader) {
+	for {
+		buf := make([]byte, inputChunkSize)
+		n, err := r.Read(buf)
+		if n > 0 {
+			s.chunks <- inputChunk{data: buf[:n]}
+		}
+		if err != nil {
+			s.chunks <- inputChunk{err: err}
+			return
+		}
+	}
+}
+
+func (s *inputSource) Read(p []byte) (int, error) {
+	if len(s.pending) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		select {
+		case <-s.ctx.Done():
+			return 0, s.ctx.Err()
+		case c := <-s.chunks:
+			if c.err != nil {
+				s.err = c.err
+				return 0, c.err
+			}
+			s.pending = c.data
+		}
+	}
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
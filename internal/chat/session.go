@@ -0,0 +1,113 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package chat holds the pieces of the conversational agent REPL that do not talk
+// to the server: the local session index and the line editor.
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// MaxHistory caps the prompts kept per session for up-arrow recall.
+const MaxHistory = 200
+
+// Session is one conversation with an agent. The server keys conversation memory
+// by ID; everything else is kept locally so the session can be resumed by ID alone.
+type Session struct {
+	ID              string    `json:"id"`
+	Agent           string    `json:"agent,omitempty"`
+	Config          string    `json:"config,omitempty"`
+	Title           string    `json:"title,omitempty"`
+	Created         time.Time `json:"created"`
+	Updated         time.Time `json:"updated"`
+	Turns           int       `json:"turns"`
+	LastExecutionID string    `json:"lastExecutionId,omitempty"`
+	History         []string  `json:"history,omitempty"`
+}
+
+// Remember appends a prompt to the session history, dropping the oldest entries
+// past MaxHistory.
+func (s *Session) Remember(prompt string) {
+	s.History = append(s.History, prompt)
+	if over := len(s.History) - MaxHistory; over > 0 {
+		s.History = append([]string(nil), s.History[over:]...)
+	}
+}
+
+// Index is the set of known sessions, stored as JSON in the CLI config directory.
+type Index struct {
+	path     string
+	Sessions []Session `json:"sessions"`
+}
+
+// LoadIndex reads the index at path. A missing file is an empty index.
+func LoadIndex(path string) (*Index, error) {
+	idx := &Index{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return idx, nil
+		}
+		return nil, fmt.Errorf("read session index: %w", err)
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, fmt.Errorf("parse session index %s: %w", path, err)
+	}
+	return idx, nil
+}
+
+// Get returns the session with id.
+func (x *Index) Get(id string) (Session, bool) {
+	for _, s := range x.Sessions {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Session{}, false
+}
+
+// Upsert adds s or replaces the session with the same ID.
+func (x *Index) Upsert(s Session) {
+	for i := range x.Sessions {
+		if x.Sessions[i].ID == s.ID {
+			x.Sessions[i] = s
+			return
+		}
+	}
+	x.Sessions = append(x.Sessions, s)
+}
+
+// Recent returns the sessions, most recently updated first.
+func (x *Index) Recent() []Session {
+	out := append([]Session(nil), x.Sessions...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Updated.After(out[j].Updated) })
+	return out
+}
+
+// Save writes the index back to its file, creating the directory if needed.
+func (x *Index) Save() error {
+	if err := os.MkdirAll(filepath.Dir(x.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(x, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(x.path, data, 0644)
+}
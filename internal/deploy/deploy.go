@@ -18,7 +18,6 @@
 package deploy
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -32,11 +31,18 @@ const (
 	EnvServerURL = "AGENTSPAN_SERVER_URL"
 	EnvAuthToken = "AGENTSPAN_AUTH_TOKEN"
 	EnvAutoStart = "AGENTSPAN_AUTO_START_SERVER"
+	EnvTags      = "AGENTSPAN_DEPLOY_TAGS"
 
 	envPrefix    = "AGENTSPAN_"
 	autoStartOff = "false"
 
-	subprocessTimeout = 120 * time.Second
+	// DefaultTimeout bounds one discover or deploy subprocess unless the caller
+	// chooses otherwise.
+	DefaultTimeout = 120 * time.Second
+
+	// interruptGrace is how long a cancelled subprocess has to exit after its
+	// interrupt before it is killed.
+	interruptGrace = 5 * time.Second
 )
 
 // TokenSource yields the JWT to forward to the subprocess. It matches
@@ -52,11 +58,13 @@ type TokenSource interface {
 //
 // Cross-component note (design §7): forwarding the JWT — rather than a legacy API
 // key — requires the Python/TypeScript SDKs to authenticate with AGENTSPAN_AUTH_TOKEN.
-// This is the single cross-component dependency of the port.
+// Likewise, Tags reach the server only through SDKs that copy AGENTSPAN_DEPLOY_TAGS
+// onto the definitions they deploy.
 type EnvBuilder struct {
 	BaseURL string
 	Tokens  TokenSource // nil => anonymous
 	BaseEnv []string    // typically os.Environ(); injected for testability
+	Tags    []string    // written onto every deployed definition, e.g. the project tag
 }
 
 // Build returns the child-process environment.
@@ -68,6 +76,9 @@ func (b EnvBuilder) Build(ctx context.Context) ([]string, error) {
 		}
 	}
 	out = append(out, EnvServerURL+"="+b.BaseURL, EnvAutoStart+"="+autoStartOff)
+	if len(b.Tags) > 0 {
+		out = append(out, EnvTags+"="+strings.Join(b.Tags, ","))
+	}
 	if b.Tokens != nil {
 		tok, err := b.Tokens.Token(ctx)
 		if err != nil {
@@ -86,28 +97,54 @@ type Runner interface {
 	Run(ctx context.Context, env []string, name string, args ...string) ([]byte, error)
 }
 
-// NewRunner returns the default subprocess Runner.
-func NewRunner() Runner { return execRunner{} }
+// RunnerOptions configures the default Runner.
+type RunnerOptions struct {
+	Dir      string              // "" => the current working directory
+	Timeout  time.Duration       // per subprocess; 0 => no limit
+	Progress func(ProgressEvent) // called for each progress line; nil => discarded
+}
 
-type execRunner struct{}
+// NewRunner returns the default subprocess Runner. Stdout is read as it is
+// written: progress lines go to opts.Progress as they arrive and the rest is
+// returned as the result. Cancelling the context interrupts the subprocess and
+// kills it only if it has not exited after a grace period, so a tool that handles
+// the interrupt can report what it did. A terminal's Ctrl-C reaches the
+// subprocess directly as well, since it shares the CLI's process group.
+func NewRunner(opts RunnerOptions) Runner { return execRunner{opts: opts} }
 
-func (execRunner) Run(ctx context.Context, env []string, name string, args ...string) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(ctx, subprocessTimeout)
+type execRunner struct {
+	opts RunnerOptions
+}
+
+func (r execRunner) Run(ctx context.Context, env []string, name string, args ...string) ([]byte, error) {
+	timeout := r.opts.Timeout
+	runCtx, cancel := ctx, context.CancelFunc(func() {})
+	if timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, name, args...)
+	cmd := exec.CommandContext(runCtx, name, args...)
+	cmd.Dir = r.opts.Dir
 	cmd.Env = env
 	cmd.Stderr = os.Stderr
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
+	// Interrupt rather than kill, as a terminal's Ctrl-C would.
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = interruptGrace
+	stdout := &progressWriter{progress: r.opts.Progress}
+	cmd.Stdout = stdout
 
 	err := cmd.Run()
-	if err != nil && stdout.Len() > 0 {
+	stdout.flush()
+	if err != nil && ctx.Err() == nil && runCtx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("%s timed out after %s; raise the limit with --timeout", name, timeout)
+	}
+	if err != nil && stdout.result.Len() > 0 {
 		// Subprocess may have written results before failing — return partial output.
-		return stdout.Bytes(), err
+		return stdout.result.Bytes(), err
 	}
 	if err != nil {
 		return nil, fmt.Errorf("run %s: %w", name, err)
 	}
-	return stdout.Bytes(), nil
+	return stdout.result.Bytes(), nil
 }
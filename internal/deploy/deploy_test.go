@@ -71,3 +71,13 @@ func TestEnvBuilderAnonymousOmitsToken(t *testing.T) {
 		t.Error("anonymous build must not set an auth token")
 	}
 }
+
+func TestEnvBuilderForwardsTags(t *testing.T) {
+	env, err := EnvBuilder{BaseURL: "http://x/api", Tags: []string{"project:shop", "team:a"}}.Build(context.Background())
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if v, _ := envValue(env, EnvTags); v != "project:shop,team:a" {
+		t.Errorf("%s = %q", EnvTags, v)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the project manifest deploy looks for in the working directory.
+const ManifestFile = "conductor.yaml"
+
+// reservedEnv are the variables deploy sets itself; a package's env cannot
+// override them. Its profile chooses the server.
+var reservedEnv = []string{EnvServerURL, EnvAuthToken, EnvAutoStart, EnvTags}
+
+// Manifest lists the agent packages of a multi-package project, deployed in order.
+// Project names the project in the tag deploy writes onto its agents (default: the
+// name of the manifest's directory).
+type Manifest struct {
+	Project  string    `yaml:"project"`
+	Packages []Package `yaml:"packages"`
+}
+
+// Package is one agent package in a manifest. Path is relative to the manifest;
+// Language and Package override detection as --language and --package do.
+// Include and Exclude narrow the discovered agents, Env is added to the SDK's
+// environment and Profile names the CLI profile to deploy to (default: the
+// active one).
+type Package struct {
+	Name     string            `yaml:"name"`
+	Path     string            `yaml:"path"`
+	Language string            `yaml:"language"`
+	Package  string            `yaml:"package"`
+	Include  []string          `yaml:"include"`
+	Exclude  []string          `yaml:"exclude"`
+	Env      map[string]string `yaml:"env"`
+	Profile  string            `yaml:"profile"`
+}
+
+// ParseManifest decodes a manifest and checks that every package can be deployed.
+// Unknown keys are rejected, so a misspelled "exclude" fails loudly instead of
+// deploying everything. A package's name defaults to its path.
+func ParseManifest(data []byte) (Manifest, error) {
+	var m Manifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return Manifest{}, fmt.Errorf("parse %s: %w", ManifestFile, err)
+	}
+	if len(m.Packages) == 0 {
+		return Manifest{}, fmt.Errorf("%s lists no packages", ManifestFile)
+	}
+	seen := map[string]bool{}
+	for i := range m.Packages {
+		p := &m.Packages[i]
+		if p.Path == "" {
+			return Manifest{}, fmt.Errorf("%s: package %d has no path", ManifestFile, i+1)
+		}
+		if filepath.IsAbs(p.Path) || strings.HasPrefix(filepath.Clean(p.Path), "..") {
+			return Manifest{}, fmt.Errorf("%s: package path %q must be inside the project", ManifestFile, p.Path)
+		}
+		if p.Name == "" {
+			p.Name = filepath.ToSlash(filepath.Clean(p.Path))
+		}
+		if seen[p.Name] {
+			return Manifest{}, fmt.Errorf("%s: duplicate package name %q", ManifestFile, p.Name)
+		}
+		seen[p.Name] = true
+		if len(p.Include) > 0 && len(p.Exclude) > 0 {
+			return Manifest{}, fmt.Errorf("%s: package %q sets both include and exclude", ManifestFile, p.Name)
+		}
+		for k := range p.Env {
+			if slices.Contains(reservedEnv, k) {
+				return Manifest{}, fmt.Errorf("%s: package %q cannot set %s, which deploy sets itself", ManifestFile, p.Name, k)
+			}
+		}
+	}
+	return m, nil
+}
+
+// Selects reports whether the package's include/exclude lists keep agent name.
+func (p Package) Selects(name string) bool {
+	if len(p.Include) > 0 {
+		return slices.Contains(p.Include, name)
+	}
+	return !slices.Contains(p.Exclude, name)
+}
+
+// WithEnv returns env with the package's variables set, replacing any inherited
+// values of the same name.
+func (p Package) WithEnv(env []string) []string {
+	if len(p.Env) == 0 {
+		return env
+	}
+	out := make([]string, 0, len(env)+len(p.Env))
+	for _, e := range env {
+		key, _, _ := strings.Cut(e, "=")
+		if _, ok := p.Env[key]; !ok {
+			out = append(out, e)
+		}
+	}
+	keys := make([]string, 0, len(p.Env))
+	for k := range p.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		out = append(out, k+"="+p.Env[k])
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deploy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	m, err := ParseManifest([]byte(`
+packages:
+  - path: services/support
+    language: python
+    exclude: [draft]
+  - name: billing
+    path: ./billing/
+    include: [invoicer]
+    env: {REGION: eu}
+    profile: prod
+`))
+	if err != nil {
+		t.Fatalf("ParseManifest: %v", err)
+	}
+	if len(m.Packages) != 2 {
+		t.Fatalf("packages = %d, want 2", len(m.Packages))
+	}
+	if got := m.Packages[0].Name; got != "services/support" {
+		t.Errorf("default name = %q, want the path", got)
+	}
+	if p := m.Packages[1]; p.Name != "billing" || p.Profile != "prod" || p.Env["REGION"] != "eu" {
+		t.Errorf("billing = %+v", p)
+	}
+}
+
+func TestParseManifestRejects(t *testing.T) {
+	tests := []struct {
+		name, yaml, wantErr string
+	}{
+		{"no packages", "packages: []", "no packages"},
+		{"unknown key", "packages:\n  - path: a\n    exlude: [x]", "exlude"},
+		{"missing path", "packages:\n  - name: a", "no path"},
+		{"outside project", "packages:\n  - path: ../other", "inside the project"},
+		{"duplicate", "packages:\n  - path: a\n  - path: b\n    name: a", "duplicate"},
+		{"include and exclude", "packages:\n  - path: a\n    include: [x]\n    exclude: [y]", "both"},
+		{"connection env", "packages:\n  - path: a\n    env: {AGENTSPAN_SERVER_URL: x}", "deploy sets"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPackageSelectsAndWithEnv(t *testing.T) {
+	inc := Package{Include: []string{"a"}}
+	exc := Package{Exclude: []string{"a"}}
+	if !inc.Selects("a") || inc.Selects("b") || exc.Selects("a") || !exc.Selects("b") {
+		t.Error("include/exclude selection is wrong")
+	}
+
+	p := Package{Env: map[string]string{"REGION": "eu", "DEBUG": "1"}}
+	got := p.WithEnv([]string{"PATH=/bin", "REGION=us"})
+	want := []string{"PATH=/bin", "DEBUG=1", "REGION=eu"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithEnv = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Progress stages the language tools report. Tools may report others; they are
+// passed through for display.
+const (
+	StageDiscovering = "discovering"
+	StageDeploying   = "deploying"
+	StageDeployed    = "deployed"
+	StageFailed      = "failed"
+)
+
+// ProgressEvent is one progress line a language tool writes to stdout ahead of its
+// result, as a single-line JSON object with a "progress" key:
+//
+//	{"progress": "deploying", "agent": "support"}
+//
+// Tools that write no progress lines keep working: their stdout is all result.
+type ProgressEvent struct {
+	Stage   string `json:"progress"`
+	Agent   string `json:"agent,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// parseProgress returns the event on line, if line is a progress line.
+func parseProgress(line []byte) (ProgressEvent, bool) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return ProgressEvent{}, false
+	}
+	var probe struct {
+		Stage *string `json:"progress"`
+	}
+	if json.Unmarshal(line, &probe) != nil || probe.Stage == nil {
+		return ProgressEvent{}, false
+	}
+	var e ProgressEvent
+	if json.Unmarshal(line, &e) != nil {
+		return ProgressEvent{}, false
+	}
+	return e, true
+}
+
+// progressWriter splits a tool's stdout into progress events, handed to progress
+// as each line completes, and the result, which is everything else.
+type progressWriter struct {
+	progress func(ProgressEvent)
+	partial  []byte
+	result   bytes.Buffer
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.line(w.partial[:i+1])
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush handles a final line that has no newline.
+func (w *progressWriter) flush() {
+	if len(w.partial) > 0 {
+		w.line(w.partial)
+		w.partial = nil
+	}
+}
+
+func (w *progressWriter) line(line []byte) {
+	if e, ok := parseProgress(line); ok {
+		if w.progress != nil {
+			w.progress(e)
+		}
+		return
+	}
+	w.result.Write(line)
+}
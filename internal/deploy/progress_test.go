@@ -0,0 +1,51 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deploy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProgressWriterSplitsEventsFromResult(t *testing.T) {
+	var events []ProgressEvent
+	w := &progressWriter{progress: func(e ProgressEvent) { events = append(events, e) }}
+
+	// Writes split mid-line, as pipe reads do.
+	for _, chunk := range []string{
+		`{"progress": "deploying", "ag`, "ent\": \"support\"}\n[\n  {\"agent_name\": \"support\"",
+		", \"success\": true}\n]\n", `{"progress":"deployed","agent":"support"}`,
+	} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.flush()
+
+	want := []ProgressEvent{{Stage: StageDeploying, Agent: "support"}, {Stage: StageDeployed, Agent: "support"}}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+	if got := w.result.String(); got != "[\n  {\"agent_name\": \"support\", \"success\": true}\n]\n" {
+		t.Errorf("result = %q", got)
+	}
+}
+
+func TestParseProgressIgnoresResultObjects(t *testing.T) {
+	for _, line := range []string{`{"name": "support", "framework": ""}`, `[{"progress": "x"}]`, `not json`, ``} {
+		if _, ok := parseProgress([]byte(line)); ok {
+			t.Errorf("%q parsed as progress", line)
+		}
+	}
+}
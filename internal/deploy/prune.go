@@ -0,0 +1,61 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// projectTagPrefix marks the tag that records which project deployed an agent.
+const projectTagPrefix = "project:"
+
+// ProjectTag returns the tag deploy writes onto a project's agents. The tag
+// travels in a comma-separated variable, so a project name cannot contain commas.
+func ProjectTag(project string) (string, error) {
+	project = strings.TrimSpace(project)
+	if project == "" || strings.Contains(project, ",") {
+		return "", fmt.Errorf("invalid project name %q: it must be non-empty and contain no commas", project)
+	}
+	return projectTagPrefix + project, nil
+}
+
+// ProjectOrphans returns the agents carrying tag whose names are not in keep:
+// agents an earlier deploy of the project registered that it no longer defines.
+// Agents without the tag are never orphans, whoever deployed them. The result is
+// sorted by name.
+func ProjectOrphans(agents []agent.AgentSummary, tag string, keep []string) []agent.AgentSummary {
+	var orphans []agent.AgentSummary
+	for _, a := range agents {
+		if slices.Contains(a.Tags, tag) && !slices.Contains(keep, a.Name) {
+			orphans = append(orphans, a)
+		}
+	}
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Name < orphans[j].Name })
+	return orphans
+}
+
+// Tagged reports whether an agent's stored definition carries tag. An SDK that
+// ignores the tags deploy asks for registers definitions without it.
+func Tagged(def json.RawMessage, tag string) bool {
+	var probe struct {
+		Tags []string `json:"tags"`
+	}
+	return json.Unmarshal(def, &probe) == nil && slices.Contains(probe.Tags, tag)
+}
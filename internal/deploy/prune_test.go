@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deploy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+func TestProjectTag(t *testing.T) {
+	if tag, err := ProjectTag(" shop "); err != nil || tag != "project:shop" {
+		t.Errorf("ProjectTag = %q, %v", tag, err)
+	}
+	for _, bad := range []string{"", "a,b"} {
+		if _, err := ProjectTag(bad); err == nil {
+			t.Errorf("ProjectTag(%q) should fail", bad)
+		}
+	}
+}
+
+func TestProjectOrphans(t *testing.T) {
+	const tag = "project:shop"
+	listed := []agent.AgentSummary{
+		{Name: "support", Tags: []string{tag}},
+		{Name: "renamed_old", Tags: []string{"team:x", tag}},
+		{Name: "other_project", Tags: []string{"project:blog"}},
+		{Name: "hand_made"},
+		{Name: "abandoned", Tags: []string{tag}},
+	}
+
+	var names []string
+	for _, a := range ProjectOrphans(listed, tag, []string{"support", "hand_made"}) {
+		names = append(names, a.Name)
+	}
+	if want := []string{"abandoned", "renamed_old"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("orphans = %q, want %q", names, want)
+	}
+}
+
+func TestTagged(t *testing.T) {
+	const tag = "project:shop"
+	for def, want := range map[string]bool{
+		`{"name":"a","tags":["team:x","project:shop"]}`: true,
+		`{"name":"a","tags":["project:blog"]}`:          false,
+		`{"name":"a"}`:                                  false,
+		`not json`:                                      false,
+	} {
+		if got := Tagged(json.RawMessage(def), tag); got != want {
+			t.Errorf("Tagged(%s) = %v, want %v", def, got, want)
+		}
+	}
+}
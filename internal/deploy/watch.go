@@ -0,0 +1,177 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Polling defaults for Watch. Polling rather than OS notifications keeps the
+// watcher dependency-free and identical on every platform; a project's source
+// tree is small enough to stat twice a second.
+const (
+	DefaultWatchInterval = 500 * time.Millisecond
+	DefaultWatchQuiet    = time.Second
+)
+
+// skippedDirs are never watched: VCS metadata, installed dependencies, virtual
+// environments and build output change often and never hold agent source.
+var skippedDirs = map[string]bool{
+	".git":         true,
+	".gradle":      true,
+	".venv":        true,
+	"venv":         true,
+	"__pycache__":  true,
+	"node_modules": true,
+	"build":        true,
+	"dist":         true,
+	"target":       true,
+}
+
+// WatchRoot is a directory to watch and the file-name globs that count as source
+// in it, e.g. "*.py".
+type WatchRoot struct {
+	Dir      string
+	Patterns []string
+}
+
+// WatchOptions tunes Watch; zero values take the defaults.
+type WatchOptions struct {
+	Interval time.Duration // between polls
+	Quiet    time.Duration // with no further change before a batch is reported
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Snapshot records the modification time and size of each watched file.
+type Snapshot map[string]fileStamp
+
+// TakeSnapshot stats every file under the roots that matches a root's patterns.
+// Files that vanish mid-walk are skipped rather than failing the snapshot.
+func TakeSnapshot(roots []WatchRoot) (Snapshot, error) {
+	s := Snapshot{}
+	for _, root := range roots {
+		err := filepath.WalkDir(root.Dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if path == root.Dir {
+					return err
+				}
+				return nil
+			}
+			if d.IsDir() {
+				if path != root.Dir && skippedDirs[d.Name()] {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !matchesAny(d.Name(), root.Patterns) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			s[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func matchesAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Changed returns the files added, removed or modified between s and next, sorted.
+func (s Snapshot) Changed(next Snapshot) []string {
+	var changed []string
+	for path, stamp := range next {
+		if old, ok := s[path]; !ok || !old.modTime.Equal(stamp.modTime) || old.size != stamp.size {
+			changed = append(changed, path)
+		}
+	}
+	for path := range s {
+		if _, ok := next[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Watch polls the roots and calls onChange with the files changed since the last
+// call, once no further change has been seen for the quiet period, so an editor's
+// burst of writes triggers one call. onChange runs on Watch's goroutine; changes
+// made while it runs are reported on the next call. Watch returns nil when ctx is
+// cancelled.
+func Watch(ctx context.Context, roots []WatchRoot, opts WatchOptions, onChange func(changed []string)) error {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+	if opts.Quiet <= 0 {
+		opts.Quiet = DefaultWatchQuiet
+	}
+	prev, err := TakeSnapshot(roots)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	pending := map[string]bool{}
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			next, err := TakeSnapshot(roots)
+			if err != nil {
+				return err
+			}
+			if changed := prev.Changed(next); len(changed) > 0 {
+				for _, path := range changed {
+					pending[path] = true
+				}
+				prev, lastChange = next, now
+				continue
+			}
+			if len(pending) == 0 || now.Sub(lastChange) < opts.Quiet {
+				continue
+			}
+			batch := make([]string, 0, len(pending))
+			for path := range pending {
+				batch = append(batch, path)
+			}
+			sort.Strings(batch)
+			pending = map[string]bool{}
+			onChange(batch)
+		}
+	}
+}
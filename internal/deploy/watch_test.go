@@ -0,0 +1,102 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotMatchesPatternsAndSkipsDependencies(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "agents", "support.py"), "x")
+	writeFile(t, filepath.Join(dir, "README.md"), "x")
+	writeFile(t, filepath.Join(dir, ".venv", "lib", "site.py"), "x")
+
+	s, err := TakeSnapshot([]WatchRoot{{Dir: dir, Patterns: []string{"*.py"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s) != 1 {
+		t.Fatalf("snapshot = %v, want only agents/support.py", s)
+	}
+
+	writeFile(t, filepath.Join(dir, "agents", "support.py"), "changed")
+	writeFile(t, filepath.Join(dir, "agents", "billing.py"), "x")
+	next, err := TakeSnapshot([]WatchRoot{{Dir: dir, Patterns: []string{"*.py"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "agents", "billing.py"), filepath.Join(dir, "agents", "support.py")}
+	if got := s.Changed(next); !reflect.DeepEqual(got, want) {
+		t.Errorf("Changed = %q, want %q", got, want)
+	}
+	if got := next.Changed(s); len(got) != 2 {
+		t.Errorf("reverse Changed = %q, want the removal and the change", got)
+	}
+}
+
+func TestWatchDebouncesABurstIntoOneCall(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "agent.go")
+	writeFile(t, file, "v0")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	calls := make(chan []string, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, []WatchRoot{{Dir: dir, Patterns: []string{"*.go"}}},
+			WatchOptions{Interval: 10 * time.Millisecond, Quiet: 100 * time.Millisecond},
+			func(changed []string) { calls <- changed })
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	for i := 1; i <= 3; i++ {
+		writeFile(t, file, "v"+string(rune('0'+i)))
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	select {
+	case changed := <-calls:
+		if !reflect.DeepEqual(changed, []string{file}) {
+			t.Errorf("changed = %q, want %q", changed, file)
+		}
+	case <-ctx.Done():
+		t.Fatal("no change reported")
+	}
+	select {
+	case extra := <-calls:
+		t.Errorf("burst reported more than once; extra call %q", extra)
+	case <-time.After(250 * time.Millisecond):
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Watch: %v", err)
+	}
+}
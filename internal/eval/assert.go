@@ -0,0 +1,262 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// observation is what a case's stream showed, reduced to what assertions read.
+type observation struct {
+	tools      []string
+	guardrails []string // names of failed guardrails
+	turns      int
+	output     agent.RawValue
+	finished   bool
+	errText    string
+	duration   time.Duration
+}
+
+// observe reads a case's events. Events do not mark model calls, so turns is only
+// estimated from them: one per run of tool calls and results, which the model
+// asked for together, plus the call giving the final answer. llmTurns replaces it
+// when the execution's status can be read.
+func observe(events []agent.SSEEvent) observation {
+	var o observation
+	inToolRound := false
+	for _, e := range events {
+		p := e.Payload()
+		typ := e.ResolvedType()
+		switch typ {
+		case agent.EventToolCall:
+			o.tools = append(o.tools, p.ToolName)
+			if !inToolRound {
+				o.turns++
+			}
+		case agent.EventGuardrailFail:
+			o.guardrails = append(o.guardrails, p.GuardrailName)
+		case agent.EventDone:
+			o.output, o.finished = p.Output, true
+			o.turns++
+		case agent.EventError:
+			o.errText = p.Content
+		}
+		inToolRound = typ == agent.EventToolCall || typ == agent.EventToolResult
+	}
+	return o
+}
+
+// llmTurns counts the finished model calls in an execution's status detail.
+func llmTurns(raw json.RawMessage) (int, error) {
+	status, err := agent.ParseStatus(raw)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, t := range status.Tasks {
+		if t.Kind == agent.TaskLLM && !t.Open() {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// check returns one message per failed assertion; none means the case passed.
+func check(exp Expect, o observation) []string {
+	var failures []string
+	fail := func(format string, args ...any) { failures = append(failures, fmt.Sprintf(format, args...)) }
+
+	if o.errText != "" {
+		fail("execution failed: %s", o.errText)
+	} else if !o.finished {
+		fail("execution did not finish")
+	}
+
+	called := map[string]bool{}
+	for _, t := range o.tools {
+		called[t] = true
+	}
+	for _, t := range exp.Tools {
+		if !called[t] {
+			fail("expected tool %s to be called; called: %s", t, listOrNone(o.tools))
+		}
+	}
+	for _, t := range exp.NoTools {
+		if called[t] {
+			fail("tool %s was called", t)
+		}
+	}
+	if exp.NoGuardrailFail && len(o.guardrails) > 0 {
+		fail("guardrail failed: %s", strings.Join(o.guardrails, ", "))
+	}
+	if exp.MaxTurns > 0 && o.turns > exp.MaxTurns {
+		fail("took %d turns, max %d", o.turns, exp.MaxTurns)
+	}
+	if max := time.Duration(exp.MaxDuration); max > 0 && o.duration > max {
+		fail("took %s, max %s", o.duration.Round(time.Millisecond), max)
+	}
+	if exp.Output != nil && o.finished {
+		failures = append(failures, checkOutput(*exp.Output, o.output)...)
+	}
+	return failures
+}
+
+func checkOutput(exp OutputExpect, output agent.RawValue) []string {
+	var failures []string
+	if exp.Regex != "" {
+		if !regexp.MustCompile(exp.Regex).MatchString(output.String()) {
+			failures = append(failures, fmt.Sprintf("output %q does not match /%s/", output.String(), exp.Regex))
+		}
+	}
+	if exp.JSONPath == "" {
+		return failures
+	}
+
+	var doc any
+	if err := json.Unmarshal(output, &doc); err != nil {
+		return append(failures, fmt.Sprintf("output is not JSON, cannot select %s", exp.JSONPath))
+	}
+	steps, _ := parsePath(exp.JSONPath)
+	v, ok := selectPath(doc, steps)
+	if !ok {
+		return append(failures, fmt.Sprintf("%s not found in output", exp.JSONPath))
+	}
+	if exp.Equals != nil {
+		want := normalizeJSON(exp.Equals)
+		if !reflect.DeepEqual(v, want) {
+			failures = append(failures, fmt.Sprintf("%s = %s, want %s", exp.JSONPath, compact(v), compact(want)))
+		}
+	}
+	if exp.Matches != "" {
+		text, isString := v.(string)
+		if !isString {
+			text = compact(v)
+		}
+		if !regexp.MustCompile(exp.Matches).MatchString(text) {
+			failures = append(failures, fmt.Sprintf("%s = %q does not match /%s/", exp.JSONPath, text, exp.Matches))
+		}
+	}
+	return failures
+}
+
+// pathStep is one step of a JSON path: an object key, or an array index when key
+// is empty.
+type pathStep struct {
+	key   string
+	index int
+}
+
+// parsePath reads the subset of JSONPath assertions need: $ followed by .key,
+// ['key'] and [n] steps.
+func parsePath(path string) ([]pathStep, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("json path %q must start with $", path)
+	}
+	var steps []pathStep
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("json path %q: unclosed ['", path)
+			}
+			steps = append(steps, pathStep{key: rest[2:end]})
+			rest = rest[end+2:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("json path %q: unclosed [", path)
+			}
+			n, err := strconv.Atoi(rest[1:end])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("json path %q: invalid index %q", path, rest[1:end])
+			}
+			steps = append(steps, pathStep{index: n})
+			rest = rest[end+1:]
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("json path %q: empty key", path)
+			}
+			steps = append(steps, pathStep{key: rest[:end]})
+			rest = rest[end:]
+		default:
+			return nil, fmt.Errorf("json path %q: unexpected %q", path, rest)
+		}
+	}
+	return steps, nil
+}
+
+func selectPath(v any, steps []pathStep) (any, bool) {
+	for _, s := range steps {
+		if s.key != "" {
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[s.key]; !ok {
+				return nil, false
+			}
+			continue
+		}
+		a, ok := v.([]any)
+		if !ok || s.index >= len(a) {
+			return nil, false
+		}
+		v = a[s.index]
+	}
+	return v, true
+}
+
+// normalizeJSON round-trips a YAML value through JSON so it compares equal to the
+// same value decoded from the output (YAML ints become float64, and so on).
+func normalizeJSON(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if json.Unmarshal(data, &out) != nil {
+		return v
+	}
+	return out
+}
+
+func compact(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
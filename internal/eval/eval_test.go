@@ -0,0 +1,319 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// fakeClient is an agent.Client whose executions replay a script chosen by prompt.
+// A prompt with no script streams nothing until the context ends.
+type fakeClient struct {
+	mu        sync.Mutex
+	scripts   map[string][]agent.SSEEvent
+	prompts   map[string]string
+	responded []string
+	running   int
+	maxRun    int
+	// statuses are the status details by prompt; without one, Status fails.
+	statuses map[string]json.RawMessage
+}
+
+func newFakeClient(scripts map[string][]agent.SSEEvent) *fakeClient {
+	return &fakeClient{scripts: scripts, prompts: map[string]string{}}
+}
+
+func ev(typ agent.EventType, data string) agent.SSEEvent {
+	return agent.SSEEvent{Type: typ, Data: []byte(data)}
+}
+
+func (f *fakeClient) Run(ctx context.Context, req agent.RunRequest) (agent.Execution, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := fmt.Sprintf("e%d", len(f.prompts)+1)
+	f.prompts[id] = req.Prompt
+	return agent.Execution{ID: id, AgentName: req.Name}, nil
+}
+
+func (f *fakeClient) Stream(ctx context.Context, id, lastEventID string) (<-chan agent.SSEEvent, <-chan error) {
+	f.mu.Lock()
+	script, ok := f.scripts[f.prompts[id]]
+	f.running++
+	f.maxRun = max(f.maxRun, f.running)
+	f.mu.Unlock()
+
+	events := make(chan agent.SSEEvent)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(events)
+		defer func() { f.mu.Lock(); f.running--; f.mu.Unlock() }()
+		// Overlap the streams a little so the concurrency limit is observable.
+		time.Sleep(5 * time.Millisecond)
+		if ok {
+			for _, e := range script {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+		}
+		<-ctx.Done()
+		errc <- ctx.Err()
+	}()
+	return events, errc
+}
+
+func (f *fakeClient) Respond(ctx context.Context, id string, r agent.HumanResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responded = append(f.responded, id)
+	return nil
+}
+
+func (f *fakeClient) Get(ctx context.Context, name string, v *int) (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+func (f *fakeClient) CheckSupported(ctx context.Context) error { return nil }
+func (f *fakeClient) Deploy(ctx context.Context, fw string, c json.RawMessage) (agent.DeployResult, error) {
+	return agent.DeployResult{}, nil
+}
+func (f *fakeClient) List(ctx context.Context) ([]agent.AgentSummary, error) { return nil, nil }
+func (f *fakeClient) Delete(ctx context.Context, name string, v *int) error  { return nil }
+func (f *fakeClient) Compile(ctx context.Context, d json.RawMessage) (json.RawMessage, error) {
+	return nil, nil
+}
+func (f *fakeClient) SearchExecutions(ctx context.Context, _ agent.ExecutionFilter) (agent.ExecutionPage, error) {
+	return agent.ExecutionPage{}, nil
+}
+func (f *fakeClient) GetExecution(ctx context.Context, id string) (json.RawMessage, error) {
+	return nil, nil
+}
+func (f *fakeClient) Status(ctx context.Context, id string) (json.RawMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if status, ok := f.statuses[f.prompts[id]]; ok {
+		return status, nil
+	}
+	return nil, fmt.Errorf("no status for %s", id)
+}
+func (f *fakeClient) Prune(ctx context.Context, r agent.PruneRequest) (agent.PruneResult, error) {
+	return agent.PruneResult{}, nil
+}
+
+const suiteYAML = `
+name: triage
+agent: triage
+timeout: 1s
+cases:
+  - name: lookup
+    prompt: where is order 42
+    expect:
+      tools: [lookup_order]
+      noGuardrailFail: true
+      maxTurns: 2
+      output:
+        jsonPath: $.orders[0].status
+        equals: shipped
+  - name: regex
+    prompt: say hi
+    expect:
+      output:
+        regex: "^hel+o"
+  - name: guardrail
+    prompt: be rude
+    expect:
+      noGuardrailFail: true
+      tools: [lookup_order]
+  - name: approval
+    prompt: refund
+    approve: [issue_refund]
+    expect:
+      tools: [issue_refund]
+  - name: blocked
+    prompt: delete everything
+  - name: hangs
+    prompt: never answers
+    expect:
+      maxDuration: 50ms
+`
+
+func TestRunSuite(t *testing.T) {
+	suite, err := ParseSuite([]byte(suiteYAML))
+	if err != nil {
+		t.Fatalf("ParseSuite: %v", err)
+	}
+	done := ev(agent.EventDone, `{"output":"ok"}`)
+	fc := newFakeClient(map[string][]agent.SSEEvent{
+		"where is order 42": {
+			ev(agent.EventThinking, `{"content":"look up"}`),
+			ev(agent.EventToolCall, `{"toolName":"lookup_order"}`),
+			ev(agent.EventThinking, `{"content":"answer"}`),
+			ev(agent.EventDone, `{"output":{"orders":[{"status":"shipped"}]}}`),
+		},
+		"say hi": {ev(agent.EventDone, `{"output":"hello there"}`)},
+		"be rude": {
+			ev(agent.EventGuardrailFail, `{"guardrailName":"tone"}`),
+			done,
+		},
+		"refund": {
+			ev(agent.EventWaiting, `{"pendingTool":{"name":"issue_refund"}}`),
+			ev(agent.EventToolCall, `{"toolName":"issue_refund"}`),
+			done,
+		},
+		"delete everything": {ev(agent.EventWaiting, `{"pendingTool":{"name":"drop_tables"}}`)},
+	})
+
+	var finished []string
+	r := &Runner{Service: agent.NewService(fc), Concurrency: 2, OnResult: func(c CaseResult) { finished = append(finished, c.Name) }}
+	report := r.Run(context.Background(), suite)
+
+	byName := map[string]CaseResult{}
+	for _, c := range report.Cases {
+		byName[c.Name] = c
+	}
+	if got := byName["lookup"]; !got.Passed || got.Turns != 2 || !reflect.DeepEqual(got.Tools, []string{"lookup_order"}) {
+		t.Errorf("lookup = %+v, want a pass with 2 turns and the tool call", got)
+	}
+	if got := byName["regex"]; !got.Passed {
+		t.Errorf("regex = %+v, want a pass", got)
+	}
+	if got := byName["guardrail"]; got.Passed || len(got.Failures) != 2 ||
+		!strings.Contains(got.Failures[0], "lookup_order") || !strings.Contains(got.Failures[1], "tone") {
+		t.Errorf("guardrail = %+v, want the missing tool and the guardrail failure", got)
+	}
+	if got := byName["approval"]; !got.Passed || len(fc.responded) != 1 {
+		t.Errorf("approval = %+v responded=%v, want a pass after one approval", got, fc.responded)
+	}
+	if got := byName["blocked"]; got.Passed || !strings.Contains(got.Error, "drop_tables") {
+		t.Errorf("blocked = %+v, want an error naming the pending tool", got)
+	}
+	if got := byName["hangs"]; got.Passed || !strings.Contains(got.Error, "timed out after 50ms") {
+		t.Errorf("hangs = %+v, want a timeout error", got)
+	}
+
+	if passed, failed, errored := report.Counts(); passed != 3 || failed != 1 || errored != 2 {
+		t.Errorf("Counts() = %d/%d/%d, want 3 passed, 1 failed, 2 errored", passed, failed, errored)
+	}
+	if report.Cases[0].Name != "lookup" || len(finished) != len(suite.Cases) {
+		t.Errorf("cases out of suite order or OnResult missed some: %v", finished)
+	}
+	if fc.maxRun > 2 {
+		t.Errorf("%d streams ran at once, want at most the concurrency of 2", fc.maxRun)
+	}
+}
+
+// Turns are the execution's model calls, not its thinking events: a model that
+// emits none still has its calls counted.
+func TestRunSuiteCountsModelCallsAsTurns(t *testing.T) {
+	suite, err := ParseSuite([]byte("agent: triage\ncases:\n  - {name: chatty, prompt: p, expect: {maxTurns: 2}}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := newFakeClient(map[string][]agent.SSEEvent{"p": {
+		ev(agent.EventToolCall, `{"toolName":"a"}`),
+		ev(agent.EventToolCall, `{"toolName":"b"}`),
+		ev(agent.EventDone, `{"output":"ok"}`),
+	}})
+	fc.statuses = map[string]json.RawMessage{"p": json.RawMessage(`{"tasks":[
+		{"taskType":"LLM_CHAT_COMPLETE","status":"COMPLETED","startTime":1},
+		{"taskType":"SIMPLE","status":"COMPLETED","startTime":2},
+		{"taskType":"LLM_CHAT_COMPLETE","status":"COMPLETED","startTime":3},
+		{"taskType":"SIMPLE","status":"COMPLETED","startTime":4},
+		{"taskType":"LLM_CHAT_COMPLETE","status":"COMPLETED","startTime":5}]}`)}
+
+	report := (&Runner{Service: agent.NewService(fc)}).Run(context.Background(), suite)
+	got := report.Cases[0]
+	if got.Turns != 3 || got.Passed || len(got.Failures) != 1 || got.Failures[0] != "took 3 turns, max 2" {
+		t.Errorf("chatty = %+v, want 3 turns failing maxTurns 2", got)
+	}
+}
+
+func TestParseSuiteRejectsBadCases(t *testing.T) {
+	for name, yaml := range map[string]string{
+		"no cases":     "name: x\n",
+		"unknown key":  "agent: a\ncases:\n  - prompt: p\n    expect:\n      tool: [x]\n",
+		"no agent":     "cases:\n  - prompt: p\n",
+		"no prompt":    "agent: a\ncases:\n  - name: c\n",
+		"duplicate":    "agent: a\ncases:\n  - {name: c, prompt: p}\n  - {name: c, prompt: q}\n",
+		"bad regex":    "agent: a\ncases:\n  - prompt: p\n    expect: {output: {regex: '('}}\n",
+		"bad path":     "agent: a\ncases:\n  - prompt: p\n    expect: {output: {jsonPath: 'a.b'}}\n",
+		"bad duration": "agent: a\ntimeout: soon\ncases:\n  - prompt: p\n",
+	} {
+		if _, err := ParseSuite([]byte(yaml)); err == nil {
+			t.Errorf("%s: ParseSuite succeeded, want an error", name)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	steps, err := parsePath("$.a['b c'][2].d")
+	want := []pathStep{{key: "a"}, {key: "b c"}, {index: 2}, {key: "d"}}
+	if err != nil || !reflect.DeepEqual(steps, want) {
+		t.Errorf("parsePath = %+v, %v; want %+v", steps, err, want)
+	}
+}
+
+func TestReports(t *testing.T) {
+	report := Report{
+		Suite:   "triage",
+		Started: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Cases: []CaseResult{
+			{Name: "ok", Agent: "triage", Passed: true, Duration: 1500 * time.Millisecond, ExecutionID: "e1"},
+			{Name: "bad", Agent: "triage", Failures: []string{"tool x was called", "took 3 turns, max 2"}},
+			{Name: "err", Agent: "triage", Error: "timed out after 1s"},
+		},
+	}
+
+	var xmlOut bytes.Buffer
+	if err := report.WriteJUnit(&xmlOut); err != nil {
+		t.Fatal(err)
+	}
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(xmlOut.Bytes(), &parsed); err != nil {
+		t.Fatalf("JUnit output does not parse: %v\n%s", err, xmlOut.String())
+	}
+	s := parsed.Suites[0]
+	if s.Tests != 3 || s.Failures != 1 || s.Errors != 1 || s.Cases[0].Time != "1.500" ||
+		s.Cases[1].Failure == nil || s.Cases[2].Error == nil {
+		t.Errorf("JUnit suite = %+v", s)
+	}
+
+	var jsonOut bytes.Buffer
+	if err := report.WriteJSON(&jsonOut); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Passed int `json:"passed"`
+		Cases  []struct {
+			DurationMs int64 `json:"durationMs"`
+		} `json:"cases"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil || decoded.Passed != 1 || decoded.Cases[0].DurationMs != 1500 {
+		t.Errorf("JSON report = %s (err %v), want passed 1 and durations in ms", jsonOut.String(), err)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package eval
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// junitSuiteName names the JUnit suite when the eval suite has no name.
+const junitSuiteName = "agent-eval"
+
+// CaseResult is the outcome of one case. Error means the case could not be judged
+// (it did not start, timed out, or blocked); Failures lists the assertions that did
+// not hold.
+type CaseResult struct {
+	Name        string        `json:"name"`
+	Agent       string        `json:"agent"`
+	Prompt      string        `json:"prompt"`
+	ExecutionID string        `json:"executionId,omitempty"`
+	Passed      bool          `json:"passed"`
+	Duration    time.Duration `json:"-"`
+	Turns       int           `json:"turns"`
+	Tools       []string      `json:"tools,omitempty"`
+	Output      string        `json:"output,omitempty"`
+	Failures    []string      `json:"failures,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// Report is the outcome of a suite, cases in suite order.
+type Report struct {
+	Suite    string        `json:"suite"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"-"`
+	Cases    []CaseResult  `json:"cases"`
+}
+
+// Counts returns how many cases passed, failed an assertion, and errored.
+func (r Report) Counts() (passed, failed, errored int) {
+	for _, c := range r.Cases {
+		switch {
+		case c.Passed:
+			passed++
+		case c.Error != "":
+			errored++
+		default:
+			failed++
+		}
+	}
+	return passed, failed, errored
+}
+
+// WriteJSON writes the report as indented JSON with pass/fail counts; durations are
+// in milliseconds.
+func (r Report) WriteJSON(w io.Writer) error {
+	type caseJSON struct {
+		CaseResult
+		Duration int64 `json:"durationMs"`
+	}
+	passed, failed, errored := r.Counts()
+	out := struct {
+		Suite    string     `json:"suite"`
+		Started  time.Time  `json:"started"`
+		Duration int64      `json:"durationMs"`
+		Passed   int        `json:"passed"`
+		Failed   int        `json:"failed"`
+		Errored  int        `json:"errored"`
+		Cases    []caseJSON `json:"cases"`
+	}{r.Suite, r.Started, r.Duration.Milliseconds(), passed, failed, errored, make([]caseJSON, len(r.Cases))}
+	for i, c := range r.Cases {
+		out.Cases[i] = caseJSON{CaseResult: c, Duration: c.Duration.Milliseconds()}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// JUnit XML shapes, as CI systems read them.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML: one testsuite, one testcase per case,
+// the agent as its class name and the execution id in system-out.
+func (r Report) WriteJUnit(w io.Writer) error {
+	name := r.Suite
+	if name == "" {
+		name = junitSuiteName
+	}
+	_, failed, errored := r.Counts()
+	suite := junitTestSuite{
+		Name:      name,
+		Tests:     len(r.Cases),
+		Failures:  failed,
+		Errors:    errored,
+		Time:      seconds(r.Duration),
+		Timestamp: r.Started.UTC().Format(time.RFC3339),
+	}
+	for _, c := range r.Cases {
+		tc := junitTestCase{Name: c.Name, ClassName: c.Agent, Time: seconds(c.Duration)}
+		if c.ExecutionID != "" {
+			tc.SystemOut = "execution: " + c.ExecutionID
+		}
+		switch {
+		case c.Error != "":
+			tc.Error = &junitProblem{Message: c.Error, Body: c.Error}
+		case !c.Passed:
+			tc.Failure = &junitProblem{Message: c.Failures[0], Body: strings.Join(c.Failures, "\n")}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
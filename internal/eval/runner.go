@@ -0,0 +1,124 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// approveReason is recorded on responses the suite sends for approved tools.
+const approveReason = "approved by agent eval"
+
+// Service is the slice of agent.Service a suite needs.
+type Service interface {
+	Run(ctx context.Context, req agent.RunRequest) (agent.Execution, error)
+	StreamExecution(ctx context.Context, executionID, lastEventID string, sink agent.EventSink) error
+	Respond(ctx context.Context, id string, resp agent.HumanResponse) error
+	Status(ctx context.Context, id string) (json.RawMessage, error)
+}
+
+// Runner runs suites against a service.
+type Runner struct {
+	Service     Service
+	Concurrency int
+	// OnResult, when set, is called as each case finishes, in completion order.
+	OnResult func(CaseResult)
+	now      func() time.Time
+}
+
+// Run executes every case, at most Concurrency at a time, and reports them in
+// suite order. A case that cannot start is reported as errored; it does not stop
+// the others.
+func (r *Runner) Run(ctx context.Context, s Suite) Report {
+	now := r.now
+	if now == nil {
+		now = time.Now
+	}
+	report := Report{Suite: s.Name, Started: now()}
+	report.Cases = agent.RunEach(len(s.Cases), r.Concurrency, func(i int) CaseResult {
+		return r.runCase(ctx, s, s.Cases[i], now)
+	}, r.OnResult)
+	report.Duration = now().Sub(report.Started)
+	return report
+}
+
+func (r *Runner) runCase(ctx context.Context, s Suite, c Case, now func() time.Time) CaseResult {
+	res := CaseResult{Name: c.Name, Agent: c.Agent, Prompt: c.Prompt}
+	if res.Agent == "" {
+		res.Agent = c.Config
+	}
+
+	timeout := s.timeout(c)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := now()
+	req := agent.RunRequest{Name: c.Agent, Prompt: c.Prompt, SessionID: c.SessionID}
+	if len(c.Definition) > 0 {
+		req = agent.RunRequest{Definition: c.Definition, Prompt: c.Prompt, SessionID: c.SessionID}
+	}
+	exec, err := r.Service.Run(ctx, req)
+	if err != nil {
+		res.Error = fmt.Sprintf("start: %v", err)
+		res.Duration = now().Sub(start)
+		return res
+	}
+	res.ExecutionID = exec.ID
+
+	col := &collector{}
+	approver := agent.NewAutoApprover(ctx, r.Service, exec.ID, c.Approve, approveReason, "list it under approve to answer it")
+	err = r.Service.StreamExecution(ctx, exec.ID, "", agent.MultiSink(col, approver))
+	res.Duration = now().Sub(start)
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		res.Error = fmt.Sprintf("timed out after %s", timeout)
+	case approver.Blocked != "":
+		res.Error = approver.Blocked
+	case err != nil:
+		res.Error = fmt.Sprintf("stream: %v", err)
+	}
+
+	o := observe(col.events)
+	o.duration = res.Duration
+	if raw, err := r.Service.Status(ctx, exec.ID); err == nil {
+		if turns, err := llmTurns(raw); err == nil {
+			o.turns = turns
+		}
+	}
+	res.Output = o.output.String()
+	res.Tools = o.tools
+	res.Turns = o.turns
+	if res.Error == "" {
+		res.Failures = check(c.Expect, o)
+	}
+	res.Passed = res.Error == "" && len(res.Failures) == 0
+	return res
+}
+
+// collector keeps a case's events.
+type collector struct {
+	events []agent.SSEEvent
+}
+
+func (c *collector) OnEvent(e agent.SSEEvent) error {
+	c.events = append(c.events, e)
+	return nil
+}
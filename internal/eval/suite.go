@@ -0,0 +1,157 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package eval runs agent evaluation suites: each case starts an agent with a
+// prompt, collects the streamed events and checks them against the case's
+// expectations. It works on agent.Service alone, so a fake agent.Client is all a
+// test needs.
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTimeout bounds a case that sets neither maxDuration nor a suite timeout.
+const DefaultTimeout = 5 * time.Minute
+
+// Suite is a named set of cases. Agent, Config and Timeout are defaults for cases
+// that leave them out.
+type Suite struct {
+	Name    string   `yaml:"name"`
+	Agent   string   `yaml:"agent"`
+	Config  string   `yaml:"config"`
+	Timeout Duration `yaml:"timeout"`
+	Cases   []Case   `yaml:"cases"`
+}
+
+// Case is one prompt and what its execution must satisfy. Config is a path the
+// caller resolves into Definition; the eval package never touches the filesystem.
+type Case struct {
+	Name       string          `yaml:"name"`
+	Agent      string          `yaml:"agent"`
+	Config     string          `yaml:"config"`
+	Definition json.RawMessage `yaml:"-"`
+	Prompt     string          `yaml:"prompt"`
+	SessionID  string          `yaml:"session"`
+	// Approve lists tools whose human-input requests are approved; any other
+	// request fails the case, since nobody is there to answer it.
+	Approve []string `yaml:"approve"`
+	Expect  Expect   `yaml:"expect"`
+}
+
+// Expect holds a case's assertions; an unset field asserts nothing.
+type Expect struct {
+	// Tools must each be called at least once, in any order.
+	Tools []string `yaml:"tools"`
+	// NoTools must not be called.
+	NoTools []string `yaml:"noTools"`
+	// NoGuardrailFail fails the case on any guardrail_fail event.
+	NoGuardrailFail bool `yaml:"noGuardrailFail"`
+	// Output checks the final output of the done event.
+	Output *OutputExpect `yaml:"output"`
+	// MaxTurns caps the model turns: the execution's LLM calls, or when its status
+	// cannot be read, its rounds of tool calls plus the final answer.
+	MaxTurns int `yaml:"maxTurns"`
+	// MaxDuration caps the time from start to the terminal event; it is also the
+	// case timeout.
+	MaxDuration Duration `yaml:"maxDuration"`
+}
+
+// OutputExpect checks the final output. Regex matches the output as text. JSONPath
+// selects a value from the output as JSON ($.a.b[0]), which must then equal Equals
+// when set and match Matches when set; a path alone only requires the value to exist.
+type OutputExpect struct {
+	Regex    string `yaml:"regex"`
+	JSONPath string `yaml:"jsonPath"`
+	Equals   any    `yaml:"equals"`
+	Matches  string `yaml:"matches"`
+}
+
+// Duration is a time.Duration written as "30s" or "2m" in YAML.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	v, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: invalid duration %q", node.Line, node.Value)
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d Duration) MarshalYAML() (any, error) { return time.Duration(d).String(), nil }
+
+// ParseSuite decodes a suite, applies the suite-level defaults to its cases and
+// checks that every case can run. Unknown keys are rejected, so a misspelled
+// assertion fails loudly instead of silently asserting nothing.
+func ParseSuite(data []byte) (Suite, error) {
+	var s Suite
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil {
+		return Suite{}, fmt.Errorf("parse suite: %w", err)
+	}
+	if len(s.Cases) == 0 {
+		return Suite{}, fmt.Errorf("suite has no cases")
+	}
+	seen := map[string]bool{}
+	for i := range s.Cases {
+		c := &s.Cases[i]
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("case-%d", i+1)
+		}
+		if seen[c.Name] {
+			return Suite{}, fmt.Errorf("duplicate case name %q", c.Name)
+		}
+		seen[c.Name] = true
+		if c.Agent == "" && c.Config == "" {
+			c.Agent, c.Config = s.Agent, s.Config
+		}
+		if c.Agent == "" && c.Config == "" {
+			return Suite{}, fmt.Errorf("case %q: set agent or config on the case or the suite", c.Name)
+		}
+		if c.Prompt == "" {
+			return Suite{}, fmt.Errorf("case %q: prompt is required", c.Name)
+		}
+		if o := c.Expect.Output; o != nil {
+			for _, re := range []string{o.Regex, o.Matches} {
+				if _, err := regexp.Compile(re); err != nil {
+					return Suite{}, fmt.Errorf("case %q: %w", c.Name, err)
+				}
+			}
+			if o.JSONPath != "" {
+				if _, err := parsePath(o.JSONPath); err != nil {
+					return Suite{}, fmt.Errorf("case %q: %w", c.Name, err)
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+// timeout is how long c may run before it is abandoned.
+func (s Suite) timeout(c Case) time.Duration {
+	switch {
+	case c.Expect.MaxDuration > 0:
+		return time.Duration(c.Expect.MaxDuration)
+	case s.Timeout > 0:
+		return time.Duration(s.Timeout)
+	}
+	return DefaultTimeout
+}
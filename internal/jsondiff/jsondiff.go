@@ -0,0 +1,97 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package jsondiff holds the change that agent and workflow definition diffs
+// report, and compares the JSON values those definitions are made of.
+package jsondiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ServerManagedKeys are stamped by the server that stores a definition on every
+// save, so they differ between any two copies of it and never count as differences.
+var ServerManagedKeys = []string{"createTime", "updateTime", "updatedTime", "createdBy", "updatedBy", "ownerApp"}
+
+// Kind says how a task or field differs between two definitions.
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+	Moved   Kind = "moved"
+)
+
+// Change is one difference between two definitions. Task is the workflow task
+// the change belongs to, or "" for a field of the definition itself. Path names
+// the field; it is "" when a whole task was added, removed or moved. Old and New
+// are values as compact JSON or plain text, or for a task as a whole its type
+// and location.
+type Change struct {
+	Task string `json:"task,omitempty"`
+	Path string `json:"path,omitempty"`
+	Kind Kind   `json:"kind"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// Compare reports how b differs from a, as a change without a task or path, or
+// false when they are equal or both absent.
+func Compare(a, b json.RawMessage) (Change, bool) {
+	switch {
+	case IsAbsent(a) && IsAbsent(b):
+		return Change{}, false
+	case IsAbsent(a):
+		return Change{Kind: Added, New: Display(b)}, true
+	case IsAbsent(b):
+		return Change{Kind: Removed, Old: Display(a)}, true
+	case Equal(a, b):
+		return Change{}, false
+	default:
+		return Change{Kind: Changed, Old: Display(a), New: Display(b)}, true
+	}
+}
+
+// IsAbsent reports whether v is missing or null.
+func IsAbsent(v json.RawMessage) bool {
+	return len(v) == 0 || string(v) == "null"
+}
+
+// Equal compares two JSON values ignoring whitespace and the order of object keys.
+func Equal(a, b json.RawMessage) bool {
+	var x, y any
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return bytes.Equal(a, b)
+	}
+	xb, _ := json.Marshal(x)
+	yb, _ := json.Marshal(y)
+	return bytes.Equal(xb, yb)
+}
+
+// Display renders a value for a change: strings as their text, anything else as
+// compact JSON with sorted keys.
+func Display(v json.RawMessage) string {
+	var s string
+	if json.Unmarshal(v, &s) == nil {
+		return s
+	}
+	var x any
+	if json.Unmarshal(v, &x) != nil {
+		return strings.TrimSpace(string(v))
+	}
+	out, _ := json.Marshal(x)
+	return string(out)
+}
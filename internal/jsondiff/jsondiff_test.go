@@ -0,0 +1,42 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package jsondiff
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   string
+		want   Change
+		differ bool
+	}{
+		{name: "both absent", a: "", b: "null"},
+		{name: "reordered keys", a: `{"a":1,"b":2}`, b: `{ "b": 2, "a": 1 }`},
+		{name: "added", a: "", b: `"x"`, want: Change{Kind: Added, New: "x"}, differ: true},
+		{name: "removed", a: `{"b":1,"a":2}`, b: "null", want: Change{Kind: Removed, Old: `{"a":2,"b":1}`}, differ: true},
+		{name: "changed", a: "60", b: "120", want: Change{Kind: Changed, Old: "60", New: "120"}, differ: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, differ := Compare(json.RawMessage(tt.a), json.RawMessage(tt.b))
+			if got != tt.want || differ != tt.differ {
+				t.Errorf("Compare(%s, %s) = %+v, %v; want %+v, %v", tt.a, tt.b, got, differ, tt.want, tt.differ)
+			}
+		})
+	}
+}
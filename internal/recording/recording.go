@@ -0,0 +1,121 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package recording captures a stream of agent events as JSON Lines and plays it
+// back into any agent.EventSink with the original timing. A recording reproduces a
+// renderer bug without the server that produced it.
+package recording
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// maxLineBytes bounds one recorded event; it matches the SSE parser's line limit
+// with room for the JSON escaping of the data.
+const maxLineBytes = 4 * 1024 * 1024
+
+// Entry is one recorded event. Data is kept as the exact string the server sent —
+// not re-encoded JSON — so a replay hands sinks byte-for-byte what they saw live.
+type Entry struct {
+	Time time.Time       `json:"time"`
+	ID   string          `json:"id,omitempty"`
+	Type agent.EventType `json:"event,omitempty"`
+	Data string          `json:"data"`
+}
+
+// Event returns the entry as the event it was recorded from.
+func (e Entry) Event() agent.SSEEvent {
+	ev := agent.SSEEvent{ID: e.ID, Type: e.Type}
+	if e.Data != "" {
+		ev.Data = json.RawMessage(e.Data)
+	}
+	return ev
+}
+
+// Recorder is an agent.EventSink that writes each event as one JSON line, stamped
+// with the time it arrived.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+// NewRecorder returns a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// OnEvent writes e. A write failure is returned and so ends the stream: a recording
+// with silent gaps would be worse than none.
+func (r *Recorder) OnEvent(e agent.SSEEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(Entry{Time: r.now(), ID: e.ID, Type: e.Type, Data: string(e.Data)}); err != nil {
+		return fmt.Errorf("record event: %w", err)
+	}
+	return nil
+}
+
+// Read parses a recording. Blank lines are skipped; a malformed line is reported
+// with its line number.
+func Read(r io.Reader) ([]Entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	var entries []Entry
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Replay pushes entries into sink, waiting between them for the recorded gap
+// divided by speed. A speed of zero or less replays without waiting. Cancelling
+// ctx stops the replay cleanly; a sink error ends it and is returned.
+func Replay(ctx context.Context, entries []Entry, speed float64, sink agent.EventSink) error {
+	return replay(ctx, entries, speed, sink, agent.SleepContext)
+}
+
+func replay(ctx context.Context, entries []Entry, speed float64, sink agent.EventSink, wait func(context.Context, time.Duration) error) error {
+	for i, e := range entries {
+		if i > 0 && speed > 0 {
+			if gap := e.Time.Sub(entries[i-1].Time); gap > 0 {
+				if wait(ctx, time.Duration(float64(gap)/speed)) != nil {
+					return nil
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := sink.OnEvent(e.Event()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package recording
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+type collectSink struct{ events []agent.SSEEvent }
+
+func (c *collectSink) OnEvent(e agent.SSEEvent) error {
+	c.events = append(c.events, e)
+	return nil
+}
+
+func TestRecordReadRoundTrip(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := start
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	rec.now = func() time.Time { clock = clock.Add(250 * time.Millisecond); return clock }
+
+	in := []agent.SSEEvent{
+		{ID: "1", Type: agent.EventMessage, Data: []byte(`{"content":"hi"}`)},
+		{ID: "2", Type: agent.EventDone, Data: []byte("not\njson")},
+		{Data: []byte(`{"type":"thinking"}`)},
+	}
+	for _, e := range in {
+		if err := rec.OnEvent(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(in) {
+		t.Fatalf("recording has %d lines, want %d:\n%s", n, len(in), buf.String())
+	}
+
+	entries, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	var out []agent.SSEEvent
+	for _, e := range entries {
+		out = append(out, e.Event())
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+	if got := entries[1].Time.Sub(entries[0].Time); got != 250*time.Millisecond {
+		t.Errorf("recorded gap = %s, want 250ms", got)
+	}
+}
+
+func TestReadReportsLineNumber(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"data":"{}"}` + "\n\n{oops\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("Read err = %v, want it to point at line 3", err)
+	}
+}
+
+func TestReplayScalesGapsBySpeed(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []Entry{
+		{Time: start, Type: agent.EventMessage},
+		{Time: start.Add(2 * time.Second), Type: agent.EventMessage},
+		{Time: start.Add(3 * time.Second), Type: agent.EventDone},
+	}
+	var waits []time.Duration
+	wait := func(_ context.Context, d time.Duration) error { waits = append(waits, d); return nil }
+
+	sink := &collectSink{}
+	if err := replay(context.Background(), entries, 2, sink, wait); err != nil {
+		t.Fatal(err)
+	}
+	if len(sink.events) != 3 || !reflect.DeepEqual(waits, []time.Duration{time.Second, 500 * time.Millisecond}) {
+		t.Errorf("events=%d waits=%v, want 3 events and halved gaps", len(sink.events), waits)
+	}
+
+	waits = nil
+	if err := replay(context.Background(), entries, 0, &collectSink{}, wait); err != nil || len(waits) != 0 {
+		t.Errorf("speed 0: err=%v waits=%v, want no waiting", err, waits)
+	}
+}
+
+func TestReplayStopsOnSinkError(t *testing.T) {
+	boom := errors.New("boom")
+	entries := []Entry{{Type: agent.EventMessage}, {Type: agent.EventDone}}
+	calls := 0
+	sink := sinkFunc(func(agent.SSEEvent) error { calls++; return boom })
+	if err := Replay(context.Background(), entries, 0, sink); !errors.Is(err, boom) || calls != 1 {
+		t.Errorf("err=%v calls=%d, want boom after the first event", err, calls)
+	}
+}
+
+type sinkFunc func(agent.SSEEvent) error
+
+func (f sinkFunc) OnEvent(e agent.SSEEvent) error { return f(e) }
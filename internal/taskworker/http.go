@@ -0,0 +1,115 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package taskworker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// httpErrorBodyLimit caps how much of a failed response is kept in the task logs.
+const httpErrorBodyLimit = 4 << 10
+
+// HTTPOptions configures an HTTPHandler.
+type HTTPOptions struct {
+	// URL receives a POST per task.
+	URL string
+	// Timeout bounds a single task's request. Zero means no timeout.
+	Timeout time.Duration
+	// StopGrace is how long a request in flight when the worker stops has to
+	// finish before it is abandoned. Zero lets it run to completion.
+	StopGrace time.Duration
+	// Client sends the requests; nil uses http.DefaultClient.
+	Client *http.Client
+}
+
+// HTTPHandler serves a task by POSTing the full task JSON to a URL. The response body
+// follows the stdio contract — {status, output, logs, reason} — and is normalised the
+// same way, so a tool can move between a local program and a service unchanged. It is
+// safe for concurrent use.
+type HTTPHandler struct {
+	opts HTTPOptions
+}
+
+// NewHTTPHandler returns a Handler that POSTs each task to opts.URL.
+func NewHTTPHandler(opts HTTPOptions) *HTTPHandler {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	return &HTTPHandler{opts: opts}
+}
+
+func (h *HTTPHandler) Handle(ctx context.Context, t Task) Result {
+	log.Infof("Processing task: %s (workflow: %s)", t.ID, t.WorkflowID)
+
+	// Detached from the loop's cancellation for the reason StdioHandler gives: a task
+	// already sent should report its real result rather than a self-inflicted failure.
+	// StopGrace bounds how long stopping waits for it, so a hung endpoint cannot hold
+	// the worker open forever.
+	detached, abandon := context.WithCancel(context.WithoutCancel(ctx))
+	defer abandon()
+	reqCtx := detached
+	if h.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(detached, h.opts.Timeout)
+		defer cancel()
+	}
+	if h.opts.StopGrace > 0 {
+		stop := context.AfterFunc(ctx, func() {
+			timer := time.NewTimer(h.opts.StopGrace)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				abandon()
+			case <-detached.Done():
+			}
+		})
+		defer stop()
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, h.opts.URL, bytes.NewReader(t.Raw))
+	if err != nil {
+		return Failure(fmt.Sprintf("build worker request: %v", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.opts.Client.Do(req)
+	if err != nil {
+		return Failure(fmt.Sprintf("worker request failed: %v", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, httpErrorBodyLimit))
+		return Result{
+			Status: StatusFailed,
+			Reason: fmt.Sprintf("worker returned HTTP %d", resp.StatusCode),
+			Logs:   []string{string(body)},
+		}
+	}
+	var parsed stdioResult
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Failure(fmt.Sprintf("invalid worker response JSON: %v", err))
+	}
+
+	result := normalizeStdioResult(parsed)
+	log.Infof("Task %s handled with status: %s", t.ID, result.Status)
+	return result
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package taskworker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPHandlerPostsTaskAndParsesResult(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = w.Write([]byte(`{"status":"COMPLETED","output":{"message":"hi"}}`))
+	}))
+	defer srv.Close()
+
+	got := NewHTTPHandler(HTTPOptions{URL: srv.URL}).Handle(context.Background(), stdioTask())
+
+	if got.Status != StatusCompleted || got.Output["message"] != "hi" {
+		t.Errorf("result = %+v, want COMPLETED with message=hi", got)
+	}
+	if body != string(stdioTask().Raw) {
+		t.Errorf("request body = %s, want the full task", body)
+	}
+}
+
+func TestHTTPHandlerFailures(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		reason string
+	}{
+		{"server error", http.StatusInternalServerError, "boom", "worker returned HTTP 500"},
+		{"bad JSON", http.StatusOK, "not json", "invalid worker response JSON"},
+		{"unknown status", http.StatusOK, `{"status":"DONE"}`, "invalid status from worker"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			got := NewHTTPHandler(HTTPOptions{URL: srv.URL}).Handle(context.Background(), stdioTask())
+			if got.Status != StatusFailed || !strings.Contains(got.Reason, tt.reason) {
+				t.Errorf("result = %+v, want FAILED with reason containing %q", got, tt.reason)
+			}
+		})
+	}
+}
+
+func TestHTTPHandlerAbandonsHungRequestAfterStopGrace(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, stop := context.WithCancel(context.Background())
+	stop()
+	done := make(chan Result, 1)
+	go func() {
+		done <- NewHTTPHandler(HTTPOptions{URL: srv.URL, StopGrace: 50 * time.Millisecond}).Handle(ctx, stdioTask())
+	}()
+	select {
+	case got := <-done:
+		if got.Status != StatusFailed {
+			t.Errorf("result = %+v, want FAILED once abandoned", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("a hung request outlived the stop grace period")
+	}
+}
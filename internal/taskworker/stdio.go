@@ -49,6 +49,9 @@ type StdioOptions struct {
 	ExecTimeout time.Duration
 	// Verbose prints the task JSON and the result JSON to stdout.
 	Verbose bool
+	// Echo receives a copy of the child's stdout. Nil means os.Stdout; callers whose
+	// stdout carries machine-readable output point it elsewhere.
+	Echo io.Writer
 }
 
 // StdioHandler runs an external program per task: the full task JSON goes in on stdin,
@@ -103,7 +106,11 @@ func (h *StdioHandler) Handle(ctx context.Context, t Task) Result {
 	// The child's streams are both captured and echoed, so a worker's own output stays
 	// visible in the terminal while still being available for parsing and for logs.
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = io.MultiWriter(&stdout, os.Stdout)
+	echo := h.opts.Echo
+	if echo == nil {
+		echo = os.Stdout
+	}
+	cmd.Stdout = io.MultiWriter(&stdout, echo)
 	cmd.Stderr = io.MultiWriter(&stderr, os.Stderr)
 
 	result := h.runAndParse(cmd, &stdout, &stderr)
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package toolserve runs the tool workers a deployed agent needs from inside the
+// command that deployed or started it. A manifest (or a directory of tool files)
+// binds each tool task type to a handler — a stdio program, a JavaScript file for
+// the embedded interpreter, or an HTTP endpoint — and Start runs one taskworker
+// loop per bound type. Reading the files is left to the cmd layer.
+package toolserve
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/conductor-oss/conductor-cli/internal/taskworker"
+)
+
+// Kind says how a bound tool's handler runs.
+type Kind string
+
+const (
+	// KindCommand runs a program per task with the stdio worker contract.
+	KindCommand Kind = "command"
+	// KindJS runs a JavaScript file in the embedded interpreter.
+	KindJS Kind = "js"
+	// KindHTTP POSTs each task to a URL.
+	KindHTTP Kind = "http"
+)
+
+const jsExt = ".js"
+
+// Binding maps one tool task type to its handler. Target is the program, the
+// JavaScript file or the URL, according to Kind, and Args a program's arguments;
+// relative paths are already resolved against the manifest's directory.
+type Binding struct {
+	TaskType string
+	Kind     Kind
+	Target   string
+	Args     []string
+}
+
+// manifest is the --serve-tools file format:
+//
+//	tools:
+//	  lookup_order: ./tools/lookup_order.py --verbose   # stdio command
+//	  issue_refund: ./tools/refund.js                   # JavaScript
+//	  search_docs: http://localhost:8080/search         # HTTP endpoint
+type manifest struct {
+	Tools map[string]string `yaml:"tools"`
+}
+
+// ParseManifest reads a tool manifest. baseDir is the manifest's directory, which
+// relative paths in it are resolved against. Bindings are sorted by task type.
+func ParseManifest(data []byte, baseDir string) ([]Binding, error) {
+	var m manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse tool manifest: %w", err)
+	}
+	if len(m.Tools) == 0 {
+		return nil, fmt.Errorf("tool manifest binds no tools; list them under \"tools:\"")
+	}
+	bindings := make([]Binding, 0, len(m.Tools))
+	for taskType, target := range m.Tools {
+		b, err := classify(taskType, target, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, b)
+	}
+	slices.SortFunc(bindings, func(a, b Binding) int { return strings.Compare(a.TaskType, b.TaskType) })
+	return bindings, nil
+}
+
+// classify infers a manifest entry's Kind from its target: a URL, a lone .js file,
+// or otherwise a command line split on whitespace (no shell quoting).
+func classify(taskType, target, baseDir string) (Binding, error) {
+	fields := strings.Fields(target)
+	if len(fields) == 0 {
+		return Binding{}, fmt.Errorf("tool %q has no handler", taskType)
+	}
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		return Binding{TaskType: taskType, Kind: KindHTTP, Target: target}, nil
+	}
+	if len(fields) == 1 && strings.EqualFold(filepath.Ext(fields[0]), jsExt) {
+		return Binding{TaskType: taskType, Kind: KindJS, Target: resolve(fields[0], baseDir)}, nil
+	}
+	// Only an explicitly relative program is resolved: a bare "python3" is looked up
+	// on PATH, as a shell would.
+	program := fields[0]
+	if strings.HasPrefix(program, "./") || strings.HasPrefix(program, "../") {
+		program = resolve(program, baseDir)
+	}
+	return Binding{TaskType: taskType, Kind: KindCommand, Target: program, Args: fields[1:]}, nil
+}
+
+func resolve(path, baseDir string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
+
+// FileBinding binds a file found in a --serve-tools directory: the task type is the
+// file name without its extension, a .js file runs in the embedded interpreter and
+// anything else is executed as a stdio program.
+func FileBinding(dir, name string) Binding {
+	ext := filepath.Ext(name)
+	b := Binding{TaskType: strings.TrimSuffix(name, ext), Kind: KindCommand, Target: filepath.Join(dir, name)}
+	if strings.EqualFold(ext, jsExt) {
+		b.Kind = KindJS
+	}
+	return b
+}
+
+// Missing returns the required task types that no binding serves, in order.
+func Missing(bindings []Binding, required []string) []string {
+	var missing []string
+	for _, r := range required {
+		if !slices.ContainsFunc(bindings, func(b Binding) bool { return b.TaskType == r }) {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// Start runs a worker loop for each task type in handlers until stop is called or
+// ctx is done. stop cancels the loops and waits for their in-flight tasks to report.
+func Start(ctx context.Context, runner taskworker.Runner, handlers map[string]taskworker.Handler) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for taskType, h := range handlers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			taskworker.NewWorker(runner, taskworker.Config{}).Run(ctx, taskType, h)
+		}()
+	}
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
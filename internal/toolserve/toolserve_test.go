@@ -0,0 +1,119 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package toolserve
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/taskworker"
+)
+
+func TestParseManifestClassifiesTargets(t *testing.T) {
+	data := []byte(`tools:
+  search: https://tools.example.com/search
+  refund: ./refund.js
+  lookup: ./bin/lookup --verbose
+  summarize: python3 summarize.py
+`)
+	got, err := ParseManifest(data, "/proj")
+	if err != nil {
+		t.Fatalf("ParseManifest: %v", err)
+	}
+	want := []Binding{
+		{TaskType: "lookup", Kind: KindCommand, Target: "/proj/bin/lookup", Args: []string{"--verbose"}},
+		{TaskType: "refund", Kind: KindJS, Target: "/proj/refund.js"},
+		{TaskType: "search", Kind: KindHTTP, Target: "https://tools.example.com/search"},
+		{TaskType: "summarize", Kind: KindCommand, Target: "python3", Args: []string{"summarize.py"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bindings =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"no tools":      "other: 1\n",
+		"empty handler": "tools:\n  lookup: \"\"\n",
+		"not YAML":      "tools: [\n",
+	} {
+		if _, err := ParseManifest([]byte(data), "."); err == nil {
+			t.Errorf("%s: ParseManifest succeeded, want an error", name)
+		}
+	}
+}
+
+func TestFileBindingAndMissing(t *testing.T) {
+	bindings := []Binding{FileBinding("tools", "refund.js"), FileBinding("tools", "lookup.py")}
+	want := []Binding{
+		{TaskType: "refund", Kind: KindJS, Target: "tools/refund.js"},
+		{TaskType: "lookup", Kind: KindCommand, Target: "tools/lookup.py"},
+	}
+	if !reflect.DeepEqual(bindings, want) {
+		t.Errorf("bindings = %+v, want %+v", bindings, want)
+	}
+	if got := Missing(bindings, []string{"lookup", "search", "refund", "notify"}); !reflect.DeepEqual(got, []string{"search", "notify"}) {
+		t.Errorf("Missing = %v, want [search notify]", got)
+	}
+}
+
+// pollRecorder is a Runner that never has work and records which task types were
+// polled.
+type pollRecorder struct {
+	mu     sync.Mutex
+	polled map[string]bool
+}
+
+func (p *pollRecorder) Poll(ctx context.Context, taskType string) ([]taskworker.PolledTask, error) {
+	p.mu.Lock()
+	p.polled[taskType] = true
+	p.mu.Unlock()
+	return nil, nil
+}
+
+func (p *pollRecorder) Update(context.Context, taskworker.Task, taskworker.Result) error { return nil }
+
+func (p *pollRecorder) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.polled)
+}
+
+func TestStartPollsEveryTypeUntilStopped(t *testing.T) {
+	noop := taskworker.HandlerFunc(func(context.Context, taskworker.Task) taskworker.Result { return taskworker.Result{} })
+	runner := &pollRecorder{polled: map[string]bool{}}
+	stop := Start(context.Background(), runner, map[string]taskworker.Handler{"lookup": noop, "refund": noop})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runner.count() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if runner.count() != 2 {
+		t.Fatalf("polled %v, want both task types", runner.polled)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stop did not return")
+	}
+}
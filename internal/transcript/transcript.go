@@ -0,0 +1,174 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package transcript records an agent conversation — each prompt and the events its
+// execution streamed — and renders it as a Markdown document. The Transcript is an
+// agent.EventSink, so recording is just another sink next to the renderer.
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// Turn is one prompt and the events its execution produced.
+type Turn struct {
+	Prompt      string
+	ExecutionID string
+	AgentName   string
+	Events      []agent.SSEEvent
+}
+
+// Transcript is an ordered list of turns. Events arriving before the first Begin
+// open an untitled turn, so streaming an existing execution records too.
+type Transcript struct {
+	Title     string
+	SessionID string
+	Turns     []Turn
+}
+
+// Begin opens a new turn; subsequent events are recorded under it.
+func (t *Transcript) Begin(prompt, executionID, agentName string) {
+	t.Turns = append(t.Turns, Turn{Prompt: prompt, ExecutionID: executionID, AgentName: agentName})
+}
+
+// OnEvent records e on the current turn. It never fails, so recording can never
+// stop a stream.
+func (t *Transcript) OnEvent(e agent.SSEEvent) error {
+	if len(t.Turns) == 0 {
+		t.Begin("", e.Payload().ExecutionID, "")
+	}
+	cur := &t.Turns[len(t.Turns)-1]
+	cur.Events = append(cur.Events, e)
+	return nil
+}
+
+// Reset drops every recorded turn, keeping the title.
+func (t *Transcript) Reset(sessionID string) {
+	t.SessionID = sessionID
+	t.Turns = nil
+}
+
+// WriteMarkdown renders the transcript. Tool arguments and results are written in
+// full as fenced JSON — the point of a transcript is what the terminal truncates.
+func (t *Transcript) WriteMarkdown(w io.Writer) error {
+	var b bytes.Buffer
+	title := t.Title
+	if title == "" {
+		title = "Agent conversation"
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	if t.SessionID != "" {
+		fmt.Fprintf(&b, "Session: `%s`\n\n", t.SessionID)
+	}
+	for i, turn := range t.Turns {
+		writeTurn(&b, i+1, turn)
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+func writeTurn(b *bytes.Buffer, n int, turn Turn) {
+	fmt.Fprintf(b, "## Turn %d\n\n", n)
+	if turn.ExecutionID != "" {
+		meta := "Execution `" + turn.ExecutionID + "`"
+		if turn.AgentName != "" {
+			meta = "Agent `" + turn.AgentName + "` · " + meta
+		}
+		fmt.Fprintf(b, "%s\n\n", meta)
+	}
+	if turn.Prompt != "" {
+		fmt.Fprintf(b, "**User:**\n\n%s\n\n", quote(turn.Prompt))
+	}
+
+	// Message events stream in fragments; they are buffered and written as one
+	// paragraph when any other event (or the turn) ends them.
+	var message strings.Builder
+	flush := func() {
+		if message.Len() > 0 {
+			fmt.Fprintf(b, "**Agent:**\n\n%s\n\n", message.String())
+			message.Reset()
+		}
+	}
+
+	for _, e := range turn.Events {
+		p := e.Payload()
+		typ := e.ResolvedType()
+		if typ == agent.EventMessage {
+			message.WriteString(p.Content)
+			continue
+		}
+		flush()
+		switch typ {
+		case agent.EventThinking:
+			fmt.Fprintf(b, "**Thinking:**\n\n%s\n\n", quote(p.Content))
+		case agent.EventToolCall:
+			fmt.Fprintf(b, "**Tool call:** `%s`\n\n%s\n", p.ToolName, fenced(p.Args))
+		case agent.EventToolResult:
+			fmt.Fprintf(b, "**Tool result:** `%s`\n\n%s\n", p.ToolName, fenced(p.Result))
+		case agent.EventHandoff:
+			fmt.Fprintf(b, "**Handoff:** → `%s`\n\n", p.Target)
+		case agent.EventGuardrailPass:
+			fmt.Fprintf(b, "**Guardrail passed:** `%s`\n\n", p.GuardrailName)
+		case agent.EventGuardrailFail:
+			fmt.Fprintf(b, "**Guardrail failed:** `%s`", p.GuardrailName)
+			if p.Content != "" {
+				fmt.Fprintf(b, " — %s", p.Content)
+			}
+			b.WriteString("\n\n")
+		case agent.EventWaiting:
+			fmt.Fprintf(b, "**Waiting for human input:** `%s`\n\n", p.PendingToolName())
+			if len(p.PendingTool) > 0 {
+				if data, err := json.Marshal(p.PendingTool); err == nil {
+					fmt.Fprintf(b, "%s\n", fenced(agent.RawValue(data)))
+				}
+			}
+		case agent.EventError:
+			fmt.Fprintf(b, "**Error:** %s\n\n", p.Content)
+		case agent.EventDone:
+			fmt.Fprintf(b, "### Output\n\n%s\n", fenced(p.Output))
+		default:
+			if typ != "" {
+				fmt.Fprintf(b, "**%s:**\n\n%s\n", typ, fenced(agent.RawValue(e.Data)))
+			}
+		}
+	}
+	flush()
+}
+
+// fenced renders a payload as a fenced block: JSON indented under a json fence, a
+// plain string as text.
+func fenced(v agent.RawValue) string {
+	if len(v) == 0 {
+		return "```\n(none)\n```\n"
+	}
+	var text string
+	if json.Unmarshal(v, &text) == nil {
+		return "```\n" + text + "\n```\n"
+	}
+	var buf bytes.Buffer
+	if json.Indent(&buf, v, "", "  ") != nil {
+		return "```\n" + string(v) + "\n```\n"
+	}
+	return "```json\n" + buf.String() + "\n```\n"
+}
+
+// quote renders text as a Markdown blockquote, keeping its line breaks.
+func quote(s string) string {
+	return "> " + strings.ReplaceAll(strings.TrimRight(s, "\n"), "\n", "\n> ")
+}
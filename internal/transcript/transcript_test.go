@@ -0,0 +1,74 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package transcript
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+func evt(typ agent.EventType, data string) agent.SSEEvent {
+	return agent.SSEEvent{Type: typ, Data: []byte(data)}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	tr := &Transcript{Title: "Chat with triage", SessionID: "s1"}
+	tr.Begin("Where is my order?\nIt is late.", "e1", "triage")
+	for _, e := range []agent.SSEEvent{
+		evt(agent.EventThinking, `{"content":"look it up"}`),
+		evt(agent.EventToolCall, `{"toolName":"lookup","args":{"order":42}}`),
+		evt(agent.EventToolResult, `{"toolName":"lookup","result":"shipped"}`),
+		evt(agent.EventHandoff, `{"target":"billing"}`),
+		evt(agent.EventGuardrailPass, `{"guardrailName":"pii"}`),
+		evt(agent.EventGuardrailFail, `{"guardrailName":"tone","content":"too curt"}`),
+		evt(agent.EventMessage, `{"content":"It "}`),
+		evt(agent.EventMessage, `{"content":"shipped."}`),
+		evt(agent.EventDone, `{"output":{"result":"It shipped."}}`),
+	} {
+		if err := tr.OnEvent(e); err != nil {
+			t.Fatalf("OnEvent: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := tr.WriteMarkdown(&out); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	want := "# Chat with triage\n\n" +
+		"Session: `s1`\n\n" +
+		"## Turn 1\n\n" +
+		"Agent `triage` · Execution `e1`\n\n" +
+		"**User:**\n\n> Where is my order?\n> It is late.\n\n" +
+		"**Thinking:**\n\n> look it up\n\n" +
+		"**Tool call:** `lookup`\n\n```json\n{\n  \"order\": 42\n}\n```\n\n" +
+		"**Tool result:** `lookup`\n\n```\nshipped\n```\n\n" +
+		"**Handoff:** → `billing`\n\n" +
+		"**Guardrail passed:** `pii`\n\n" +
+		"**Guardrail failed:** `tone` — too curt\n\n" +
+		"**Agent:**\n\nIt shipped.\n\n" +
+		"### Output\n\n```json\n{\n  \"result\": \"It shipped.\"\n}\n```\n\n"
+	if out.String() != want {
+		t.Errorf("markdown =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestEventsBeforeBeginOpenATurn(t *testing.T) {
+	tr := &Transcript{}
+	_ = tr.OnEvent(evt(agent.EventMessage, `{"executionId":"e9","content":"hi"}`))
+	if len(tr.Turns) != 1 || tr.Turns[0].ExecutionID != "e9" || len(tr.Turns[0].Events) != 1 {
+		t.Fatalf("turns = %+v, want one turn for e9 holding the event", tr.Turns)
+	}
+}
@@ -0,0 +1,367 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package tui is the full-screen terminal presentation of a streamed agent
+// execution. It follows the model/update/view split: Model is plain state, Update
+// folds one message (a streamed event, a key press, a clock tick) into it, and View
+// renders it to a frame of lines. Only program.go touches the terminal, so the model
+// is tested without one.
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// Msg is anything the program folds into the model.
+type Msg interface{}
+
+// EventMsg carries one streamed agent event.
+type EventMsg struct{ Event agent.SSEEvent }
+
+// KeyMsg is one decoded key press.
+type KeyMsg struct{ Key Key }
+
+// TickMsg advances the clock shown in the status bar.
+type TickMsg struct{ Now time.Time }
+
+// ResizeMsg reports the terminal size.
+type ResizeMsg struct{ Width, Height int }
+
+// StreamEndedMsg reports that the event stream stopped, with its error (nil on a
+// clean end). The view stays up so the user can still browse the transcript.
+type StreamEndedMsg struct{ Err error }
+
+// Key names the keys the model reacts to; everything else decodes to KeyNone.
+type Key int
+
+const (
+	KeyNone Key = iota
+	KeyUp
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyTop
+	KeyBottom
+	KeyToggle
+	KeyToggleAll
+	KeyQuit
+)
+
+// itemKind classifies one entry in the message pane.
+type itemKind int
+
+const (
+	itemMessage itemKind = iota
+	itemThinking
+	itemTool
+	itemHandoff
+	itemGuardrail
+	itemWaiting
+	itemError
+	itemOutput
+	itemOther
+)
+
+// item is one entry in the message pane. Tool calls carry their arguments and,
+// once it arrives, their result, so the pair collapses and expands as one unit.
+type item struct {
+	kind      itemKind
+	agent     string // the agent that was active when the item arrived
+	title     string
+	body      string // full text shown when expanded
+	args      agent.RawValue
+	result    agent.RawValue
+	hasResult bool
+	failed    bool
+	expanded  bool
+}
+
+// collapsible reports whether the item has detail hidden behind its title line.
+func (it item) collapsible() bool {
+	switch it.kind {
+	case itemTool, itemThinking, itemOutput, itemOther:
+		return true
+	}
+	return false
+}
+
+// agentNode is one agent in the handoff tree.
+type agentNode struct {
+	name     string
+	children []*agentNode
+	parent   *agentNode
+}
+
+// status is the execution state shown in the status bar.
+type status string
+
+const (
+	statusRunning status = "RUNNING"
+	statusWaiting status = "WAITING"
+	statusDone    status = "COMPLETED"
+	statusFailed  status = "FAILED"
+	statusEnded   status = "DISCONNECTED"
+)
+
+// Model is the full TUI state. It is a value updated only through Update, so the
+// program loop is the single writer and no field needs a lock.
+type Model struct {
+	ExecutionID string
+
+	items    []item
+	selected int
+	// follow pins the view to the newest item until the user moves the selection;
+	// jumping to the bottom re-arms it.
+	follow bool
+	offset int
+
+	root    *agentNode
+	current *agentNode
+
+	status  status
+	errText string
+
+	started time.Time
+	now     time.Time
+	ended   time.Time
+	// finished is set by a terminal event or the end of the stream; it freezes the clock.
+	finished bool
+
+	width, height int
+	quit          bool
+}
+
+// NewModel returns the initial state for an execution of agentName. The clock
+// starts at started; the status bar's elapsed time is measured from it.
+func NewModel(executionID, agentName string, started time.Time) Model {
+	root := &agentNode{name: agentName}
+	return Model{
+		ExecutionID: executionID,
+		follow:      true,
+		root:        root,
+		current:     root,
+		status:      statusRunning,
+		started:     started,
+		now:         started,
+		width:       defaultWidth,
+		height:      defaultHeight,
+	}
+}
+
+// Quitting reports whether the user asked to leave.
+func (m Model) Quitting() bool { return m.quit }
+
+// Finished reports whether the execution reached a terminal event or the stream
+// stopped.
+func (m Model) Finished() bool { return m.finished }
+
+// Elapsed is the wall time since the execution started, frozen once it finishes.
+func (m Model) Elapsed() time.Duration {
+	end := m.now
+	if m.Finished() {
+		end = m.ended
+	}
+	return end.Sub(m.started).Truncate(time.Second)
+}
+
+// Summary describes how the execution ended (or that it is still running) for the
+// line printed after the TUI closes and the normal screen returns.
+func (m Model) Summary() string {
+	s := fmt.Sprintf("Execution %s %s after %s.", m.ExecutionID, m.status, m.Elapsed())
+	if m.errText != "" {
+		s += " " + m.errText
+	}
+	for i := len(m.items) - 1; i >= 0; i-- {
+		if m.items[i].kind == itemOutput && m.items[i].body != "" {
+			return s + "\n\n" + m.items[i].body
+		}
+	}
+	return s
+}
+
+// Update folds one message into the model.
+func (m Model) Update(msg Msg) Model {
+	switch msg := msg.(type) {
+	case EventMsg:
+		m = m.onEvent(msg.Event)
+	case KeyMsg:
+		m = m.onKey(msg.Key)
+	case TickMsg:
+		m.now = msg.Now
+	case ResizeMsg:
+		if msg.Width > 0 && msg.Height > 0 {
+			m.width, m.height = msg.Width, msg.Height
+		}
+	case StreamEndedMsg:
+		if !m.Finished() {
+			m.ended, m.finished = m.now, true
+			m.status = statusEnded
+			if msg.Err != nil {
+				m.errText = msg.Err.Error()
+			}
+		}
+	}
+	return m.scroll()
+}
+
+func (m Model) onEvent(e agent.SSEEvent) Model {
+	p := e.Payload()
+	owner := m.current.name
+
+	switch e.ResolvedType() {
+	case agent.EventMessage:
+		if p.Content == "" {
+			break
+		}
+		// Messages stream in fragments; consecutive ones form a single entry.
+		if n := len(m.items); n > 0 && m.items[n-1].kind == itemMessage && m.items[n-1].agent == owner {
+			m.items[n-1].body += p.Content
+			break
+		}
+		m = m.add(item{kind: itemMessage, agent: owner, body: p.Content})
+	case agent.EventThinking:
+		m = m.add(item{kind: itemThinking, agent: owner, title: p.Content, body: p.Content})
+	case agent.EventToolCall:
+		m = m.add(item{kind: itemTool, agent: owner, title: p.ToolName, args: p.Args})
+	case agent.EventToolResult:
+		if i := m.openToolCall(p.ToolName); i >= 0 {
+			m.items[i].result = p.Result
+			m.items[i].hasResult = true
+			break
+		}
+		m = m.add(item{kind: itemTool, agent: owner, title: p.ToolName, result: p.Result, hasResult: true})
+	case agent.EventHandoff:
+		m = m.add(item{kind: itemHandoff, agent: owner, title: p.Target})
+		m = m.handoff(p.Target)
+	case agent.EventGuardrailPass:
+		m = m.add(item{kind: itemGuardrail, agent: owner, title: p.GuardrailName})
+	case agent.EventGuardrailFail:
+		m = m.add(item{kind: itemGuardrail, agent: owner, title: p.GuardrailName, body: p.Content, failed: true})
+	case agent.EventWaiting:
+		m.status = statusWaiting
+		m = m.add(item{kind: itemWaiting, agent: owner, title: p.PendingToolName()})
+	case agent.EventError:
+		m.status = statusFailed
+		m.errText = p.Content
+		m.ended, m.finished = m.now, true
+		m = m.add(item{kind: itemError, agent: owner, title: p.ToolName, body: p.Content, failed: true})
+	case agent.EventDone:
+		m.status = statusDone
+		m.ended, m.finished = m.now, true
+		m = m.add(item{kind: itemOutput, agent: owner, title: "output", body: p.Output.String(), expanded: true})
+	default:
+		if t := e.ResolvedType(); t != "" {
+			m = m.add(item{kind: itemOther, agent: owner, title: string(t), body: string(e.Data)})
+		}
+	}
+	// Any event after a wait means the human answered and the agent moved on.
+	if m.status == statusWaiting && e.ResolvedType() != agent.EventWaiting {
+		m.status = statusRunning
+	}
+	return m
+}
+
+// add appends an item, keeping the selection on the newest one while following.
+func (m Model) add(it item) Model {
+	m.items = append(m.items, it)
+	if m.follow {
+		m.selected = len(m.items) - 1
+	}
+	return m
+}
+
+// openToolCall returns the index of the newest call to tool still awaiting its
+// result, or -1.
+func (m Model) openToolCall(tool string) int {
+	for i := len(m.items) - 1; i >= 0; i-- {
+		if it := m.items[i]; it.kind == itemTool && !it.hasResult && it.title == tool {
+			return i
+		}
+	}
+	return -1
+}
+
+// handoff moves the active agent to target. A target already on the path back to
+// the root is a return to it; anything else becomes a child of the active agent.
+func (m Model) handoff(target string) Model {
+	if target == "" {
+		return m
+	}
+	for n := m.current; n != nil; n = n.parent {
+		if n.name == target {
+			m.current = n
+			return m
+		}
+	}
+	for _, c := range m.current.children {
+		if c.name == target {
+			m.current = c
+			return m
+		}
+	}
+	child := &agentNode{name: target, parent: m.current}
+	m.current.children = append(m.current.children, child)
+	m.current = child
+	return m
+}
+
+func (m Model) onKey(k Key) Model {
+	last := len(m.items) - 1
+	switch k {
+	case KeyQuit:
+		m.quit = true
+	case KeyUp:
+		m.follow = false
+		if m.selected > 0 {
+			m.selected--
+		}
+	case KeyDown:
+		if m.selected < last {
+			m.selected++
+		}
+		m.follow = m.selected == last
+	case KeyPageUp:
+		m.follow = false
+		m.selected = max(0, m.selected-m.paneHeight())
+	case KeyPageDown:
+		m.selected = min(last, m.selected+m.paneHeight())
+		m.follow = m.selected == last
+	case KeyTop:
+		m.follow = false
+		m.selected = 0
+	case KeyBottom:
+		m.follow = true
+		m.selected = max(0, last)
+	case KeyToggle:
+		if m.selected >= 0 && m.selected <= last && m.items[m.selected].collapsible() {
+			m.items[m.selected].expanded = !m.items[m.selected].expanded
+		}
+	case KeyToggleAll:
+		expand := false
+		for _, it := range m.items {
+			if it.collapsible() && !it.expanded {
+				expand = true
+				break
+			}
+		}
+		for i := range m.items {
+			if m.items[i].collapsible() {
+				m.items[i].expanded = expand
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+var start = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func event(typ agent.EventType, data string) Msg {
+	return EventMsg{Event: agent.SSEEvent{Type: typ, Data: []byte(data)}}
+}
+
+func feed(m Model, msgs ...Msg) Model {
+	for _, msg := range msgs {
+		m = m.Update(msg)
+	}
+	return m
+}
+
+// plain strips styling so assertions read against what the user sees.
+func plain(frame string) string {
+	for _, s := range []string{styleBold, styleDim, styleReverse, styleRed, styleGreen, styleYellow, styleCyan, styleReset} {
+		frame = strings.ReplaceAll(frame, s, "")
+	}
+	return frame
+}
+
+func TestToolResultPairsWithItsCallAndExpands(t *testing.T) {
+	m := feed(NewModel("e1", "triage", start),
+		event(agent.EventToolCall, `{"toolName":"lookup","args":{"q":"orders"}}`),
+		event(agent.EventToolResult, `{"toolName":"lookup","result":{"count":3}}`),
+	)
+	if len(m.items) != 1 {
+		t.Fatalf("got %d items, want the call and result folded into 1", len(m.items))
+	}
+
+	collapsed := plain(m.View())
+	if !strings.Contains(collapsed, `▸ tool  lookup({"q":"orders"}) → {"count":3}`) {
+		t.Errorf("collapsed view missing summary:\n%s", collapsed)
+	}
+	if strings.Contains(collapsed, "args:") {
+		t.Errorf("collapsed view shows detail:\n%s", collapsed)
+	}
+
+	expanded := plain(m.Update(KeyMsg{Key: KeyToggle}).View())
+	for _, want := range []string{"▾ tool  lookup", "args:", `"q": "orders"`, "result:", `"count": 3`} {
+		if !strings.Contains(expanded, want) {
+			t.Errorf("expanded view missing %q:\n%s", want, expanded)
+		}
+	}
+}
+
+func TestMessageFragmentsJoin(t *testing.T) {
+	m := feed(NewModel("e1", "triage", start),
+		event(agent.EventMessage, `{"content":"Hel"}`),
+		event(agent.EventMessage, `{"content":"lo."}`),
+	)
+	if len(m.items) != 1 || m.items[0].body != "Hello." {
+		t.Fatalf("items = %+v, want one message %q", m.items, "Hello.")
+	}
+}
+
+func TestHandoffBuildsTree(t *testing.T) {
+	m := feed(NewModel("e1", "triage", start),
+		event(agent.EventHandoff, `{"target":"billing"}`),
+		event(agent.EventHandoff, `{"target":"refunds"}`),
+		event(agent.EventHandoff, `{"target":"triage"}`),
+		event(agent.EventHandoff, `{"target":"support"}`),
+	)
+	var got []string
+	for _, l := range m.treeLines() {
+		got = append(got, strings.TrimRight(l.text, " "))
+	}
+	want := []string{
+		"   triage",
+		"   ├─ billing",
+		"   │  └─ refunds",
+		" ● └─ support",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tree =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestStatusBarTracksLifecycle(t *testing.T) {
+	m := NewModel("e1", "triage", start)
+	m = feed(m, TickMsg{Now: start.Add(5 * time.Second)}, event(agent.EventWaiting, `{"pendingTool":{"name":"approve"}}`))
+	if m.status != statusWaiting {
+		t.Errorf("status = %s, want %s", m.status, statusWaiting)
+	}
+
+	m = feed(m, event(agent.EventMessage, `{"content":"ok"}`))
+	if m.status != statusRunning {
+		t.Errorf("status after answer = %s, want %s", m.status, statusRunning)
+	}
+
+	m = feed(m, event(agent.EventDone, `{"output":"all done"}`), TickMsg{Now: start.Add(time.Minute)})
+	if !m.Finished() || m.status != statusDone {
+		t.Fatalf("finished=%t status=%s, want finished %s", m.Finished(), m.status, statusDone)
+	}
+	if got := m.Elapsed(); got != 5*time.Second {
+		t.Errorf("elapsed = %s, want the clock frozen at 5s", got)
+	}
+	status := plain(m.View())
+	for _, want := range []string{"COMPLETED", "exec e1", "elapsed 5s", "press q to quit"} {
+		if !strings.Contains(status, want) {
+			t.Errorf("view missing %q:\n%s", want, status)
+		}
+	}
+	if got, want := m.Summary(), "Execution e1 COMPLETED after 5s.\n\nall done"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestStreamEndKeepsTerminalStatus(t *testing.T) {
+	m := feed(NewModel("e1", "triage", start),
+		event(agent.EventError, `{"content":"model call failed"}`),
+		StreamEndedMsg{},
+	)
+	if m.status != statusFailed {
+		t.Errorf("status = %s, want %s to survive the stream end", m.status, statusFailed)
+	}
+
+	m = feed(NewModel("e1", "triage", start), StreamEndedMsg{Err: errors.New("connection reset")})
+	if m.status != statusEnded || m.errText != "connection reset" {
+		t.Errorf("status=%s err=%q, want %s with the stream error", m.status, m.errText, statusEnded)
+	}
+}
+
+func TestViewFillsScreenAndFollowsNewest(t *testing.T) {
+	m := feed(NewModel("e1", "triage", start), ResizeMsg{Width: 40, Height: 10})
+	for i := 0; i < 20; i++ {
+		m = feed(m, event(agent.EventThinking, `{"content":"step"}`))
+	}
+
+	rows := strings.Split(plain(m.View()), "\r\n")
+	if len(rows) != 10 {
+		t.Fatalf("frame has %d rows, want 10", len(rows))
+	}
+	for i, r := range rows {
+		if n := len([]rune(r)); n != 40 {
+			t.Errorf("row %d is %d columns, want 40: %q", i, n, r)
+		}
+	}
+	if m.selected != 19 || !strings.HasPrefix(rows[len(rows)-3], "› ") {
+		t.Errorf("selected=%d, want the newest item selected and shown last", m.selected)
+	}
+
+	m = feed(m, KeyMsg{Key: KeyTop})
+	if m.offset != 0 || m.follow {
+		t.Errorf("offset=%d follow=%t after KeyTop, want 0 and false", m.offset, m.follow)
+	}
+	m = feed(m, event(agent.EventThinking, `{"content":"later"}`))
+	if m.selected != 0 {
+		t.Errorf("a new event moved the selection to %d while not following", m.selected)
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	got := DecodeKeys([]byte("jk\x1b[A\x1b[B\x1b[5~\x1b[6~gG \ra\x1bxq\x03"))
+	want := []Key{
+		KeyDown, KeyUp, KeyUp, KeyDown, KeyPageUp, KeyPageDown, KeyTop, KeyBottom,
+		KeyToggle, KeyToggle, KeyToggleAll, KeyQuit, KeyQuit,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeKeys = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// Terminal control sequences and timing.
+const (
+	enterAltScreen = "\x1b[?1049h"
+	exitAltScreen  = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	cursorHome     = "\x1b[H"
+	clearScreen    = "\x1b[2J"
+
+	// tickInterval drives the elapsed clock and the resize check; SIGWINCH is not
+	// portable, so the size is polled on each tick instead.
+	tickInterval = time.Second
+	keyBuffer    = 64
+	msgBuffer    = 256
+)
+
+// ErrQuit is returned from the sink once the user has left the TUI, which stops
+// the stream feeding it.
+var ErrQuit = errors.New("tui closed")
+
+// Program owns the terminal while the TUI runs: it reads keys, polls the size,
+// folds messages into the model and repaints after each one.
+type Program struct {
+	model Model
+	in    *os.File
+	out   *os.File
+	msgs  chan Msg
+	done  chan struct{}
+}
+
+// NewProgram returns a program that renders m on out and reads keys from in.
+func NewProgram(m Model, in, out *os.File) *Program {
+	return &Program{
+		model: m,
+		in:    in,
+		out:   out,
+		msgs:  make(chan Msg, msgBuffer),
+		done:  make(chan struct{}),
+	}
+}
+
+// Send queues a message for the model. It reports false once the program has
+// stopped, so no producer blocks on a TUI that is gone.
+func (p *Program) Send(msg Msg) bool {
+	select {
+	case p.msgs <- msg:
+		return true
+	case <-p.done:
+		return false
+	}
+}
+
+// Sink returns the agent.EventSink that posts each streamed event to the program.
+func (p *Program) Sink() agent.EventSink { return programSink{p: p} }
+
+type programSink struct{ p *Program }
+
+func (s programSink) OnEvent(e agent.SSEEvent) error {
+	if !s.p.Send(EventMsg{Event: e}) {
+		return ErrQuit
+	}
+	return nil
+}
+
+// Run switches the terminal to raw mode on the alternate screen and processes
+// messages until the user quits or ctx ends. The terminal is always restored, and
+// the final model is returned so the caller can print a summary on the normal screen.
+func (p *Program) Run(ctx context.Context) (Model, error) {
+	defer close(p.done)
+
+	fd := int(p.in.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return p.model, fmt.Errorf("enter raw mode: %w", err)
+	}
+	defer term.Restore(fd, state)
+
+	io.WriteString(p.out, enterAltScreen+hideCursor+clearScreen)
+	defer io.WriteString(p.out, showCursor+exitAltScreen)
+
+	go p.readKeys()
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	p.resize()
+	p.render()
+	for {
+		select {
+		case <-ctx.Done():
+			return p.model, nil
+		case msg := <-p.msgs:
+			p.model = p.model.Update(msg)
+		case now := <-ticker.C:
+			p.resize()
+			p.model = p.model.Update(TickMsg{Now: now})
+		}
+		if p.model.Quitting() {
+			return p.model, nil
+		}
+		p.render()
+	}
+}
+
+func (p *Program) render() {
+	io.WriteString(p.out, cursorHome+p.model.View())
+}
+
+// resize applies the current terminal size, clearing the screen when it changed so
+// no rows from the old frame linger.
+func (p *Program) resize() {
+	w, h, err := term.GetSize(int(p.out.Fd()))
+	if err != nil || (w == p.model.width && h == p.model.height) {
+		return
+	}
+	p.model = p.model.Update(ResizeMsg{Width: w, Height: h})
+	io.WriteString(p.out, clearScreen)
+}
+
+// readKeys forwards decoded key presses until stdin fails or the program stops.
+// The read itself cannot be interrupted, so the goroutine ends on the next key
+// after Run returns — which is harmless for a process about to exit.
+func (p *Program) readKeys() {
+	buf := make([]byte, keyBuffer)
+	for {
+		n, err := p.in.Read(buf)
+		for _, k := range DecodeKeys(buf[:n]) {
+			if !p.Send(KeyMsg{Key: k}) {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// DecodeKeys maps the bytes of one raw-mode read to keys. Escape sequences for the
+// arrow, page and home/end keys are matched whole; unknown bytes are dropped.
+func DecodeKeys(b []byte) []Key {
+	sequences := []struct {
+		seq string
+		key Key
+	}{
+		{"\x1b[A", KeyUp}, {"\x1bOA", KeyUp},
+		{"\x1b[B", KeyDown}, {"\x1bOB", KeyDown},
+		{"\x1b[5~", KeyPageUp}, {"\x1b[6~", KeyPageDown},
+		{"\x1b[H", KeyTop}, {"\x1b[1~", KeyTop},
+		{"\x1b[F", KeyBottom}, {"\x1b[4~", KeyBottom},
+	}
+	var keys []Key
+	for i := 0; i < len(b); {
+		if b[i] == 0x1b {
+			matched := false
+			for _, s := range sequences {
+				if len(b)-i >= len(s.seq) && string(b[i:i+len(s.seq)]) == s.seq {
+					keys = append(keys, s.key)
+					i += len(s.seq)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				i++
+			}
+			continue
+		}
+		switch b[i] {
+		case 'k':
+			keys = append(keys, KeyUp)
+		case 'j':
+			keys = append(keys, KeyDown)
+		case 'g':
+			keys = append(keys, KeyTop)
+		case 'G':
+			keys = append(keys, KeyBottom)
+		case '\r', '\n', ' ':
+			keys = append(keys, KeyToggle)
+		case 'a':
+			keys = append(keys, KeyToggleAll)
+		case 'q', 0x03: // q or Ctrl-C, which raw mode delivers as a byte
+			keys = append(keys, KeyQuit)
+		}
+		i++
+	}
+	return keys
+}
@@ -0,0 +1,343 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/conductor-oss/conductor-cli/internal/agent"
+)
+
+// Layout defaults and limits.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+	// chromeLines are the fixed rows around the panes: header, separator, help, status.
+	chromeLines = 4
+	// treeShare caps the handoff tree at 1/treeShare of the screen height.
+	treeShare    = 4
+	minPaneLines = 3
+	detailIndent = "      "
+	helpText     = "↑/↓ select  enter expand  a expand all  g/G top/bottom  q quit"
+)
+
+// ANSI styles. The view emits plain text and wraps whole lines in a style, so a
+// truncation never cuts an escape sequence in half.
+const (
+	styleNone     = ""
+	styleBold     = "\x1b[1m"
+	styleDim      = "\x1b[2m"
+	styleReverse  = "\x1b[7m"
+	styleRed      = "\x1b[31m"
+	styleGreen    = "\x1b[32m"
+	styleYellow   = "\x1b[33m"
+	styleCyan     = "\x1b[36m"
+	styleReset    = "\x1b[0m"
+	styleSelected = styleReverse
+)
+
+// line is one rendered row before styling.
+type line struct {
+	text  string
+	style string
+	item  int // index of the owning item, -1 for chrome
+}
+
+// View renders the model as one frame of exactly height rows, each padded or cut to
+// width columns and joined with CRLF, ready to paint over a raw-mode screen.
+func (m Model) View() string {
+	var rows []string
+	add := func(text, style string) {
+		rows = append(rows, styled(fit(text, m.width), style))
+	}
+
+	add(fmt.Sprintf(" conductor agent  %s  ·  execution %s", m.root.name, m.ExecutionID), styleBold+styleReverse)
+	for _, l := range m.treeLines() {
+		add(l.text, l.style)
+	}
+	add(strings.Repeat("─", m.width), styleDim)
+
+	body := m.bodyLines()
+	pane := m.paneHeight()
+	for i := 0; i < pane; i++ {
+		if j := m.offset + i; j < len(body) {
+			add(body[j].text, body[j].style)
+		} else {
+			add("", styleNone)
+		}
+	}
+
+	add(" "+helpText, styleDim)
+	add(m.statusText(), styleReverse+statusStyle(m.status))
+	return strings.Join(rows, "\r\n")
+}
+
+func (m Model) statusText() string {
+	s := fmt.Sprintf(" ● %s  exec %s  elapsed %s  agent %s  %d item(s)",
+		m.status, m.ExecutionID, m.Elapsed(), m.current.name, len(m.items))
+	if m.errText != "" {
+		s += "  error: " + firstLine(m.errText)
+	}
+	if m.Finished() {
+		s += "  — press q to quit"
+	}
+	return s
+}
+
+func statusStyle(s status) string {
+	switch s {
+	case statusDone:
+		return styleGreen
+	case statusFailed, statusEnded:
+		return styleRed
+	case statusWaiting:
+		return styleYellow
+	}
+	return styleNone
+}
+
+// treeLines renders the handoff tree, capped so the message pane keeps most of the
+// screen; when capped, the rows nearest the active agent are the ones kept.
+func (m Model) treeLines() []line {
+	var out []line
+	active := 0
+	var walk func(n *agentNode, prefix string, last, root bool)
+	walk = func(n *agentNode, prefix string, last, root bool) {
+		branch, next := "", ""
+		if !root {
+			branch, next = "├─ ", prefix+"│  "
+			if last {
+				branch, next = "└─ ", prefix+"   "
+			}
+		}
+		marker, style := "  ", styleNone
+		if n == m.current {
+			marker, style = "● ", styleCyan
+			active = len(out)
+		}
+		out = append(out, line{text: " " + marker + prefix + branch + n.name, style: style, item: -1})
+		for i, c := range n.children {
+			walk(c, next, i == len(n.children)-1, false)
+		}
+	}
+	walk(m.root, "", true, true)
+
+	limit := max(1, m.height/treeShare)
+	if len(out) <= limit {
+		return out
+	}
+	start := min(max(0, active-limit/2), len(out)-limit)
+	return out[start : start+limit]
+}
+
+// paneHeight is the number of rows available to the message pane.
+func (m Model) paneHeight() int {
+	return max(minPaneLines, m.height-chromeLines-len(m.treeLines()))
+}
+
+// scroll recomputes the pane offset after an update: pinned to the end while
+// following, otherwise moved just far enough to keep the selected item in view.
+func (m Model) scroll() Model {
+	body := m.bodyLines()
+	pane := m.paneHeight()
+	if m.follow {
+		m.offset = max(0, len(body)-pane)
+		return m
+	}
+	first, last := -1, -1
+	for i, l := range body {
+		if l.item == m.selected {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return m
+	}
+	if first < m.offset {
+		m.offset = first
+	} else if last >= m.offset+pane {
+		m.offset = min(first, last-pane+1)
+	}
+	m.offset = max(0, min(m.offset, len(body)-pane))
+	return m
+}
+
+// bodyLines renders every item, wrapped to the screen width.
+func (m Model) bodyLines() []line {
+	var out []line
+	for i, it := range m.items {
+		marker := "  "
+		if i == m.selected {
+			marker = "› "
+		}
+		for j, l := range itemLines(it) {
+			prefix := "  "
+			if j == 0 {
+				prefix = marker
+			}
+			for _, w := range wrap(prefix+l.text, m.width) {
+				style := l.style
+				if i == m.selected && j == 0 {
+					style += styleSelected
+				}
+				out = append(out, line{text: w, style: style, item: i})
+			}
+		}
+	}
+	return out
+}
+
+// itemLines renders one item: a title row, then its detail when expanded.
+func itemLines(it item) []line {
+	fold := "▸ "
+	if it.expanded {
+		fold = "▾ "
+	}
+	var out []line
+	title := func(text, style string) { out = append(out, line{text: text, style: style}) }
+	detail := func(label, text string) {
+		if label != "" {
+			out = append(out, line{text: "  " + label, style: styleDim})
+		}
+		for _, l := range strings.Split(text, "\n") {
+			out = append(out, line{text: detailIndent + l})
+		}
+	}
+
+	switch it.kind {
+	case itemMessage:
+		for _, l := range strings.Split(it.body, "\n") {
+			out = append(out, line{text: l})
+		}
+	case itemThinking:
+		title(fold+"thinking  "+firstLine(it.title), styleDim)
+		if it.expanded {
+			detail("", it.body)
+		}
+	case itemTool:
+		summary := fmt.Sprintf("%stool  %s(%s)", fold, it.title, it.args.String())
+		if it.hasResult {
+			summary += " → " + it.result.String()
+		} else {
+			summary += " …"
+		}
+		title(summary, styleCyan)
+		if it.expanded {
+			detail("args:", pretty(it.args))
+			if it.hasResult {
+				detail("result:", pretty(it.result))
+			}
+		}
+	case itemHandoff:
+		title("→ handoff to "+it.title, styleBold)
+	case itemGuardrail:
+		if it.failed {
+			text := "✗ guardrail " + it.title
+			if it.body != "" {
+				text += ": " + it.body
+			}
+			title(text, styleRed)
+		} else {
+			title("✓ guardrail "+it.title, styleGreen)
+		}
+	case itemWaiting:
+		text := "⏸ waiting for human input"
+		if it.title != "" {
+			text += " (" + it.title + ")"
+		}
+		title(text, styleYellow)
+	case itemError:
+		text := "✗ error"
+		if it.title != "" {
+			text += " in " + it.title
+		}
+		title(text+": "+it.body, styleRed)
+	case itemOutput:
+		title(fold+"output", styleGreen+styleBold)
+		if it.expanded {
+			detail("", prettyText(it.body))
+		}
+	default:
+		title(fold+"["+it.title+"]", styleDim)
+		if it.expanded {
+			detail("", prettyText(it.body))
+		}
+	}
+	return out
+}
+
+// pretty renders a raw payload for the expanded view: strings as their text,
+// anything else as indented JSON.
+func pretty(v agent.RawValue) string {
+	if len(v) == 0 {
+		return "(none)"
+	}
+	var text string
+	if json.Unmarshal(v, &text) == nil {
+		return text
+	}
+	return prettyText(string(v))
+}
+
+func prettyText(s string) string {
+	var buf bytes.Buffer
+	if json.Indent(&buf, []byte(s), "", "  ") != nil {
+		return s
+	}
+	return buf.String()
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " …"
+	}
+	return s
+}
+
+// wrap hard-wraps s into rows of at most width runes; a blank s stays one row.
+func wrap(s string, width int) []string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return []string{s}
+	}
+	var out []string
+	runes := []rune(s)
+	for len(runes) > width {
+		out = append(out, string(runes[:width]))
+		runes = runes[width:]
+	}
+	return append(out, string(runes))
+}
+
+// fit cuts or pads s to exactly width runes so each frame overwrites the last.
+func fit(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		return string([]rune(s)[:width])
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+func styled(s, style string) string {
+	if style == styleNone {
+		return s
+	}
+	return style + s + styleReset
+}
@@ -0,0 +1,368 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package workflowdef reads Conductor workflow definitions structurally: it walks
+// their task trees and compares two definitions task by task.
+package workflowdef
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+// Keys of a workflow or task definition that hold nested task lists.
+const (
+	keyTasks         = "tasks"
+	keyForkTasks     = "forkTasks"
+	keyDecisionCases = "decisionCases"
+	keyDefaultCase   = "defaultCase"
+	keyLoopOver      = "loopOver"
+	keyRef           = "taskReferenceName"
+	keyType          = "type"
+	keyName          = "name"
+)
+
+// versionLatest names the newest version in a workflow reference.
+const versionLatest = "latest"
+
+// nestedTaskKeys hold a task's child tasks, which are compared as tasks of their
+// own rather than as fields of their parent.
+var nestedTaskKeys = map[string]bool{keyForkTasks: true, keyDecisionCases: true, keyDefaultCase: true, keyLoopOver: true}
+
+// serverManagedKeys are jsondiff.ServerManagedKeys, ignored at any depth.
+var serverManagedKeys = map[string]bool{}
+
+func init() {
+	for _, k := range jsondiff.ServerManagedKeys {
+		serverManagedKeys[k] = true
+	}
+}
+
+// Task is one task of a definition, found wherever it is nested. Container names
+// the list holding it — "tasks" at the top level, else "<parentRef>.forkTasks[i]",
+// "<parentRef>.decisionCases[case]", "<parentRef>.defaultCase" or
+// "<parentRef>.loopOver" — and Index is its position there.
+type Task struct {
+	Ref       string
+	Type      string
+	Name      string
+	Container string
+	Index     int
+	Fields    map[string]json.RawMessage
+}
+
+// Location is where the task sits, e.g. "tasks[2]".
+func (t Task) Location() string {
+	return fmt.Sprintf("%s[%d]", t.Container, t.Index)
+}
+
+// Tasks returns every task in def in document order, parents before their
+// children.
+func Tasks(def json.RawMessage) ([]Task, error) {
+	var wf map[string]json.RawMessage
+	if err := json.Unmarshal(def, &wf); err != nil {
+		return nil, fmt.Errorf("workflow definition is not a JSON object: %w", err)
+	}
+	var tasks []Task
+	if err := collectTasks(keyTasks, wf[keyTasks], &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func collectTasks(container string, list json.RawMessage, out *[]Task) error {
+	if jsondiff.IsAbsent(list) {
+		return nil
+	}
+	var entries []map[string]json.RawMessage
+	if err := json.Unmarshal(list, &entries); err != nil {
+		return fmt.Errorf("%s is not a list of tasks: %w", container, err)
+	}
+	for i, fields := range entries {
+		t := Task{Container: container, Index: i, Fields: fields}
+		_ = json.Unmarshal(fields[keyRef], &t.Ref)
+		_ = json.Unmarshal(fields[keyType], &t.Type)
+		_ = json.Unmarshal(fields[keyName], &t.Name)
+		if t.Ref == "" {
+			t.Ref = fmt.Sprintf("%s[%d]", container, i)
+		}
+		*out = append(*out, t)
+		if err := collectChildren(t, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func collectChildren(t Task, out *[]Task) error {
+	if raw := t.Fields[keyForkTasks]; !jsondiff.IsAbsent(raw) {
+		var branches []json.RawMessage
+		if err := json.Unmarshal(raw, &branches); err != nil {
+			return fmt.Errorf("%s.%s is not a list of branches: %w", t.Ref, keyForkTasks, err)
+		}
+		for i, b := range branches {
+			if err := collectTasks(fmt.Sprintf("%s.%s[%d]", t.Ref, keyForkTasks, i), b, out); err != nil {
+				return err
+			}
+		}
+	}
+	if raw := t.Fields[keyDecisionCases]; !jsondiff.IsAbsent(raw) {
+		var cases map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &cases); err != nil {
+			return fmt.Errorf("%s.%s is not a map of cases: %w", t.Ref, keyDecisionCases, err)
+		}
+		for _, c := range sortedKeys(cases) {
+			if err := collectTasks(fmt.Sprintf("%s.%s[%s]", t.Ref, keyDecisionCases, c), cases[c], out); err != nil {
+				return err
+			}
+		}
+	}
+	for _, k := range []string{keyDefaultCase, keyLoopOver} {
+		if err := collectTasks(t.Ref+"."+k, t.Fields[k], out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Diff compares two workflow definitions semantically: workflow-level fields, then
+// tasks matched by taskReferenceName wherever they are nested. A task is moved
+// when its container changed, or when it is out of order relative to the other
+// tasks its container kept. Field changes are reported per task: a change's Task
+// is the taskReferenceName, and its Path is dotted through nested objects
+// ("inputParameters.url").
+func Diff(from, to json.RawMessage) ([]jsondiff.Change, error) {
+	var a, b map[string]json.RawMessage
+	if err := json.Unmarshal(from, &a); err != nil {
+		return nil, fmt.Errorf("old definition is not a JSON object: %w", err)
+	}
+	if err := json.Unmarshal(to, &b); err != nil {
+		return nil, fmt.Errorf("new definition is not a JSON object: %w", err)
+	}
+	oldTasks, err := Tasks(from)
+	if err != nil {
+		return nil, err
+	}
+	newTasks, err := Tasks(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := diffFields("", "", a, b, map[string]bool{keyTasks: true})
+
+	oldByRef := indexTasks(oldTasks)
+	newByRef := indexTasks(newTasks)
+	for _, t := range oldTasks {
+		if _, ok := newByRef[t.Ref]; !ok {
+			changes = append(changes, jsondiff.Change{Task: t.Ref, Kind: jsondiff.Removed, Old: describe(t)})
+		}
+	}
+	moved := movedTasks(oldTasks, newTasks, oldByRef)
+	for _, t := range newTasks {
+		old, ok := oldByRef[t.Ref]
+		if !ok {
+			changes = append(changes, jsondiff.Change{Task: t.Ref, Kind: jsondiff.Added, New: describe(t)})
+			continue
+		}
+		if moved[t.Ref] {
+			changes = append(changes, jsondiff.Change{Task: t.Ref, Kind: jsondiff.Moved, Old: old.Location(), New: t.Location()})
+		}
+		changes = append(changes, diffFields(t.Ref, "", old.Fields, t.Fields, nestedTaskKeys)...)
+	}
+	return changes, nil
+}
+
+// DiffFields compares two definitions that hold no tasks, such as task definitions
+// or schedules, field by field as Diff compares workflow-level fields.
+func DiffFields(from, to json.RawMessage) ([]jsondiff.Change, error) {
+	var a, b map[string]json.RawMessage
+	if err := json.Unmarshal(from, &a); err != nil {
+		return nil, fmt.Errorf("old definition is not a JSON object: %w", err)
+	}
+	if err := json.Unmarshal(to, &b); err != nil {
+		return nil, fmt.Errorf("new definition is not a JSON object: %w", err)
+	}
+	return diffFields("", "", a, b, nil), nil
+}
+
+// movedTasks finds the tasks present in both definitions that changed container,
+// or whose order among their container's common tasks changed. Within a container,
+// the tasks on the longest common subsequence stayed put; the rest moved.
+func movedTasks(oldTasks, newTasks []Task, oldByRef map[string]Task) map[string]bool {
+	moved := map[string]bool{}
+	oldOrder := map[string][]string{}
+	newOrder := map[string][]string{}
+	for _, t := range newTasks {
+		old, ok := oldByRef[t.Ref]
+		if !ok {
+			continue
+		}
+		if old.Container != t.Container {
+			moved[t.Ref] = true
+			continue
+		}
+		newOrder[t.Container] = append(newOrder[t.Container], t.Ref)
+	}
+	kept := map[string]bool{}
+	for _, refs := range newOrder {
+		for _, r := range refs {
+			kept[r] = true
+		}
+	}
+	for _, t := range oldTasks {
+		if kept[t.Ref] {
+			oldOrder[t.Container] = append(oldOrder[t.Container], t.Ref)
+		}
+	}
+	for container, refs := range newOrder {
+		stayed := commonSubsequence(oldOrder[container], refs)
+		for _, r := range refs {
+			if !stayed[r] {
+				moved[r] = true
+			}
+		}
+	}
+	return moved
+}
+
+// commonSubsequence returns the members of a longest common subsequence of a and b.
+func commonSubsequence(a, b []string) map[string]bool {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	in := map[string]bool{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			in[a[i]] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return in
+}
+
+// diffFields compares two objects key by key, recursing into nested objects so a
+// change is reported at the field that changed. Keys in skip are ignored at this
+// level; server-managed keys are ignored at every level.
+func diffFields(task, prefix string, a, b map[string]json.RawMessage, skip map[string]bool) []jsondiff.Change {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	var changes []jsondiff.Change
+	for _, k := range sortedKeys(keys) {
+		if skip[k] || serverManagedKeys[k] {
+			continue
+		}
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		va, vb := a[k], b[k]
+		var oa, ob map[string]json.RawMessage
+		if json.Unmarshal(va, &oa) == nil && json.Unmarshal(vb, &ob) == nil && oa != nil && ob != nil {
+			changes = append(changes, diffFields(task, path, oa, ob, nil)...)
+			continue
+		}
+		if c, ok := jsondiff.Compare(va, vb); ok {
+			c.Task, c.Path = task, path
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// describe summarises a whole task for an added or removed change.
+func describe(t Task) string {
+	s := t.Type
+	if t.Name != "" && t.Name != t.Ref {
+		s = strings.TrimSpace(s + " " + t.Name)
+	}
+	return fmt.Sprintf("%s at %s", s, t.Location())
+}
+
+func indexTasks(tasks []Task) map[string]Task {
+	m := make(map[string]Task, len(tasks))
+	for _, t := range tasks {
+		m[t.Ref] = t
+	}
+	return m
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParseRef splits "name", "name@version" or "profile:name@version" into its parts.
+// version is nil for the latest, which "name@latest" also names; profile is "" for
+// the active profile.
+func ParseRef(s string) (profile, name string, version *int, err error) {
+	rest := s
+	if i := strings.Index(rest, ":"); i >= 0 {
+		profile, rest = rest[:i], rest[i+1:]
+		if profile == "" {
+			return "", "", nil, fmt.Errorf("invalid workflow reference %q: empty profile", s)
+		}
+	}
+	name = rest
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		name = rest[:i]
+		if rest[i+1:] == versionLatest {
+			return profile, name, nil, checkName(s, name)
+		}
+		v, convErr := strconv.Atoi(rest[i+1:])
+		if convErr != nil || v < 1 {
+			return "", "", nil, fmt.Errorf("invalid workflow reference %q: version must be a positive number or %s", s, versionLatest)
+		}
+		version = &v
+	}
+	if err := checkName(s, name); err != nil {
+		return "", "", nil, err
+	}
+	return profile, name, version, nil
+}
+
+func checkName(ref, name string) error {
+	if name == "" {
+		return fmt.Errorf("invalid workflow reference %q: empty name", ref)
+	}
+	return nil
+}
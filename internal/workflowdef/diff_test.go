@@ -0,0 +1,125 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package workflowdef
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/conductor-oss/conductor-cli/internal/jsondiff"
+)
+
+const baseWorkflow = `{
+  "name": "order", "version": 1, "timeoutSeconds": 60, "updateTime": 1,
+  "tasks": [
+    {"name": "fetch", "taskReferenceName": "fetch", "type": "SIMPLE",
+     "inputParameters": {"url": "http://a", "id": "${workflow.input.id}"}, "retryCount": 3},
+    {"name": "route", "taskReferenceName": "route", "type": "SWITCH",
+     "decisionCases": {"big": [{"name": "review", "taskReferenceName": "review", "type": "HUMAN"}]},
+     "defaultCase": [{"name": "ship", "taskReferenceName": "ship", "type": "SIMPLE"}]},
+    {"name": "notify", "taskReferenceName": "notify", "type": "SIMPLE"},
+    {"name": "audit", "taskReferenceName": "audit", "type": "SIMPLE"}
+  ]
+}`
+
+func TestDiffReportsTaskAndFieldChanges(t *testing.T) {
+	to := `{
+  "name": "order", "version": 2, "timeoutSeconds": 120, "updateTime": 2,
+  "tasks": [
+    {"name": "fetch", "taskReferenceName": "fetch", "type": "SIMPLE",
+     "inputParameters": {"url": "http://b", "id": "${workflow.input.id}"}, "retryCount": 5},
+    {"name": "route", "taskReferenceName": "route", "type": "SWITCH",
+     "decisionCases": {"big": [
+       {"name": "review", "taskReferenceName": "review", "type": "HUMAN"},
+       {"name": "ship", "taskReferenceName": "ship", "type": "SIMPLE"}]},
+     "defaultCase": []},
+    {"name": "audit", "taskReferenceName": "audit", "type": "SIMPLE"},
+    {"name": "notify", "taskReferenceName": "notify", "type": "SIMPLE"},
+    {"name": "charge", "taskReferenceName": "charge_ref", "type": "SIMPLE"}
+  ]
+}`
+	changes, err := Diff([]byte(baseWorkflow), []byte(to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []jsondiff.Change{
+		{Path: "timeoutSeconds", Kind: jsondiff.Changed, Old: "60", New: "120"},
+		{Path: "version", Kind: jsondiff.Changed, Old: "1", New: "2"},
+		{Task: "fetch", Path: "inputParameters.url", Kind: jsondiff.Changed, Old: "http://a", New: "http://b"},
+		{Task: "fetch", Path: "retryCount", Kind: jsondiff.Changed, Old: "3", New: "5"},
+		{Task: "ship", Kind: jsondiff.Moved, Old: "route.defaultCase[0]", New: "route.decisionCases[big][1]"},
+		{Task: "notify", Kind: jsondiff.Moved, Old: "tasks[2]", New: "tasks[3]"},
+		{Task: "charge_ref", Kind: jsondiff.Added, New: "SIMPLE charge at tasks[4]"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes =\n%+v\nwant\n%+v", changes, want)
+	}
+}
+
+func TestDiffIdenticalAndRemoved(t *testing.T) {
+	changes, err := Diff([]byte(baseWorkflow), []byte(baseWorkflow))
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("identical: changes = %+v, err = %v", changes, err)
+	}
+
+	to := `{"name": "order", "version": 1, "timeoutSeconds": 60, "tasks": [
+    {"name": "fetch", "taskReferenceName": "fetch", "type": "SIMPLE",
+     "inputParameters": {"url": "http://a", "id": "${workflow.input.id}"}, "retryCount": 3}]}`
+	changes, err = Diff([]byte(baseWorkflow), []byte(to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var removed []string
+	for _, c := range changes {
+		if c.Kind != jsondiff.Removed {
+			t.Errorf("unexpected change %+v", c)
+		}
+		removed = append(removed, c.Task)
+	}
+	if want := []string{"route", "review", "ship", "notify", "audit"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %q, want %q", removed, want)
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	v := 3
+	tests := []struct {
+		in      string
+		profile string
+		name    string
+		version *int
+		wantErr bool
+	}{
+		{in: "order", name: "order"},
+		{in: "order@3", name: "order", version: &v},
+		{in: "prod:order@3", profile: "prod", name: "order", version: &v},
+		{in: "prod:order", profile: "prod", name: "order"},
+		{in: "order@latest", name: "order"},
+		{in: "order@v2", wantErr: true},
+		{in: ":order", wantErr: true},
+		{in: "prod:@2", wantErr: true},
+	}
+	for _, tt := range tests {
+		profile, name, version, err := ParseRef(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseRef(%q) should fail", tt.in)
+			}
+			continue
+		}
+		if err != nil || profile != tt.profile || name != tt.name || !reflect.DeepEqual(version, tt.version) {
+			t.Errorf("ParseRef(%q) = %q, %q, %v, %v", tt.in, profile, name, version, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package workflowdef
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/conductor-oss/conductor-cli/internal/transport"
+)
+
+// pathWorkflowDef is the metadata endpoint for one workflow definition; the name
+// is appended and the version passed as a query parameter.
+const pathWorkflowDef = "/metadata/workflow/"
+
+// Fetch returns a stored workflow definition from the server t talks to. A nil
+// version means the latest.
+func Fetch(ctx context.Context, t transport.Config, name string, version *int) (json.RawMessage, error) {
+	path := pathWorkflowDef + url.PathEscape(name)
+	if version != nil {
+		q := url.Values{}
+		q.Set("version", strconv.Itoa(*version))
+		path += "?" + q.Encode()
+	}
+	var def json.RawMessage
+	if err := t.DoJSON(ctx, http.MethodGet, path, nil, &def); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
@@ -0,0 +1,601 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package workflowlint checks workflow definition files offline for mistakes the
+// server accepts but that fail or misbehave at run time: task reference problems,
+// expressions naming tasks or inputs that do not exist, SWITCH branches that can
+// never run, DO_WHILE conditions on unknown values, forks without a join, and
+// timeouts that cannot be reached. Checks that need the server, such as whether a
+// sub-workflow exists, run only when a Resolver is given. Every diagnostic
+// carries a JSON pointer to the field it refers to, and its line and column.
+package workflowlint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity grades a diagnostic. Errors fail linting; warnings do not.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule identifiers, as reported in diagnostics and SARIF.
+const (
+	RuleSyntax            = "syntax"
+	RuleMissingRef        = "missing-task-ref"
+	RuleDuplicateRef      = "duplicate-task-ref"
+	RuleUnknownReference  = "unknown-reference"
+	RuleUnknownInput      = "unknown-workflow-input"
+	RuleUnreachableBranch = "unreachable-switch-branch"
+	RuleLoopCondition     = "loop-condition-reference"
+	RuleForkWithoutJoin   = "fork-without-join"
+	RuleSubWorkflow       = "sub-workflow"
+	RuleTimeout           = "timeout"
+)
+
+// Rule describes one check.
+type Rule struct {
+	ID          string
+	Description string
+}
+
+// Rules lists every check, in the order they run.
+var Rules = []Rule{
+	{RuleSyntax, "The file parses as a workflow definition"},
+	{RuleMissingRef, "Every task has a taskReferenceName"},
+	{RuleDuplicateRef, "No two tasks share a taskReferenceName"},
+	{RuleUnknownReference, "Expressions reference tasks that exist in the workflow"},
+	{RuleUnknownInput, "Expressions reference workflow inputs the workflow declares"},
+	{RuleUnreachableBranch, "SWITCH cases can be reached"},
+	{RuleLoopCondition, "DO_WHILE loop conditions reference the loop's inputs and tasks"},
+	{RuleForkWithoutJoin, "Every FORK_JOIN is followed by a JOIN on its own branches"},
+	{RuleSubWorkflow, "SUB_WORKFLOW tasks name a workflow that exists"},
+	{RuleTimeout, "No task times out later than its workflow"},
+}
+
+// Diagnostic is one problem found in a file. Pointer is a JSON pointer (RFC 6901)
+// to the offending field; Line and Column are 1-based.
+type Diagnostic struct {
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Pointer  string   `json:"pointer"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	s := fmt.Sprintf("%d:%d: %s: %s [%s]", d.Line, d.Column, d.Severity, d.Message, d.Rule)
+	if d.Pointer != "" {
+		s += " (" + d.Pointer + ")"
+	}
+	return s
+}
+
+// HasErrors reports whether any diagnostic is an error.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolver answers what linting needs a server for. A nil version means the
+// latest.
+type Resolver interface {
+	WorkflowExists(ctx context.Context, name string, version *int) (bool, error)
+	TaskTimeout(ctx context.Context, taskName string) (seconds int64, found bool, err error)
+}
+
+// Options tunes linting. Without a Resolver, sub-workflows are only checked for
+// a name and task timeouts only where a task carries its definition inline.
+type Options struct {
+	Resolver Resolver
+}
+
+// Workflow and task keys the checks read.
+const (
+	keyTasks            = "tasks"
+	keyName             = "name"
+	keyType             = "type"
+	keyRef              = "taskReferenceName"
+	keyInputParameters  = "inputParameters"
+	keyTimeoutSeconds   = "timeoutSeconds"
+	keyForkTasks        = "forkTasks"
+	keyDecisionCases    = "decisionCases"
+	keyDefaultCase      = "defaultCase"
+	keyLoopOver         = "loopOver"
+	keyLoopCondition    = "loopCondition"
+	keyJoinOn           = "joinOn"
+	keyEvaluatorType    = "evaluatorType"
+	keyExpression       = "expression"
+	keyCaseValueParam   = "caseValueParam"
+	keySubWorkflowParam = "subWorkflowParam"
+	keyVersion          = "version"
+	keyWorkflowDef      = "workflowDefinition"
+	keyTaskDefinition   = "taskDefinition"
+)
+
+// Task types with checks of their own.
+const (
+	typeSimple          = "SIMPLE"
+	typeSwitch          = "SWITCH"
+	typeDecision        = "DECISION"
+	typeDoWhile         = "DO_WHILE"
+	typeForkJoin        = "FORK_JOIN"
+	typeForkJoinDynamic = "FORK_JOIN_DYNAMIC"
+	typeJoin            = "JOIN"
+	typeDynamic         = "DYNAMIC"
+	typeSubWorkflow     = "SUB_WORKFLOW"
+
+	evaluatorValueParam = "value-param"
+)
+
+// Expression roots that are not task references.
+const (
+	rootWorkflow = "workflow"
+	rootInput    = "input"
+)
+
+// builtinRoots are expression roots the server resolves itself.
+var builtinRoots = map[string]bool{rootWorkflow: true, "CPEWF_TASK_ID": true}
+
+var (
+	// expressionPattern finds ${...} expressions in a string value.
+	expressionPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+	// loopValuePattern finds the $.name values a loop condition reads.
+	loopValuePattern = regexp.MustCompile(`\$\.([A-Za-z_][A-Za-z0-9_]*)`)
+	// yamlErrorLine pulls the line number out of a YAML syntax error.
+	yamlErrorLine = regexp.MustCompile(`^line (\d+): `)
+)
+
+// Lint checks a YAML or JSON file holding a workflow definition, or a list of
+// them, and returns its diagnostics ordered by position. A syntax error is the
+// only diagnostic when the file does not parse. An error means the Resolver failed.
+func Lint(ctx context.Context, data []byte, opts Options) ([]Diagnostic, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		d := Diagnostic{Line: 1, Column: 1, Severity: SeverityError, Rule: RuleSyntax, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := yamlErrorLine.FindStringSubmatch(d.Message); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Message = strings.TrimPrefix(d.Message, m[0])
+		}
+		return []Diagnostic{d}, nil
+	}
+	if len(doc.Content) == 0 {
+		return []Diagnostic{{Line: 1, Column: 1, Severity: SeverityError, Rule: RuleSyntax, Message: "file is empty"}}, nil
+	}
+
+	l := &linter{ctx: ctx, opts: opts}
+	root := doc.Content[0]
+	workflows := []*yaml.Node{root}
+	prefix := func(int) string { return "" }
+	if root.Kind == yaml.SequenceNode {
+		workflows = root.Content
+		prefix = func(i int) string { return "/" + strconv.Itoa(i) }
+	}
+	for i, wf := range workflows {
+		if err := l.workflow(wf, prefix(i)); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(l.diags, func(i, j int) bool {
+		if l.diags[i].Line != l.diags[j].Line {
+			return l.diags[i].Line < l.diags[j].Line
+		}
+		return l.diags[i].Column < l.diags[j].Column
+	})
+	return l.diags, nil
+}
+
+type linter struct {
+	ctx   context.Context
+	opts  Options
+	diags []Diagnostic
+}
+
+func (l *linter) report(n *yaml.Node, sev Severity, rule, pointer, format string, args ...any) {
+	l.diags = append(l.diags, Diagnostic{Line: n.Line, Column: n.Column, Severity: sev, Rule: rule,
+		Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+// task is one task of a workflow, wherever it is nested.
+type task struct {
+	node    *yaml.Node
+	pointer string
+	ref     string
+	typ     string
+}
+
+func (l *linter) workflow(n *yaml.Node, pointer string) error {
+	if n.Kind != yaml.MappingNode || lookup(n, keyTasks) == nil {
+		l.report(n, SeverityError, RuleSyntax, pointer, "not a workflow definition: expected an object with %q", keyTasks)
+		return nil
+	}
+	var tasks []task
+	l.taskList(lookup(n, keyTasks), pointer+"/"+keyTasks, &tasks)
+
+	refs := l.checkRefs(tasks)
+	l.checkExpressions(n, pointer, tasks, refs)
+	for _, t := range tasks {
+		switch t.typ {
+		case typeSwitch, typeDecision:
+			l.checkSwitch(t)
+		case typeDoWhile:
+			l.checkLoopCondition(t)
+		}
+	}
+	if err := l.checkSubWorkflows(tasks); err != nil {
+		return err
+	}
+	if err := l.checkTimeouts(n, tasks); err != nil {
+		return err
+	}
+	// A workflow defined inline runs in a scope of its own, so it is linted as one.
+	for _, t := range tasks {
+		if def := inlineWorkflow(t); def != nil {
+			if err := l.workflow(def, t.pointer+"/"+keySubWorkflowParam+"/"+keyWorkflowDef); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// inlineWorkflow returns the workflow definition a SUB_WORKFLOW task carries
+// inline, or nil.
+func inlineWorkflow(t task) *yaml.Node {
+	if t.typ != typeSubWorkflow {
+		return nil
+	}
+	return lookup(lookup(t.node, keySubWorkflowParam), keyWorkflowDef)
+}
+
+// taskList collects the tasks of a list and their nested tasks, parents first,
+// and checks that each fork in the list is joined.
+func (l *linter) taskList(list *yaml.Node, pointer string, out *[]task) {
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, n := range list.Content {
+		if n.Kind != yaml.MappingNode {
+			continue
+		}
+		t := task{node: n, pointer: pointer + "/" + strconv.Itoa(i), ref: scalar(n, keyRef), typ: scalar(n, keyType)}
+		if t.typ == "" {
+			t.typ = typeSimple
+		}
+		*out = append(*out, t)
+		if t.typ == typeForkJoin || t.typ == typeForkJoinDynamic {
+			var next *yaml.Node
+			if i+1 < len(list.Content) {
+				next = list.Content[i+1]
+			}
+			l.checkJoin(t, next, pointer+"/"+strconv.Itoa(i+1))
+		}
+
+		if forks := lookup(n, keyForkTasks); forks != nil && forks.Kind == yaml.SequenceNode {
+			for j, branch := range forks.Content {
+				l.taskList(branch, fmt.Sprintf("%s/%s/%d", t.pointer, keyForkTasks, j), out)
+			}
+		}
+		if cases := lookup(n, keyDecisionCases); cases != nil && cases.Kind == yaml.MappingNode {
+			for j := 0; j+1 < len(cases.Content); j += 2 {
+				l.taskList(cases.Content[j+1], t.pointer+"/"+keyDecisionCases+"/"+escape(cases.Content[j].Value), out)
+			}
+		}
+		l.taskList(lookup(n, keyDefaultCase), t.pointer+"/"+keyDefaultCase, out)
+		l.taskList(lookup(n, keyLoopOver), t.pointer+"/"+keyLoopOver, out)
+	}
+}
+
+// checkRefs reports missing and duplicate task references and returns the
+// references in use.
+func (l *linter) checkRefs(tasks []task) map[string]bool {
+	first := map[string]string{}
+	for _, t := range tasks {
+		if t.ref == "" {
+			l.report(t.node, SeverityError, RuleMissingRef, t.pointer, "%s task %q has no %s", t.typ, scalar(t.node, keyName), keyRef)
+			continue
+		}
+		if prev, dup := first[t.ref]; dup {
+			l.report(lookup(t.node, keyRef), SeverityError, RuleDuplicateRef, t.pointer+"/"+keyRef,
+				"duplicate %s %q (first at %s)", keyRef, t.ref, prev)
+			continue
+		}
+		first[t.ref] = t.pointer
+	}
+	refs := map[string]bool{}
+	for ref := range first {
+		refs[ref] = true
+	}
+	return refs
+}
+
+// checkExpressions reports ${...} expressions, anywhere in the workflow outside
+// its inline sub-workflows, whose root is neither a task reference nor the
+// workflow, and workflow inputs the workflow does not declare. A workflow that
+// creates tasks at run time may reference them, so unknown task references are
+// only warnings there; inputs are checked only when the workflow declares some.
+func (l *linter) checkExpressions(wf *yaml.Node, pointer string, tasks []task, refs map[string]bool) {
+	refSeverity := SeverityError
+	for _, t := range tasks {
+		if t.typ == typeDynamic || t.typ == typeForkJoinDynamic {
+			refSeverity = SeverityWarning
+		}
+	}
+	var inputs []string
+	if declared := lookup(wf, keyInputParameters); declared != nil && declared.Kind == yaml.SequenceNode {
+		for _, in := range declared.Content {
+			inputs = append(inputs, in.Value)
+		}
+	}
+
+	inline := map[*yaml.Node]bool{}
+	for _, t := range tasks {
+		if def := inlineWorkflow(t); def != nil {
+			inline[def] = true
+		}
+	}
+	walkStrings(wf, pointer, inline, func(n *yaml.Node, p string) {
+		for _, m := range expressionPattern.FindAllStringSubmatch(n.Value, -1) {
+			parts := splitExpression(m[1])
+			switch {
+			case len(parts) == 0:
+			case parts[0] == rootWorkflow:
+				if len(parts) > 2 && parts[1] == rootInput && len(inputs) > 0 && !slices.Contains(inputs, parts[2]) {
+					l.report(n, SeverityWarning, RuleUnknownInput, p,
+						"%s references workflow input %q, which is not in the workflow's %s", m[0], parts[2], keyInputParameters)
+				}
+			case !refs[parts[0]] && !builtinRoots[parts[0]]:
+				l.report(n, refSeverity, RuleUnknownReference, p, "%s references %q, which is not a task in this workflow", m[0], parts[0])
+			}
+		}
+	})
+}
+
+// splitExpression splits an expression path such as "ref.output.items[0].id"
+// into its dotted parts, dropping indexes.
+func splitExpression(expr string) []string {
+	var parts []string
+	for _, p := range strings.Split(strings.TrimSpace(expr), ".") {
+		if i := strings.Index(p, "["); i >= 0 {
+			p = p[:i]
+		}
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// checkSwitch reports the cases of a SWITCH or DECISION that can never run
+// because it switches on an input parameter with a constant value.
+func (l *linter) checkSwitch(t task) {
+	param := scalar(t.node, keyCaseValueParam)
+	if t.typ == typeSwitch {
+		if ev := scalar(t.node, keyEvaluatorType); ev != "" && ev != evaluatorValueParam {
+			return
+		}
+		param = scalar(t.node, keyExpression)
+	}
+	value := lookup(lookup(t.node, keyInputParameters), param)
+	if param == "" || value == nil || value.Kind != yaml.ScalarNode || strings.Contains(value.Value, "${") {
+		return
+	}
+	matched := false
+	if cases := lookup(t.node, keyDecisionCases); cases != nil && cases.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(cases.Content); i += 2 {
+			key := cases.Content[i]
+			if key.Value == value.Value {
+				matched = true
+				continue
+			}
+			l.report(key, SeverityWarning, RuleUnreachableBranch, t.pointer+"/"+keyDecisionCases+"/"+escape(key.Value),
+				"case %q of %s is unreachable: %s is always %q", key.Value, t.ref, param, value.Value)
+		}
+	}
+	if def := lookup(t.node, keyDefaultCase); matched && def != nil && len(def.Content) > 0 {
+		l.report(def, SeverityWarning, RuleUnreachableBranch, t.pointer+"/"+keyDefaultCase,
+			"the default case of %s is unreachable: %s is always %q", t.ref, param, value.Value)
+	}
+}
+
+// checkLoopCondition reports the $.name values a DO_WHILE condition reads that
+// the loop does not provide: the condition sees the loop's input parameters, its
+// own output and the outputs of the tasks it runs, by reference.
+func (l *linter) checkLoopCondition(t task) {
+	cond := lookup(t.node, keyLoopCondition)
+	if cond == nil || cond.Kind != yaml.ScalarNode {
+		return
+	}
+	known := map[string]bool{t.ref: true}
+	if in := lookup(t.node, keyInputParameters); in != nil && in.Kind == yaml.MappingNode {
+		for i := 0; i < len(in.Content); i += 2 {
+			known[in.Content[i].Value] = true
+		}
+	}
+	var body []task
+	(&linter{}).taskList(lookup(t.node, keyLoopOver), "", &body)
+	for _, b := range body {
+		known[b.ref] = true
+	}
+	var reported []string
+	for _, m := range loopValuePattern.FindAllStringSubmatch(cond.Value, -1) {
+		if name := m[1]; !known[name] && !slices.Contains(reported, name) {
+			reported = append(reported, name)
+			l.report(cond, SeverityError, RuleLoopCondition, t.pointer+"/"+keyLoopCondition,
+				"loop condition of %s reads $.%s, which is neither an input parameter of the loop nor a task in it", t.ref, name)
+		}
+	}
+}
+
+// checkJoin reports a fork not followed by a JOIN, and a JOIN waiting on tasks
+// that are not on the fork's branches.
+func (l *linter) checkJoin(fork task, next *yaml.Node, nextPointer string) {
+	if next == nil || scalar(next, keyType) != typeJoin {
+		l.report(fork.node, SeverityError, RuleForkWithoutJoin, fork.pointer, "%s %s is not followed by a %s", fork.typ, fork.ref, typeJoin)
+		return
+	}
+	joinOn := lookup(next, keyJoinOn)
+	if fork.typ != typeForkJoin || joinOn == nil || joinOn.Kind != yaml.SequenceNode {
+		return
+	}
+	var branches []task
+	forks := lookup(fork.node, keyForkTasks)
+	if forks != nil && forks.Kind == yaml.SequenceNode {
+		for _, b := range forks.Content {
+			(&linter{}).taskList(b, "", &branches)
+		}
+	}
+	for i, ref := range joinOn.Content {
+		if !slices.ContainsFunc(branches, func(b task) bool { return b.ref == ref.Value }) {
+			l.report(ref, SeverityError, RuleForkWithoutJoin, fmt.Sprintf("%s/%s/%d", nextPointer, keyJoinOn, i),
+				"%s %s waits on %q, which is not on a branch of %s", typeJoin, scalar(next, keyRef), ref.Value, fork.ref)
+		}
+	}
+}
+
+// checkSubWorkflows reports SUB_WORKFLOW tasks without a workflow to start and,
+// with a Resolver, those naming a workflow the server does not have. Names
+// computed at run time are not checked.
+func (l *linter) checkSubWorkflows(tasks []task) error {
+	for _, t := range tasks {
+		if t.typ != typeSubWorkflow {
+			continue
+		}
+		param := lookup(t.node, keySubWorkflowParam)
+		name := scalar(param, keyName)
+		switch {
+		case lookup(param, keyWorkflowDef) != nil, strings.Contains(name, "${"):
+		case name == "":
+			l.report(t.node, SeverityError, RuleSubWorkflow, t.pointer, "%s %s does not name a workflow in %s.%s",
+				typeSubWorkflow, t.ref, keySubWorkflowParam, keyName)
+		case l.opts.Resolver != nil:
+			var version *int
+			if v, err := strconv.Atoi(scalar(param, keyVersion)); err == nil {
+				version = &v
+			}
+			ok, err := l.opts.Resolver.WorkflowExists(l.ctx, name, version)
+			if err != nil {
+				return fmt.Errorf("check sub-workflow %q: %w", name, err)
+			}
+			if !ok {
+				label := name
+				if version != nil {
+					label = fmt.Sprintf("%s v%d", name, *version)
+				}
+				l.report(lookup(param, keyName), SeverityError, RuleSubWorkflow, t.pointer+"/"+keySubWorkflowParam+"/"+keyName,
+					"%s %s starts workflow %s, which does not exist on the server", typeSubWorkflow, t.ref, label)
+			}
+		}
+	}
+	return nil
+}
+
+// checkTimeouts reports tasks whose timeout is longer than the workflow's, which
+// the workflow's timeout always cuts short. A task's timeout comes from its
+// inline task definition or, with a Resolver, from its registered one.
+func (l *linter) checkTimeouts(wf *yaml.Node, tasks []task) error {
+	limit, err := strconv.ParseInt(scalar(wf, keyTimeoutSeconds), 10, 64)
+	if err != nil || limit <= 0 {
+		return nil
+	}
+	for _, t := range tasks {
+		at, p := t.node, t.pointer
+		var timeout int64
+		if def := lookup(t.node, keyTaskDefinition); def != nil {
+			if n := lookup(def, keyTimeoutSeconds); n != nil {
+				at, p = n, t.pointer+"/"+keyTaskDefinition+"/"+keyTimeoutSeconds
+				timeout, _ = strconv.ParseInt(n.Value, 10, 64)
+			}
+		} else if t.typ == typeSimple && l.opts.Resolver != nil {
+			name := scalar(t.node, keyName)
+			if name == "" {
+				continue
+			}
+			var found bool
+			if timeout, found, err = l.opts.Resolver.TaskTimeout(l.ctx, name); err != nil {
+				return fmt.Errorf("check task %q: %w", name, err)
+			} else if !found {
+				continue
+			}
+		}
+		if timeout > limit {
+			l.report(at, SeverityWarning, RuleTimeout, p,
+				"task %s times out after %ds, but the workflow's %s is %d", t.ref, timeout, keyTimeoutSeconds, limit)
+		}
+	}
+	return nil
+}
+
+// walkStrings calls fn for every string value under n with its JSON pointer,
+// except those under the nodes in skip.
+func walkStrings(n *yaml.Node, pointer string, skip map[*yaml.Node]bool, fn func(*yaml.Node, string)) {
+	if skip[n] {
+		return
+	}
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Tag == "!!str" {
+			fn(n, pointer)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			walkStrings(n.Content[i+1], pointer+"/"+escape(n.Content[i].Value), skip, fn)
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			walkStrings(c, pointer+"/"+strconv.Itoa(i), skip, fn)
+		}
+	}
+}
+
+// escape encodes a key as a JSON pointer reference token.
+func escape(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// lookup returns the value of key in a mapping node, or nil.
+func lookup(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalar returns the value of a scalar key in a mapping node, or "".
+func scalar(n *yaml.Node, key string) string {
+	if v := lookup(n, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
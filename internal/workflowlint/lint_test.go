@@ -0,0 +1,246 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package workflowlint
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func messages(diags []Diagnostic) []string {
+	out := make([]string, len(diags))
+	for i, d := range diags {
+		out[i] = d.String()
+	}
+	return out
+}
+
+func lint(t *testing.T, src string, opts Options) []string {
+	t.Helper()
+	diags, err := Lint(context.Background(), []byte(src), opts)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	return messages(diags)
+}
+
+func TestLintCleanWorkflow(t *testing.T) {
+	wf := `name: order_flow
+inputParameters: [orderId, childName]
+timeoutSeconds: 600
+tasks:
+  - name: fetch
+    taskReferenceName: fetch_ref
+    inputParameters: {id: "${workflow.input.orderId}"}
+  - name: route
+    taskReferenceName: route_ref
+    type: SWITCH
+    evaluatorType: value-param
+    expression: kind
+    inputParameters: {kind: "${fetch_ref.output.kind}"}
+    decisionCases:
+      digital:
+        - {name: email, taskReferenceName: email_ref, inputParameters: {to: "${fetch_ref.output.customer.email}"}}
+    defaultCase:
+      - {name: ship, taskReferenceName: ship_ref, taskDefinition: {timeoutSeconds: 300}}
+  - name: fan
+    taskReferenceName: fan_ref
+    type: FORK_JOIN
+    forkTasks:
+      - [{name: a, taskReferenceName: a_ref}]
+      - [{name: b, taskReferenceName: b_ref}]
+  - {name: join, taskReferenceName: join_ref, type: JOIN, joinOn: [a_ref, b_ref]}
+  - name: poll
+    taskReferenceName: poll_ref
+    type: DO_WHILE
+    inputParameters: {limit: 3}
+    loopCondition: "if ($.poll_ref['iteration'] < $.limit && $.check_ref['done'] != true) { true; } else { false; }"
+    loopOver:
+      - {name: check, taskReferenceName: check_ref, inputParameters: {task: "${CPEWF_TASK_ID}"}}
+  - name: child
+    taskReferenceName: child_ref
+    type: SUB_WORKFLOW
+    subWorkflowParam: {name: "${workflow.input.childName}"}
+outputParameters: {result: "${child_ref.output}"}
+`
+	if got := lint(t, wf, Options{}); len(got) != 0 {
+		t.Errorf("Lint = %v, want no diagnostics", got)
+	}
+}
+
+func TestLintReportsPointers(t *testing.T) {
+	wf := `name: order_flow
+inputParameters: [orderId]
+timeoutSeconds: 60
+tasks:
+  - name: fetch
+    taskReferenceName: fetch_ref
+    inputParameters: {id: "${workflow.input.orderID}", prev: "${fecth_ref.output}"}
+  - name: fetch
+    taskReferenceName: fetch_ref
+    taskDefinition: {timeoutSeconds: 120}
+  - name: notify
+  - name: route
+    taskReferenceName: route_ref
+    type: SWITCH
+    expression: kind
+    inputParameters: {kind: digital}
+    decisionCases:
+      digital: [{name: email, taskReferenceName: email_ref}]
+      a/b: [{name: post, taskReferenceName: post_ref}]
+    defaultCase: [{name: ship, taskReferenceName: ship_ref}]
+  - name: poll
+    taskReferenceName: poll_ref
+    type: DO_WHILE
+    loopCondition: "$.done == false"
+    loopOver: [{name: check, taskReferenceName: check_ref}]
+  - {name: fan, taskReferenceName: fan_ref, type: FORK_JOIN, forkTasks: [[{name: a, taskReferenceName: a_ref}]]}
+  - {name: child, taskReferenceName: child_ref, type: SUB_WORKFLOW, subWorkflowParam: {version: 1}}
+`
+	got := lint(t, wf, Options{})
+	want := []string{
+		`7:27: warning: ${workflow.input.orderID} references workflow input "orderID", which is not in the workflow's inputParameters [unknown-workflow-input] (/tasks/0/inputParameters/id)`,
+		`7:62: error: ${fecth_ref.output} references "fecth_ref", which is not a task in this workflow [unknown-reference] (/tasks/0/inputParameters/prev)`,
+		`9:24: error: duplicate taskReferenceName "fetch_ref" (first at /tasks/0) [duplicate-task-ref] (/tasks/1/taskReferenceName)`,
+		`10:38: warning: task fetch_ref times out after 120s, but the workflow's timeoutSeconds is 60 [timeout] (/tasks/1/taskDefinition/timeoutSeconds)`,
+		`11:5: error: SIMPLE task "notify" has no taskReferenceName [missing-task-ref] (/tasks/2)`,
+		`19:7: warning: case "a/b" of route_ref is unreachable: kind is always "digital" [unreachable-switch-branch] (/tasks/3/decisionCases/a~1b)`,
+		`20:18: warning: the default case of route_ref is unreachable: kind is always "digital" [unreachable-switch-branch] (/tasks/3/defaultCase)`,
+		`24:20: error: loop condition of poll_ref reads $.done, which is neither an input parameter of the loop nor a task in it [loop-condition-reference] (/tasks/4/loopCondition)`,
+		`26:5: error: FORK_JOIN fan_ref is not followed by a JOIN [fork-without-join] (/tasks/5)`,
+		`27:5: error: SUB_WORKFLOW child_ref does not name a workflow in subWorkflowParam.name [sub-workflow] (/tasks/6)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestLintJoinOnOutsideFork(t *testing.T) {
+	wf := `{
+  "name": "fan",
+  "tasks": [
+    {"name": "fan", "taskReferenceName": "fan_ref", "type": "FORK_JOIN",
+     "forkTasks": [[{"name": "a", "taskReferenceName": "a_ref"}]]},
+    {"name": "join", "taskReferenceName": "join_ref", "type": "JOIN", "joinOn": ["a_ref", "later_ref"]},
+    {"name": "later", "taskReferenceName": "later_ref"}
+  ]
+}`
+	want := []string{
+		`6:91: error: JOIN join_ref waits on "later_ref", which is not on a branch of fan_ref [fork-without-join] (/tasks/1/joinOn/1)`,
+	}
+	if got := lint(t, wf, Options{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint = %q, want %q", got, want)
+	}
+}
+
+func TestLintDynamicTasksDowngradeUnknownReferences(t *testing.T) {
+	wf := `- name: first
+  tasks:
+    - {name: dyn, taskReferenceName: dyn_ref, type: DYNAMIC, dynamicTaskNameParam: t}
+    - {name: use, taskReferenceName: use_ref, inputParameters: {x: "${made_ref.output}"}}
+- name: second
+  tasks:
+    - {name: use, taskReferenceName: use_ref, inputParameters: {x: "${made_ref.output}"}}
+`
+	want := []string{
+		`4:68: warning: ${made_ref.output} references "made_ref", which is not a task in this workflow [unknown-reference] (/0/tasks/1/inputParameters/x)`,
+		`7:68: error: ${made_ref.output} references "made_ref", which is not a task in this workflow [unknown-reference] (/1/tasks/0/inputParameters/x)`,
+	}
+	if got := lint(t, wf, Options{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint = %q, want %q", got, want)
+	}
+}
+
+func TestLintInlineSubWorkflowIsItsOwnScope(t *testing.T) {
+	wf := `name: parent
+inputParameters: [id]
+tasks:
+  - {name: fetch, taskReferenceName: fetch_ref, inputParameters: {id: "${workflow.input.id}"}}
+  - name: child
+    taskReferenceName: child_ref
+    type: SUB_WORKFLOW
+    inputParameters: {orderId: "${fetch_ref.output.orderId}"}
+    subWorkflowParam:
+      name: child
+      workflowDefinition:
+        name: child
+        inputParameters: [orderId]
+        tasks:
+          - {name: ship, taskReferenceName: ship_ref, inputParameters: {id: "${workflow.input.orderId}"}}
+          - {name: log, taskReferenceName: log_ref, inputParameters: {x: "${fetch_ref.output}"}}
+          - {name: dup, taskReferenceName: ship_ref}
+`
+	want := []string{
+		`16:74: error: ${fetch_ref.output} references "fetch_ref", which is not a task in this workflow [unknown-reference] (/tasks/1/subWorkflowParam/workflowDefinition/tasks/1/inputParameters/x)`,
+		`17:44: error: duplicate taskReferenceName "ship_ref" (first at /tasks/1/subWorkflowParam/workflowDefinition/tasks/0) [duplicate-task-ref] (/tasks/1/subWorkflowParam/workflowDefinition/tasks/2/taskReferenceName)`,
+	}
+	if got := lint(t, wf, Options{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint = %q, want %q", got, want)
+	}
+}
+
+func TestLintSyntaxAndShape(t *testing.T) {
+	for _, tc := range []struct{ src, want string }{
+		{"name: x\ntasks: [\n", `2:1: error: did not find expected node content [syntax]`},
+		{"", `1:1: error: file is empty [syntax]`},
+		{"name: x\n", `1:1: error: not a workflow definition: expected an object with "tasks" [syntax]`},
+	} {
+		got := lint(t, tc.src, Options{})
+		if len(got) != 1 || got[0] != tc.want {
+			t.Errorf("Lint(%q) = %q, want [%q]", tc.src, got, tc.want)
+		}
+	}
+}
+
+type fakeResolver struct {
+	workflows map[string]bool
+	timeouts  map[string]int64
+	err       error
+}
+
+func (r fakeResolver) WorkflowExists(_ context.Context, name string, _ *int) (bool, error) {
+	return r.workflows[name], r.err
+}
+
+func (r fakeResolver) TaskTimeout(_ context.Context, name string) (int64, bool, error) {
+	s, ok := r.timeouts[name]
+	return s, ok, r.err
+}
+
+func TestLintOnline(t *testing.T) {
+	wf := `name: parent
+timeoutSeconds: 100
+tasks:
+  - {name: slow, taskReferenceName: slow_ref}
+  - {name: quick, taskReferenceName: quick_ref}
+  - {name: unknown, taskReferenceName: unknown_ref}
+  - {name: child, taskReferenceName: child_ref, type: SUB_WORKFLOW, subWorkflowParam: {name: child, version: 2}}
+  - {name: gone, taskReferenceName: gone_ref, type: SUB_WORKFLOW, subWorkflowParam: {name: gone, version: 2}}
+`
+	r := fakeResolver{workflows: map[string]bool{"child": true}, timeouts: map[string]int64{"slow": 3600, "quick": 10}}
+	want := []string{
+		`4:5: warning: task slow_ref times out after 3600s, but the workflow's timeoutSeconds is 100 [timeout] (/tasks/0)`,
+		`8:92: error: SUB_WORKFLOW gone_ref starts workflow gone v2, which does not exist on the server [sub-workflow] (/tasks/4/subWorkflowParam/name)`,
+	}
+	if got := lint(t, wf, Options{Resolver: r}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint =\n%q\nwant\n%q", got, want)
+	}
+
+	r.err = errors.New("connection refused")
+	if _, err := Lint(context.Background(), []byte(wf), Options{Resolver: r}); err == nil {
+		t.Error("Lint with a failing resolver: want an error")
+	}
+}
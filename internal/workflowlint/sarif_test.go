@@ -0,0 +1,99 @@
+/*
+ * Copyright 2026 Conductor Authors.
+ * <p>
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ * <p>
+ * http://www.apache.org/licenses/LICENSE-2.0
+ * <p>
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package workflowlint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	files := []File{
+		{Path: "flows/order.yaml", Diagnostics: []Diagnostic{
+			{Line: 7, Column: 27, Severity: SeverityWarning, Rule: RuleUnknownInput, Pointer: "/tasks/0/inputParameters/id", Message: "unknown input"},
+		}},
+		{Path: "flows/clean.yaml"},
+		{Path: "flows/broken.yaml", Diagnostics: []Diagnostic{
+			{Line: 3, Column: 1, Severity: SeverityError, Rule: RuleSyntax, Message: "did not find expected node content"},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, "v1.2.3", files); err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name    string `json:"name"`
+					Version string `json:"version"`
+					Rules   []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version %q with %d run(s), want 2.1.0 with 1", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Version != "v1.2.3" || len(run.Tool.Driver.Rules) != len(Rules) {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(run.Results))
+	}
+
+	r := run.Results[0]
+	loc := r.Locations[0]
+	if r.RuleID != RuleUnknownInput || run.Tool.Driver.Rules[r.RuleIndex].ID != RuleUnknownInput || r.Level != "warning" {
+		t.Errorf("result = %+v", r)
+	}
+	if loc.PhysicalLocation.ArtifactLocation.URI != "flows/order.yaml" ||
+		loc.PhysicalLocation.Region.StartLine != 7 || loc.PhysicalLocation.Region.StartColumn != 27 {
+		t.Errorf("physical location = %+v", loc.PhysicalLocation)
+	}
+	if len(loc.LogicalLocations) != 1 || loc.LogicalLocations[0].FullyQualifiedName != "/tasks/0/inputParameters/id" {
+		t.Errorf("logical locations = %+v", loc.LogicalLocations)
+	}
+	if got := run.Results[1]; got.Level != "error" || len(got.Locations[0].LogicalLocations) != 0 {
+		t.Errorf("syntax result = %+v", got)
+	}
+}
@@ -237,7 +237,7 @@ run_bounded() {
     # Poll until terminal, bounded.
     status_val=""
     for _ in $(seq 1 30); do
-        status_val=$(./conductor agent status "$eid" 2>/dev/null | grep '"status"' | cut -d'"' -f4)
+        status_val=$(./conductor agent status "$eid" --json 2>/dev/null | grep '"status"' | cut -d'"' -f4)
         [ "$status_val" != "RUNNING" ] && break
         sleep 2
     done